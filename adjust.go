@@ -428,6 +428,7 @@ func (f *File) adjustCalcChain(dir adjustDirection, num, offset, sheetID int) er
 	if f.CalcChain == nil {
 		return nil
 	}
+	f.setPartModified(defaultXMLPathCalcChain)
 	for index, c := range f.CalcChain.C {
 		if c.I != sheetID {
 			continue
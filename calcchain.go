@@ -33,7 +33,7 @@ func (f *File) calcChainReader() (*xlsxCalcChain, error) {
 // calcChainWriter provides a function to save xl/calcChain.xml after
 // serialize structure.
 func (f *File) calcChainWriter() {
-	if f.CalcChain != nil && f.CalcChain.C != nil {
+	if f.CalcChain != nil && f.CalcChain.C != nil && f.isPartModified(defaultXMLPathCalcChain) {
 		output, _ := xml.Marshal(f.CalcChain)
 		f.saveFileList(defaultXMLPathCalcChain, output)
 	}
@@ -47,9 +47,13 @@ func (f *File) deleteCalcChain(index int, cell string) error {
 		return err
 	}
 	if calc != nil {
+		count := len(calc.C)
 		calc.C = xlsxCalcChainCollection(calc.C).Filter(func(c xlsxCalcChainC) bool {
 			return !((c.I == index && c.R == cell) || (c.I == index && cell == "") || (c.I == 0 && c.R == cell))
 		})
+		if len(calc.C) != count {
+			f.setPartModified(defaultXMLPathCalcChain)
+		}
 	}
 	if len(calc.C) == 0 {
 		f.CalcChain = nil
@@ -62,6 +66,7 @@ func (f *File) deleteCalcChain(index int, cell string) error {
 		defer content.mu.Unlock()
 		for k, v := range content.Overrides {
 			if v.PartName == "/xl/calcChain.xml" {
+				f.setPartModified(defaultXMLPathContentTypes)
 				content.Overrides = append(content.Overrides[:k], content.Overrides[k+1:]...)
 			}
 		}
@@ -244,7 +244,7 @@ func (f *File) setCellTimeFunc(sheet, cell string, value time.Time) error {
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	ws.mu.Unlock()
 	var date1904, isNum bool
-	wb, err := f.workbookReader()
+	wb, err := f.loadWorkbook()
 	if err != nil {
 		return err
 	}
@@ -462,6 +462,7 @@ func (f *File) setSharedString(val string) (int, error) {
 	}
 	sst.mu.Lock()
 	defer sst.mu.Unlock()
+	f.setPartModified(defaultXMLPathSharedStrings)
 	sst.Count++
 	sst.UniqueCount++
 	t := xlsxT{Val: val}
@@ -565,7 +566,7 @@ func (c *xlsxC) getCellDate(f *File, raw bool) (string, error) {
 			c.V = strconv.FormatFloat(excelTime, 'G', 15, 64)
 		}
 	}
-	return f.formattedValue(c, raw, CellTypeBool)
+	return f.formattedValue(c, raw, CellTypeDate)
 }
 
 // getValueFrom return a value from a column/row cell, this function is
@@ -628,6 +629,291 @@ func (f *File) SetCellDefault(sheet, cell, value string) error {
 	return f.removeFormula(c, ws, sheet)
 }
 
+// SetCellValueParsed provides a function to set the value of a cell by given
+// worksheet name, cell reference, user-entered text and culture name, and
+// recognize the text like the input parser of Excel. Numbers, percentages,
+// currencies, dates, times, booleans and errors will be stored as typed
+// values, and the other text will be stored as a string. The culture decides
+// the decimal and thousands separators, the currency symbol and the order of
+// the year, month and day in the dates, the en-US culture will be used if the
+// culture isn't supported. A suitable built-in number format will be merged
+// into the existing style of the cell for the recognized percentage, currency,
+// date or time value, and the other attributes of the style will be kept. For
+// example, set the currency value "1.234,50 €" in the de-DE culture for the
+// cell A1 on Sheet1:
+//
+//	err := f.SetCellValueParsed("Sheet1", "A1", "1.234,50 €", excelize.CultureNameDeDE)
+func (f *File) SetCellValueParsed(sheet, cell, text string, culture CultureName) error {
+	info, ok := supportedCultureInfo[culture]
+	if !ok {
+		info = supportedCultureInfo[CultureNameEnUS]
+	}
+	value := strings.TrimSpace(text)
+	switch upper := strings.ToUpper(value); upper {
+	case "TRUE", "FALSE":
+		return f.SetCellBool(sheet, cell, upper == "TRUE")
+	case formulaErrorDIV, formulaErrorNAME, formulaErrorNA, formulaErrorNUM,
+		formulaErrorVALUE, formulaErrorREF, formulaErrorNULL:
+		return f.setCellError(sheet, cell, upper)
+	}
+	if num, numFmtID, ok := parseNumberText(value, info); ok {
+		if err := f.SetCellFloat(sheet, cell, num, -1, 64); err != nil {
+			return err
+		}
+		if numFmtID == 5 || numFmtID == 7 {
+			return f.setParsedValueStyle(sheet, cell, &Style{CustomNumFmt: stringPtr(info.getNumFmtCode(numFmtID))})
+		}
+		return f.setParsedValueStyle(sheet, cell, &Style{NumFmt: numFmtID})
+	}
+	wb, err := f.loadWorkbook()
+	if err != nil {
+		return err
+	}
+	date1904 := wb != nil && wb.WorkbookPr != nil && wb.WorkbookPr.Date1904
+	if num, numFmtID, ok := parseDateTimeText(value, info, date1904); ok {
+		if err := f.SetCellFloat(sheet, cell, num, -1, 64); err != nil {
+			return err
+		}
+		return f.setParsedValueStyle(sheet, cell, &Style{NumFmt: numFmtID})
+	}
+	return f.SetCellStr(sheet, cell, text)
+}
+
+// setCellError provides a function to set error type value of a cell by given
+// worksheet name, cell reference and error value.
+func (f *File) setCellError(sheet, cell, value string) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	c, col, row, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	c.S = ws.prepareCellStyle(col, row, c.S)
+	c.T, c.V = "e", value
+	c.IS = nil
+	return f.removeFormula(c, ws, sheet)
+}
+
+// setParsedValueStyle provides a function to merge the number format for the
+// parsed cell value into the existing style of the cell.
+func (f *File) setParsedValueStyle(sheet, cell string, style *Style) error {
+	if style.NumFmt == 0 && style.CustomNumFmt == nil {
+		return nil
+	}
+	return f.UpdateCellStyle(sheet, cell, cell, style)
+}
+
+// parseNumberText parses the user-entered text as a number, percentage or
+// currency by given culture, returns the number and the built-in number
+// format index for the text.
+func parseNumberText(text string, culture cultureInfo) (float64, int, bool) {
+	var negative, percent, currency bool
+	if strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")") {
+		negative, text = true, strings.TrimSpace(text[1:len(text)-1])
+	}
+	if strings.HasSuffix(text, "%") {
+		percent, text = true, strings.TrimSpace(strings.TrimSuffix(text, "%"))
+	}
+	text, negative = trimNumberSign(text, negative)
+	if symbol := culture.currencySymbol(); symbol != "" && !percent {
+		if strings.HasPrefix(text, symbol) {
+			currency = true
+			text, negative = trimNumberSign(strings.TrimSpace(strings.TrimPrefix(text, symbol)), negative)
+		} else if strings.HasSuffix(text, symbol) {
+			currency, text = true, strings.TrimSpace(strings.TrimSuffix(text, symbol))
+		}
+	}
+	num, numFmtID, ok := parseNumberDigits(text, culture)
+	if !ok {
+		return 0, 0, false
+	}
+	if negative {
+		num = -num
+	}
+	switch {
+	case percent:
+		num /= 100
+		if numFmtID == 1 || numFmtID == 3 {
+			return num, 9, true
+		}
+		return num, 10, true
+	case currency:
+		if numFmtID == 11 {
+			return 0, 0, false
+		}
+		if numFmtID == 1 || numFmtID == 3 {
+			return num, 5, true
+		}
+		return num, 7, true
+	case numFmtID == 1 || numFmtID == 2:
+		return num, 0, true
+	}
+	return num, numFmtID, true
+}
+
+// trimNumberSign removes the leading sign of the number text, returns the
+// text and if the number is negative.
+func trimNumberSign(text string, negative bool) (string, bool) {
+	if negative {
+		return text, negative
+	}
+	if strings.HasPrefix(text, "-") {
+		return strings.TrimSpace(text[1:]), true
+	}
+	return strings.TrimSpace(strings.TrimPrefix(text, "+")), false
+}
+
+// parseNumberDigits parses the unsigned number text with the decimal and
+// thousands separators of the culture, returns the number and the built-in
+// number format index 1, 2, 3, 4 or 11 matched with the text.
+func parseNumberDigits(text string, culture cultureInfo) (float64, int, bool) {
+	isDigits := func(s string) bool {
+		for _, r := range s {
+			if r < '0' || r > '9' {
+				return false
+			}
+		}
+		return true
+	}
+	if idx := strings.IndexAny(text, "Ee"); idx > 0 {
+		text = strings.Replace(text, culture.decimalSeparator, ".", 1)
+		if strings.Trim(text, "0123456789.Ee+-") != "" {
+			return 0, 0, false
+		}
+		num, err := strconv.ParseFloat(text, 64)
+		return num, 11, err == nil
+	}
+	if culture.thousandsSeparator == "\u00a0" {
+		text = strings.ReplaceAll(text, " ", culture.thousandsSeparator)
+	}
+	integer, fraction := text, ""
+	if idx := strings.Index(text, culture.decimalSeparator); idx != -1 {
+		integer, fraction = text[:idx], text[idx+len(culture.decimalSeparator):]
+	}
+	numFmtID := 1
+	if strings.Contains(integer, culture.thousandsSeparator) {
+		groups := strings.Split(integer, culture.thousandsSeparator)
+		for i, group := range groups {
+			if (i == 0 && (len(group) == 0 || len(group) > 3)) || (i > 0 && len(group) != 3) {
+				return 0, 0, false
+			}
+		}
+		integer, numFmtID = strings.Join(groups, ""), 3
+	}
+	if (integer == "" && fraction == "") || !isDigits(integer) || !isDigits(fraction) {
+		return 0, 0, false
+	}
+	if fraction != "" {
+		numFmtID++
+	}
+	num, err := strconv.ParseFloat(integer+"."+fraction, 64)
+	return num, numFmtID, err == nil
+}
+
+// parseDateTimeText parses the user-entered text as a date, time or date and
+// time by given culture, returns the Excel serial number and the built-in
+// number format index for the text.
+func parseDateTimeText(text string, culture cultureInfo, date1904 bool) (float64, int, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return 0, 0, false
+	}
+	if strings.Contains(fields[0], ":") {
+		return parseTimeText(text)
+	}
+	sep := strings.IndexAny(fields[0], "/-.")
+	if sep == -1 {
+		return 0, 0, false
+	}
+	parts := strings.Split(fields[0], fields[0][sep:sep+1])
+	if len(parts) != 3 {
+		return 0, 0, false
+	}
+	order := culture.dateOrder()
+	if len(parts[0]) == 4 {
+		order = "ymd"
+	}
+	var year, month, day int
+	for i, part := range parts {
+		val, err := strconv.Atoi(part)
+		if err != nil || val < 0 || len(part) > 4 {
+			return 0, 0, false
+		}
+		switch order[i] {
+		case 'y':
+			if len(part) != 4 && len(part) > 2 {
+				return 0, 0, false
+			}
+			if year = val; len(part) <= 2 {
+				if year += 1900; val < 30 {
+					year += 100
+				}
+			}
+		case 'm':
+			month = val
+		case 'd':
+			day = val
+		}
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if month < 1 || month > 12 || date.Day() != day {
+		return 0, 0, false
+	}
+	num, err := timeToExcelTime(date, date1904)
+	if err != nil {
+		return 0, 0, false
+	}
+	if len(fields) == 1 {
+		return num, 14, true
+	}
+	t, _, ok := parseTimeText(strings.Join(fields[1:], " "))
+	return num + t, 22, ok
+}
+
+// parseTimeText parses the user-entered text as a time, returns the fraction
+// of the day and the built-in number format index for the text.
+func parseTimeText(text string) (float64, int, bool) {
+	var meridiem string
+	text = strings.ToUpper(text)
+	for _, suffix := range []string{"AM", "PM"} {
+		if strings.HasSuffix(text, suffix) {
+			meridiem, text = suffix, strings.TrimSpace(strings.TrimSuffix(text, suffix))
+		}
+	}
+	parts := strings.Split(text, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, 0, false
+	}
+	var vals [3]int
+	for i, part := range parts {
+		val, err := strconv.Atoi(part)
+		if err != nil || val < 0 || len(part) > 2 {
+			return 0, 0, false
+		}
+		vals[i] = val
+	}
+	hour, minute, second := vals[0], vals[1], vals[2]
+	if minute > 59 || second > 59 || hour > 23 || (meridiem != "" && hour > 12) {
+		return 0, 0, false
+	}
+	numFmtID := 18 + len(parts) - 2
+	if meridiem != "" {
+		if hour %= 12; meridiem == "PM" {
+			hour += 12
+		}
+	} else {
+		numFmtID += 2
+	}
+	return float64(hour*3600+minute*60+second) / 86400, numFmtID, true
+}
+
 // GetCellFormula provides a function to get formula from cell by given
 // worksheet name and cell reference in spreadsheet.
 func (f *File) GetCellFormula(sheet, cell string) (string, error) {
@@ -817,7 +1103,7 @@ func (f *File) GetCellHyperLink(sheet, cell string) (bool, string, error) {
 	if _, _, err := SplitCellName(cell); err != nil {
 		return false, "", err
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return false, "", err
 	}
@@ -884,6 +1170,14 @@ func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...Hype
 	if err != nil {
 		return err
 	}
+	return f.setCellHyperLink(ws, sheet, cell, link, linkType, opts...)
+}
+
+// setCellHyperLink provides a function to set the hyperlink of the cell in
+// the worksheet by given worksheet name, cell reference, link resource and
+// link type.
+func (f *File) setCellHyperLink(ws *xlsxWorksheet, sheet, cell, link, linkType string, opts ...HyperlinkOpts) error {
+	var err error
 	if cell, err = ws.mergeCellsParser(cell); err != nil {
 		return err
 	}
@@ -957,7 +1251,7 @@ func getCellRichText(si *xlsxSI) (runs []RichTextRun) {
 // GetCellRichText provides a function to get rich text of cell by given
 // worksheet.
 func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err error) {
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return
 	}
@@ -1032,7 +1326,9 @@ func newFont(rPr *xlsxRPr) *Font {
 		if rPr.Color.Theme != nil {
 			font.ColorTheme = rPr.Color.Theme
 		}
-		font.ColorIndexed = rPr.Color.Indexed
+		if rPr.Color.Indexed != nil {
+			font.ColorIndexed = *rPr.Color.Indexed
+		}
 		font.ColorTint = rPr.Color.Tint
 	}
 	return &font
@@ -1210,6 +1506,7 @@ func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
 			return err
 		}
 	}
+	f.setPartModified(defaultXMLPathSharedStrings)
 	sst.SI = append(sst.SI, si)
 	sst.Count++
 	sst.UniqueCount++
@@ -1288,7 +1585,7 @@ func (ws *xlsxWorksheet) prepareCell(cell string) (*xlsxC, int, int, error) {
 // value function. Passed function implements specific part of required
 // logic.
 func (f *File) getCellStringFunc(sheet, cell string, fn func(x *xlsxWorksheet, c *xlsxC) (string, bool, error)) (string, error) {
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return "", err
 	}
@@ -1340,10 +1637,13 @@ func (f *File) getCellStringFunc(sheet, cell string, fn func(x *xlsxWorksheet, c
 // it is possible to apply a format to the cell value, it will do so, if not
 // then an error will be returned, along with the raw value of the cell.
 func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, error) {
-	if raw || c.S == 0 {
+	if raw {
 		return c.V, nil
 	}
-	styleSheet, err := f.stylesReader()
+	if c.S == 0 {
+		return localGeneralNumber(c.V, cellType, f.options), nil
+	}
+	styleSheet, err := f.loadStyles()
 	if err != nil {
 		return c.V, err
 	}
@@ -1358,7 +1658,7 @@ func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, er
 		numFmtID = *styleSheet.CellXfs.Xf[c.S].NumFmtID
 	}
 	date1904 := false
-	wb, err := f.workbookReader()
+	wb, err := f.loadWorkbook()
 	if err != nil {
 		return c.V, err
 	}
@@ -457,6 +457,26 @@ func TestGetCellType(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetCellDateValue(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{NumFmt: 22})
+	assert.NoError(t, err)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData = xlsxSheetData{Row: []xlsxRow{{R: 1, C: []xlsxC{
+		{R: "A1", T: "d", V: "2022-10-22T15:05:29Z", S: style},
+		{R: "B1", T: "d", V: "2022-10-22T15:05:29Z"},
+	}}}}
+	// Test get the ISO 8601 date cell value with the date number format
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "10/22/22 15:05", val)
+	val, err = f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "44856.6288078704", val)
+	assert.NoError(t, f.Close())
+}
+
 func TestGetValueFrom(t *testing.T) {
 	f := NewFile()
 	c := xlsxC{T: "s"}
@@ -998,3 +1018,104 @@ func TestSharedStringsError(t *testing.T) {
 func TestSIString(t *testing.T) {
 	assert.Empty(t, xlsxSI{}.String())
 }
+
+func TestSetCellValueParsed(t *testing.T) {
+	f := NewFile()
+	for idx, item := range []struct {
+		text     string
+		culture  CultureName
+		cellType CellType
+		value    string
+		numFmt   int
+		expected string
+	}{
+		{text: "1234", culture: CultureNameUnknown, value: "1234", expected: "1234"},
+		{text: " 1,234.50 ", culture: CultureNameEnUS, value: "1234.5", numFmt: 4, expected: "1,234.50"},
+		{text: "1,234", culture: CultureNameEnUS, value: "1234", numFmt: 3, expected: "1,234"},
+		{text: "(1,234)", culture: CultureNameEnUS, value: "-1234", numFmt: 3, expected: "-1,234"},
+		{text: "1.234,5", culture: CultureNameDeDE, value: "1234.5", numFmt: 4, expected: "1,234.50"},
+		{text: "1 234,5", culture: CultureNameFrFR, value: "1234.5", numFmt: 4, expected: "1,234.50"},
+		{text: "1.2E+3", culture: CultureNameEnUS, value: "1200", numFmt: 11, expected: "1.20E+03"},
+		{text: "45%", culture: CultureNameEnUS, value: "0.45", numFmt: 9, expected: "45%"},
+		{text: "-12.5%", culture: CultureNameEnUS, value: "-0.125", numFmt: 10, expected: "-12.50%"},
+		{text: "$1,234.50", culture: CultureNameEnUS, value: "1234.5", expected: "$1,234.50"},
+		{text: "-$12", culture: CultureNameEnUS, value: "-12", expected: "-$12"},
+		{text: "-1.234,50 €", culture: CultureNameDeDE, value: "-1234.5", expected: "-1,234.50 €"},
+		{text: "12/03/2024", culture: CultureNameEnUS, value: "45629", numFmt: 14, expected: "12-03-24"},
+		{text: "12/03/2024", culture: CultureNameEnGB, value: "45363", numFmt: 14, expected: "03-12-24"},
+		{text: "12.03.24", culture: CultureNameDeDE, value: "45363", numFmt: 14, expected: "03-12-24"},
+		{text: "2024-03-12 14:30", culture: CultureNameEnUS, value: "45363.604166666664", numFmt: 22, expected: "3/12/24 14:30"},
+		{text: "2:30 PM", culture: CultureNameEnUS, value: "0.6041666666666666", numFmt: 18, expected: "2:30 PM"},
+		{text: "14:30:15", culture: CultureNameEnUS, value: "0.6043402777777778", numFmt: 21, expected: "14:30:15"},
+		{text: "true", culture: CultureNameEnUS, cellType: CellTypeBool, value: "1", expected: "TRUE"},
+		{text: "#N/A", culture: CultureNameEnUS, cellType: CellTypeError, value: "#N/A", expected: "#N/A"},
+		{text: "$1.2k", culture: CultureNameEnUS, cellType: CellTypeSharedString, value: "$1.2k", expected: "$1.2k"},
+		{text: "02/30/2024", culture: CultureNameEnUS, cellType: CellTypeSharedString, value: "02/30/2024", expected: "02/30/2024"},
+		{text: "25:00", culture: CultureNameEnUS, cellType: CellTypeSharedString, value: "25:00", expected: "25:00"},
+		{text: "1,23", culture: CultureNameEnUS, cellType: CellTypeSharedString, value: "1,23", expected: "1,23"},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellValueParsed("Sheet1", cell, item.text, item.culture))
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, item.cellType, cellType, item.text)
+		val, err := f.GetCellValue("Sheet1", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, item.value, val, item.text)
+		val, err = f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, item.expected, val, item.text)
+		if item.numFmt != 0 {
+			styleID, err := f.GetCellStyle("Sheet1", cell)
+			assert.NoError(t, err)
+			style, err := f.GetStyle(styleID)
+			assert.NoError(t, err)
+			assert.Equal(t, item.numFmt, style.NumFmt, item.text)
+		}
+	}
+	// Test set parsed value on the cell with style
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", styleID))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", styleID))
+	dateStyleID, err := f.NewStyle(&Style{NumFmt: 14, Border: []Border{{Type: "left", Color: "000000", Style: 1}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B3", "B4", dateStyleID))
+	for _, item := range []struct {
+		cell, text string
+		numFmt     int
+		expected   string
+	}{
+		{cell: "B1", text: "45%", numFmt: 9, expected: "45%"},
+		{cell: "B2", text: "12/03/2024", numFmt: 14, expected: "12-03-24"},
+		{cell: "B3", text: "45%", numFmt: 9, expected: "45%"},
+		{cell: "B4", text: "1234", numFmt: 14, expected: "05-18-03"},
+	} {
+		assert.NoError(t, f.SetCellValueParsed("Sheet1", item.cell, item.text, CultureNameEnUS))
+		cellStyleID, err := f.GetCellStyle("Sheet1", item.cell)
+		assert.NoError(t, err)
+		style, err := f.GetStyle(cellStyleID)
+		assert.NoError(t, err)
+		assert.Equal(t, item.numFmt, style.NumFmt, item.cell)
+		if item.cell == "B1" || item.cell == "B2" {
+			assert.True(t, style.Font.Bold, item.cell)
+		} else {
+			assert.Equal(t, []Border{{Type: "left", Color: "000000", Style: 1}}, style.Border, item.cell)
+		}
+		val, err := f.GetCellValue("Sheet1", item.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, item.expected, val, item.cell)
+	}
+	// Test set parsed value with invalid sheet name
+	for _, text := range []string{"1", "TRUE", "#N/A", "12/03/2024", "text"} {
+		assert.EqualError(t, f.SetCellValueParsed("Sheet:1", "A1", text, CultureNameEnUS), ErrSheetNameInvalid.Error())
+	}
+	// Test set parsed value with invalid cell reference
+	assert.EqualError(t, f.SetCellValueParsed("Sheet1", "A", "#N/A", CultureNameEnUS), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set parsed value with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellValueParsed("Sheet1", "A1", "12/03/2024", CultureNameEnUS), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
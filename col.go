@@ -15,8 +15,10 @@ import (
 	"bytes"
 	"encoding/xml"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/mohae/deepcopy"
 )
@@ -215,7 +217,7 @@ func (f *File) Cols(sheet string) (*Cols, error) {
 	if !ok {
 		return nil, ErrSheetNotExist{sheet}
 	}
-	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
+	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil && f.isPartModified(name) {
 		ws := worksheet.(*xlsxWorksheet)
 		ws.mu.Lock()
 		defer ws.mu.Unlock()
@@ -258,7 +260,7 @@ func (f *File) GetColVisible(sheet, col string) (bool, error) {
 		return true, err
 	}
 	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		f.mu.Unlock()
 		return false, err
@@ -337,7 +339,7 @@ func (f *File) GetColOutlineLevel(sheet, col string) (uint8, error) {
 	if err != nil {
 		return level, err
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return 0, err
 	}
@@ -432,7 +434,7 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 		return err
 	}
 	f.mu.Lock()
-	s, err := f.stylesReader()
+	s, err := f.loadStyles()
 	if err != nil {
 		f.mu.Unlock()
 		return err
@@ -642,7 +644,7 @@ func (f *File) positionObjectPixels(sheet string, col, row, x1, y1, width, heigh
 // getColWidth provides a function to get column width in pixels by given
 // sheet name and column number.
 func (f *File) getColWidth(sheet string, col int) int {
-	ws, _ := f.workSheetReader(sheet)
+	ws, _, _ := f.loadWorkSheet(sheet)
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 	if ws.Cols != nil {
@@ -672,7 +674,7 @@ func (f *File) GetColStyle(sheet, col string) (int, error) {
 		return styleID, err
 	}
 	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		f.mu.Unlock()
 		return styleID, err
@@ -698,7 +700,7 @@ func (f *File) GetColWidth(sheet, col string) (float64, error) {
 		return defaultColWidth, err
 	}
 	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		f.mu.Unlock()
 		return defaultColWidth, err
@@ -724,6 +726,253 @@ func (f *File) GetColWidth(sheet, col string) (float64, error) {
 	return defaultColWidth, err
 }
 
+// AutoFitColWidth provides a function to set the width of a single column or
+// multiple columns to fit the display text of the cells by given worksheet
+// name and columns range. The width of the columns will be estimated based on
+// the formatted cell value, and the font family, size and bold of the cell
+// style, the merged cells across multiple columns will be ignored, and the
+// width of the columns without any cell value will not be changed. This
+// function is concurrency safe. For example, auto fit the width of columns A
+// to D on Sheet1:
+//
+//	err := f.AutoFitColWidth("Sheet1", "A:D")
+func (f *File) AutoFitColWidth(sheet, columns string) error {
+	min, max, err := f.parseColRange(columns)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	sst, err := f.loadSharedStrings()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	if _, err = f.loadStyles(); err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var mergeCells [][]int
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			coordinates, err := rangeRefToCoordinates(mergeCell.Ref)
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(coordinates)
+			if coordinates[0] != coordinates[2] {
+				mergeCells = append(mergeCells, coordinates)
+			}
+		}
+	}
+	widths := make(map[int]float64)
+	for rowIdx := range ws.SheetData.Row {
+		for colIdx := range ws.SheetData.Row[rowIdx].C {
+			c := &ws.SheetData.Row[rowIdx].C[colIdx]
+			col, row, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			if col < min || col > max || inMergeCells(mergeCells, col, row) {
+				continue
+			}
+			width, err := f.getCellTextWidth(c, sst)
+			if err != nil {
+				return err
+			}
+			if width > widths[col] {
+				widths[col] = width
+			}
+		}
+	}
+	setAutoFitCols(ws, widths)
+	return err
+}
+
+// inMergeCells provides a function to check if the cell coordinates in the
+// given merged cells coordinates.
+func inMergeCells(mergeCells [][]int, col, row int) bool {
+	for _, ref := range mergeCells {
+		if cellInRange([]int{col, row}, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// setAutoFitCols provides a function to set the width of the columns in the
+// worksheet by given estimated columns width.
+func setAutoFitCols(ws *xlsxWorksheet, widths map[int]float64) {
+	cols := make([]int, 0, len(widths))
+	for col := range widths {
+		cols = append(cols, col)
+	}
+	sort.Ints(cols)
+	for _, colNum := range cols {
+		if widths[colNum] == 0 {
+			continue
+		}
+		col := xlsxCol{
+			Min:         colNum,
+			Max:         colNum,
+			Width:       float64Ptr(widths[colNum]),
+			BestFit:     true,
+			CustomWidth: true,
+		}
+		if ws.Cols == nil {
+			ws.Cols = &xlsxCols{}
+		}
+		ws.Cols.Col = flatCols(col, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+			fc.Collapsed = c.Collapsed
+			fc.Hidden = c.Hidden
+			fc.OutlineLevel = c.OutlineLevel
+			fc.Phonetic = c.Phonetic
+			fc.Style = c.Style
+			return fc
+		})
+	}
+}
+
+// getCellTextWidth provides a function to estimate the column width in
+// characters to fit the display text of the given cell.
+func (f *File) getCellTextWidth(c *xlsxC, sst *xlsxSST) (float64, error) {
+	text, err := c.getValueFrom(f, sst, false)
+	if err != nil || text == "" {
+		return 0, err
+	}
+	styleSheet, err := f.loadStyles()
+	if err != nil {
+		return 0, err
+	}
+	return getTextWidth(text, getStyleFont(styleSheet, c.S)), err
+}
+
+// getStyleFont provides a function to get the font of the cell style by given
+// style index, the default font will be returned if the style doesn't specify
+// the font.
+func getStyleFont(styleSheet *xlsxStyleSheet, styleID int) *xlsxFont {
+	if styleSheet.Fonts == nil || len(styleSheet.Fonts.Font) == 0 {
+		return nil
+	}
+	fontID := 0
+	if styleSheet.CellXfs != nil && styleID > 0 && styleID < len(styleSheet.CellXfs.Xf) {
+		if xf := styleSheet.CellXfs.Xf[styleID]; xf.FontID != nil &&
+			*xf.FontID >= 0 && *xf.FontID < len(styleSheet.Fonts.Font) {
+			fontID = *xf.FontID
+		}
+	}
+	return styleSheet.Fonts.Font[fontID]
+}
+
+// fontWidthFactors defined the average character width of the common fonts
+// relative to the Calibri font.
+var fontWidthFactors = map[string]float64{
+	"arial":           1.1,
+	"arial black":     1.35,
+	"cambria":         1.05,
+	"candara":         1.0,
+	"century gothic":  1.2,
+	"garamond":        0.95,
+	"georgia":         1.15,
+	"helvetica":       1.1,
+	"segoe ui":        1.1,
+	"tahoma":          1.1,
+	"times new roman": 0.95,
+	"trebuchet ms":    1.1,
+	"verdana":         1.25,
+}
+
+// monospacedFonts defined the common fonts with the fixed character width.
+var monospacedFonts = map[string]bool{
+	"consolas":        true,
+	"courier":         true,
+	"courier new":     true,
+	"lucida console":  true,
+	"source code pro": true,
+}
+
+// getTextWidth provides a function to estimate the column width in characters
+// to fit the given text displayed in the given font. The width of each
+// character was estimated in pixels of the 11 point Calibri font, and scaled
+// by the font family, size and bold of the given font.
+func getTextWidth(text string, font *xlsxFont) float64 {
+	var (
+		name, size, bold = "calibri", 11.0, false
+		maxDigitWidth    = 7.0
+		pixels           float64
+	)
+	if font != nil {
+		if font.Name != nil && font.Name.Val != nil && *font.Name.Val != "" {
+			name = strings.ToLower(*font.Name.Val)
+		}
+		if font.Sz != nil && font.Sz.Val != nil && *font.Sz.Val > 0 {
+			size = *font.Sz.Val
+		}
+		bold = font.B != nil && (font.B.Val == nil || *font.B.Val)
+	}
+	for _, line := range strings.Split(text, "\n") {
+		var linePixels float64
+		for _, r := range line {
+			if monospacedFonts[name] && !isWideChar(r) {
+				linePixels += size * 0.6 * 96 / 72
+				continue
+			}
+			width := getCharWidth(r)
+			if factor, ok := fontWidthFactors[name]; ok {
+				width *= factor
+			}
+			linePixels += width * size / 11
+		}
+		if bold {
+			linePixels *= 1.1
+		}
+		pixels = math.Max(pixels, linePixels)
+	}
+	if pixels == 0 {
+		return 0
+	}
+	width := math.Ceil((pixels/maxDigitWidth+0.75)*100) / 100
+	return math.Min(width, MaxColumnWidth)
+}
+
+// getCharWidth provides a function to get the estimated width in pixels of
+// the character in the 11 point Calibri font.
+func getCharWidth(r rune) float64 {
+	switch {
+	case isWideChar(r):
+		return 14
+	case r >= '0' && r <= '9':
+		return 7
+	case strings.ContainsRune(" ijlI.,:;'|!`", r):
+		return 3
+	case strings.ContainsRune("frt()[]{}/\\\"-J", r):
+		return 4
+	case strings.ContainsRune("mw", r):
+		return 10
+	case strings.ContainsRune("MW%@", r):
+		return 12
+	case r >= 'a' && r <= 'z':
+		return 6
+	case r >= 'A' && r <= 'Z':
+		return 8
+	}
+	return 7
+}
+
+// isWideChar provides a function to check if the character is displayed in
+// double width, such as the CJK characters.
+func isWideChar(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana) ||
+		(r >= 0x3000 && r <= 0x303F) || (r >= 0xFF01 && r <= 0xFF60) || (r >= 0xFFE0 && r <= 0xFFE6)
+}
+
 // InsertCols provides a function to insert new columns before the given column
 // name and number of columns. For example, create two columns before column
 // C in Sheet1:
@@ -1,7 +1,10 @@
 package excelize
 
 import (
+	"fmt"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -460,6 +463,80 @@ func TestRemoveCol(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveCol.xlsx")))
 }
 
+func TestAutoFitColWidth(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "D", "D", 30))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Amount", "Date", "Notes"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Excelize", 1234567.891, 45306}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "A very long text in the cell"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B4", "Merged cells are ignored in the auto fit"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B4", "C4"))
+	numStyle, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", numStyle))
+	dateStyle, err := f.NewStyle(&Style{NumFmt: 15, Font: &Font{Bold: true, Size: 16, Family: "Arial"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C2", "C2", dateStyle))
+	assert.NoError(t, f.AutoFitColWidth("Sheet1", "A:E"))
+
+	widths := make([]float64, 5)
+	for i, col := range []string{"A", "B", "C", "D", "E"} {
+		widths[i], err = f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, getTextWidth("A very long text in the cell", nil), widths[0])
+	assert.Equal(t, getTextWidth("1,234,567.89", nil), widths[1])
+	assert.Greater(t, widths[2], getTextWidth("15-Jan-24", nil))
+	assert.Equal(t, getTextWidth("Notes", nil), widths[3])
+	assert.Equal(t, defaultColWidth, widths[4])
+
+	// Test auto fit column width with the text in multiple lines, wide
+	// characters and monospaced font
+	assert.Equal(t, getTextWidth("Line", nil), getTextWidth("Line\nA", nil))
+	assert.Equal(t, 2*getTextWidth("0000", nil)-0.75, getTextWidth("\u6587\u672c\u6587\u672c", nil))
+	font := &xlsxFont{Name: &attrValString{Val: stringPtr("Courier New")}}
+	assert.Equal(t, getTextWidth("iiii", font), getTextWidth("MMMM", font))
+	assert.Equal(t, float64(MaxColumnWidth), getTextWidth(strings.Repeat("M", TotalCellChars), nil))
+	assert.Zero(t, getTextWidth("", nil))
+	// Test auto fit column width concurrently with setting shared strings
+	var wg sync.WaitGroup
+	for i := 1; i <= 5; i++ {
+		wg.Add(1)
+		go func(row int) {
+			defer wg.Done()
+			assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("E%d", row), strings.Repeat("E", row)))
+			assert.NoError(t, f.AutoFitColWidth("Sheet1", "E"))
+		}(i)
+	}
+	wg.Wait()
+	width, err := f.GetColWidth("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.Equal(t, getTextWidth("EEEEE", nil), width)
+	// Test auto fit column width with invalid columns range
+	assert.Equal(t, newInvalidColumnNameError("*"), f.AutoFitColWidth("Sheet1", "*"))
+	// Test auto fit column width on not exists worksheet
+	assert.EqualError(t, f.AutoFitColWidth("SheetN", "A"), "sheet SheetN does not exist")
+	// Test auto fit column width with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "A"
+	assert.Equal(t, ErrParameterInvalid, f.AutoFitColWidth("Sheet1", "A"))
+	ws.(*xlsxWorksheet).MergeCells = nil
+	// Test auto fit column width with invalid cell reference
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].R = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AutoFitColWidth("Sheet1", "A"))
+	// Test auto fit column width with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFitColWidth("Sheet1", "A"), "XML syntax error on line 1: invalid UTF-8")
+	// Test auto fit column width with unsupported charset style sheet
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, []byte(`<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"/>`))
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFitColWidth("Sheet1", "A"), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestConvertColWidthToPixels(t *testing.T) {
 	assert.Equal(t, -11.0, convertColWidthToPixels(-1))
 }
@@ -0,0 +1,309 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/transform"
+)
+
+// CSVOptions directly maps the settings of the CSV import and export.
+//
+// StartCell specifies the top-left cell reference of the imported data, the
+// default value is "A1".
+//
+// Delimiter specifies the field delimiter, the default value is comma ','.
+//
+// Comment specifies the comment character for import, lines beginning with
+// the comment character without preceding whitespace are ignored.
+//
+// LazyQuotes specifies if a quote may appear in an unquoted field and a
+// non-doubled quote may appear in a quoted field on import.
+//
+// UseCRLF specifies if use \r\n as the line terminator on export.
+//
+// Charset specifies the character encoding of the CSV data, such as
+// "gbk" or "windows-1252". The import decodes data by the charset transcoder
+// function of the spreadsheet, which could be set by the CharsetTranscoder
+// function, and the export encodes data by the given charset. The default
+// value is UTF-8.
+//
+// InferTypes specifies if detect numbers, booleans and dates from the text on
+// import, the detected dates are stored as date-time serial numbers with the
+// built-in date number format, or the built-in date-time number format if the
+// date has a time of day. When this option is disabled, all fields are stored
+// as strings.
+//
+// DateLayouts specifies the Go time layouts used for date detection on
+// import, the default layouts are ISO 8601 dates and date-times.
+//
+// RawCellValue specifies if export the raw value of the cells, or apply the
+// number format for the cell value.
+//
+// Stream specifies if import data with the stream writer, this reduces the
+// memory usage for large data. The worksheet should be empty when using this
+// option.
+type CSVOptions struct {
+	StartCell    string
+	Delimiter    rune
+	Comment      rune
+	LazyQuotes   bool
+	UseCRLF      bool
+	Charset      string
+	InferTypes   bool
+	DateLayouts  []string
+	RawCellValue bool
+	Stream       bool
+}
+
+// defaultCSVDateLayouts defined the default layouts for date detection on
+// CSV import.
+var defaultCSVDateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05Z07:00",
+}
+
+// parseCSVOptions provides a function to parse the optional settings for
+// CSV import and export.
+func parseCSVOptions(opts *CSVOptions) *CSVOptions {
+	options := CSVOptions{}
+	if opts != nil {
+		options = *opts
+	}
+	if options.StartCell == "" {
+		options.StartCell = "A1"
+	}
+	if options.Delimiter == 0 {
+		options.Delimiter = ','
+	}
+	if len(options.DateLayouts) == 0 {
+		options.DateLayouts = defaultCSVDateLayouts
+	}
+	return &options
+}
+
+// isUTF8Charset returns if the given charset label is the UTF-8 encoding.
+func isUTF8Charset(label string) bool {
+	label = strings.ToLower(strings.TrimSpace(label))
+	return label == "" || label == "utf-8" || label == "utf8"
+}
+
+// ImportCSV provides a function to import CSV data from io.Reader into the
+// worksheet by given worksheet name and CSV options. For example, import a
+// semicolon separated file encoded in Windows-1252 and detect numbers and
+// dates:
+//
+//	file, err := os.Open("data.csv")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	if err := f.ImportCSV("Sheet1", file, &excelize.CSVOptions{
+//	    Delimiter:  ';',
+//	    Charset:    "windows-1252",
+//	    InferTypes: true,
+//	}); err != nil {
+//	    fmt.Println(err)
+//	}
+//
+// Set the Stream option to write the data with the stream writer for large
+// data, the worksheet should be empty in this case.
+func (f *File) ImportCSV(sheet string, r io.Reader, opts *CSVOptions) error {
+	options := parseCSVOptions(opts)
+	col, row, err := CellNameToCoordinates(options.StartCell)
+	if err != nil {
+		return err
+	}
+	if !isUTF8Charset(options.Charset) {
+		if r, err = f.CharsetReader(options.Charset, r); err != nil {
+			return err
+		}
+	}
+	reader := csv.NewReader(r)
+	reader.Comma, reader.Comment = options.Delimiter, options.Comment
+	reader.LazyQuotes, reader.FieldsPerRecord, reader.ReuseRecord = options.LazyQuotes, -1, true
+	var (
+		sw         *StreamWriter
+		dateStyles = make(map[int]int)
+	)
+	if options.Stream {
+		if sw, err = f.NewStreamWriter(sheet); err != nil {
+			return err
+		}
+	} else if _, err = f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	for ; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		values := make([]interface{}, len(record))
+		for i, field := range record {
+			value := options.inferCellValue(field)
+			if t, ok := value.(time.Time); ok {
+				numFmt := 14
+				if h, m, sec := t.Clock(); h+m+sec+t.Nanosecond() != 0 {
+					numFmt = 22
+				}
+				if _, ok := dateStyles[numFmt]; !ok {
+					if dateStyles[numFmt], err = f.NewStyle(&Style{NumFmt: numFmt}); err != nil {
+						return err
+					}
+				}
+				value = Cell{StyleID: dateStyles[numFmt], Value: t}
+			}
+			values[i] = value
+		}
+		if sw != nil {
+			cell, err := CoordinatesToCellName(col, row)
+			if err != nil {
+				return err
+			}
+			if err = sw.SetRow(cell, values); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = f.setCSVRow(sheet, col, row, values); err != nil {
+			return err
+		}
+	}
+	if sw != nil {
+		return sw.Flush()
+	}
+	return nil
+}
+
+// setCSVRow provides a function to set the values of a row for the imported
+// CSV record in normal mode.
+func (f *File) setCSVRow(sheet string, col, row int, values []interface{}) error {
+	for i, value := range values {
+		cell, err := CoordinatesToCellName(col+i, row)
+		if err != nil {
+			return err
+		}
+		v, ok := value.(Cell)
+		if !ok {
+			if err = f.SetCellValue(sheet, cell, value); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = f.SetCellValue(sheet, cell, v.Value); err != nil {
+			return err
+		}
+		if err = f.SetCellStyle(sheet, cell, cell, v.StyleID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inferCellValue provides a function to detect the cell value type by given
+// CSV field text. The date value will be returned as time.Time.
+func (opts *CSVOptions) inferCellValue(text string) interface{} {
+	if !opts.InferTypes || text == "" {
+		return text
+	}
+	if isNum, _, _ := isNumeric(text); isNum {
+		if num, err := strconv.ParseFloat(text, 64); err == nil {
+			return num
+		}
+	}
+	switch strings.ToUpper(text) {
+	case "TRUE":
+		return true
+	case "FALSE":
+		return false
+	}
+	for _, layout := range opts.DateLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t
+		}
+	}
+	return text
+}
+
+// ExportCSV provides a function to export the worksheet as CSV data to
+// io.Writer by given worksheet name and CSV options. The rows are read by the
+// streaming rows iterator, and the cell values are formatted by the number
+// format of the cells unless the RawCellValue option is set. The blank rows in
+// the tail of the worksheet will be skipped. For example, export a worksheet
+// as tab-separated values:
+//
+//	var buf bytes.Buffer
+//	if err := f.ExportCSV("Sheet1", &buf, &excelize.CSVOptions{
+//	    Delimiter: '\t',
+//	}); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) ExportCSV(sheet string, w io.Writer, opts *CSVOptions) error {
+	options := parseCSVOptions(opts)
+	var encoder io.WriteCloser
+	if !isUTF8Charset(options.Charset) {
+		enc, _ := charset.Lookup(options.Charset)
+		if enc == nil {
+			return newUnsupportedCharsetError(options.Charset)
+		}
+		encoder = transform.NewWriter(w, enc.NewEncoder())
+		w = encoder
+	}
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	writer.Comma, writer.UseCRLF = options.Delimiter, options.UseCRLF
+	var blankRows int
+	for rows.Next() {
+		row, err := rows.Columns(Options{RawCellValue: options.RawCellValue})
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+		if len(row) == 0 {
+			blankRows++
+			continue
+		}
+		for ; blankRows > 0; blankRows-- {
+			if err = writer.Write([]string{""}); err != nil {
+				_ = rows.Close()
+				return err
+			}
+		}
+		if err = writer.Write(row); err != nil {
+			_ = rows.Close()
+			return err
+		}
+	}
+	if err = rows.Close(); err != nil {
+		return err
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil || encoder == nil {
+		return err
+	}
+	return encoder.Close()
+}
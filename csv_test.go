@@ -0,0 +1,143 @@
+package excelize
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestImportCSV(t *testing.T) {
+	f := NewFile()
+	data := "Name,Price,Date,Valid\nApple,1.25,2023-07-01,TRUE\n\"Banana, ripe\",2,2023-07-02 10:30:00,false\n"
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader(data), &CSVOptions{InferTypes: true}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Price", "Date", "Valid"},
+		{"Apple", "1.25", "07-01-23", "TRUE"},
+		{"Banana, ripe", "2", "7/2/23 10:30", "FALSE"},
+	}, rows)
+	cellType, err := f.GetCellType("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeUnset, cellType)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestImportCSV.xlsx")))
+	// Test export the imported CSV keep the time of the dates
+	exported := new(bytes.Buffer)
+	assert.NoError(t, f.ExportCSV("Sheet1", exported, nil))
+	assert.Equal(t, "Name,Price,Date,Valid\nApple,1.25,07-01-23,TRUE\n\"Banana, ripe\",2,7/2/23 10:30,FALSE\n", exported.String())
+
+	// Test import CSV without type inference on the given start cell
+	f = NewFile()
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader("a;1\nb;2"), &CSVOptions{StartCell: "B2", Delimiter: ';'}))
+	cellType, err = f.GetCellType("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeSharedString, cellType)
+	val, err := f.GetCellValue("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", val)
+
+	// Test import CSV in stream mode
+	f = NewFile()
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader(data), &CSVOptions{InferTypes: true, Stream: true}))
+	var buf bytes.Buffer
+	assert.NoError(t, f.Write(&buf))
+	f, err = OpenReader(&buf)
+	assert.NoError(t, err)
+	val, err = f.GetCellValue("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "07-01-23", val)
+
+	// Test import CSV with type inference but without any date field, the date
+	// style should not be created
+	f = NewFile()
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader("a,1\nb,TRUE"), &CSVOptions{InferTypes: true}))
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Len(t, styles.CellXfs.Xf, 1)
+
+	// Test import CSV with non-UTF-8 charset
+	f = NewFile()
+	encoded, err := charmap.Windows1252.NewEncoder().String("Café,Ü")
+	assert.NoError(t, err)
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader(encoded), &CSVOptions{Charset: "windows-1252"}))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Café", "Ü"}}, rows)
+
+	// Test import CSV with unsupported charset
+	f.CharsetTranscoder(func(charset string, input io.Reader) (rdr io.Reader, err error) {
+		return nil, errors.New("unsupported charset")
+	})
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader(encoded), &CSVOptions{Charset: "unknown"}), "unsupported charset")
+	// Test import CSV with invalid start cell
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader(data), &CSVOptions{StartCell: "A"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test import CSV on not exists worksheet
+	assert.EqualError(t, f.ImportCSV("SheetN", strings.NewReader(data), nil), "sheet SheetN does not exist")
+	assert.EqualError(t, f.ImportCSV("SheetN", strings.NewReader(data), &CSVOptions{Stream: true}), "sheet SheetN does not exist")
+	// Test import CSV with invalid quotes
+	assert.Error(t, f.ImportCSV("Sheet1", strings.NewReader("a\"b\n"), nil))
+	// Test import CSV with columns overflow
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("a,b"), &CSVOptions{StartCell: "XFD1"}), ErrColumnNumber.Error())
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("a,b"), &CSVOptions{StartCell: "XFD1", Stream: true}), ErrColumnNumber.Error())
+}
+
+func TestExportCSV(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Price", "Note"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Apple", 0.5, "a, b"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]interface{}{"Café", 1}))
+	style, err := f.NewStyle(&Style{NumFmt: 10})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", style))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A10", "A10", style))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf, nil))
+	assert.Equal(t, "Name,Price,Note\nApple,50.00%,\"a, b\"\n\nCafé,1\n", buf.String())
+
+	// Test export CSV with raw cell value, custom delimiter and line terminator
+	buf.Reset()
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf, &CSVOptions{Delimiter: ';', UseCRLF: true, RawCellValue: true}))
+	assert.Equal(t, "Name;Price;Note\r\nApple;0.5;a, b\r\n\r\nCafé;1\r\n", buf.String())
+
+	// Test export CSV with non-UTF-8 charset
+	buf.Reset()
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf, &CSVOptions{Charset: "windows-1252"}))
+	decoded, err := charmap.Windows1252.NewDecoder().String(buf.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "Name,Price,Note\nApple,50.00%,\"a, b\"\n\nCafé,1\n", decoded)
+	expected := decoded
+
+	// Test export CSV with stateful charset encoder
+	f3 := NewFile()
+	assert.NoError(t, f3.SetSheetRow("Sheet1", "A1", &[]interface{}{"名前", "日本語"}))
+	buf.Reset()
+	assert.NoError(t, f3.ExportCSV("Sheet1", &buf, &CSVOptions{Charset: "iso-2022-jp"}))
+	decoded, err = japanese.ISO2022JP.NewDecoder().String(buf.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "名前,日本語\n", decoded)
+
+	// Test round trip between import and export, the blank lines will be
+	// skipped on import
+	f2 := NewFile()
+	assert.NoError(t, f2.ImportCSV("Sheet1", strings.NewReader(expected), nil))
+	buf.Reset()
+	assert.NoError(t, f2.ExportCSV("Sheet1", &buf, nil))
+	assert.Equal(t, strings.ReplaceAll(expected, "\n\n", "\n"), buf.String())
+
+	// Test export CSV with unsupported charset
+	assert.EqualError(t, f.ExportCSV("Sheet1", &buf, &CSVOptions{Charset: "unknown"}), newUnsupportedCharsetError("unknown").Error())
+	// Test export CSV on not exists worksheet
+	assert.EqualError(t, f.ExportCSV("SheetN", &buf, nil), "sheet SheetN does not exist")
+	// Test export CSV with unsupported charset for the shared string table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ExportCSV("Sheet1", &buf, nil), "XML syntax error on line 1: invalid UTF-8")
+}
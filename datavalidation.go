@@ -251,17 +251,23 @@ func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	if err != nil {
 		return err
 	}
+	ws.addDataValidation(dv)
+	return err
+}
+
+// addDataValidation provides a function to append the data validation to the
+// worksheet.
+func (ws *xlsxWorksheet) addDataValidation(dv *DataValidation) {
 	if nil == ws.DataValidations {
 		ws.DataValidations = new(xlsxDataValidations)
 	}
 	ws.DataValidations.DataValidation = append(ws.DataValidations.DataValidation, dv)
 	ws.DataValidations.Count = len(ws.DataValidations.DataValidation)
-	return err
 }
 
 // GetDataValidations returns data validations list by given worksheet name.
 func (f *File) GetDataValidations(sheet string) ([]*DataValidation, error) {
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return nil, err
 	}
@@ -1401,6 +1401,7 @@ func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rI
 		FPrintsWithSheet: *opts.PrintObject,
 	}
 	content.TwoCellAnchor = append(content.TwoCellAnchor, &twoCellAnchor)
+	f.setPartModified(drawingXML)
 	f.Drawings.Store(drawingXML, content)
 	return err
 }
@@ -1444,6 +1445,7 @@ func (f *File) addSheetDrawingChart(drawingXML string, rID int, opts *GraphicOpt
 		FPrintsWithSheet: *opts.PrintObject,
 	}
 	content.AbsoluteAnchor = append(content.AbsoluteAnchor, &absoluteAnchor)
+	f.setPartModified(drawingXML)
 	f.Drawings.Store(drawingXML, content)
 	return err
 }
@@ -1488,6 +1490,7 @@ func (f *File) deleteDrawing(col, row int, drawingXML, drawingType string) error
 			}
 		}
 	}
+	f.setPartModified(drawingXML)
 	f.Drawings.Store(drawingXML, wsDr)
 	return err
 }
@@ -82,6 +82,12 @@ func newNoExistSheetError(name string) error {
 	return fmt.Errorf("sheet %s does not exist", name)
 }
 
+// newNoExistNamedStyleError defined the error message on receiving the non
+// existing named cell style name.
+func newNoExistNamedStyleError(name string) error {
+	return fmt.Errorf("named style %s does not exist", name)
+}
+
 // newNotWorksheetError defined the error message on receiving a sheet which
 // not a worksheet.
 func newNotWorksheetError(name string) error {
@@ -106,13 +112,31 @@ func newUnknownFilterTokenError(token string) error {
 	return fmt.Errorf("unknown operator: %s", token)
 }
 
+// newUnsupportedCharsetError defined the error message on receiving the
+// unsupported character encoding.
+func newUnsupportedCharsetError(charset string) error {
+	return fmt.Errorf("unsupported charset %q", charset)
+}
+
+// newInvalidThemeColorError defined the error message on receiving the
+// invalid theme color.
+func newInvalidThemeColorError(color string) error {
+	return fmt.Errorf("invalid theme color %q", color)
+}
+
 var (
 	// ErrStreamSetColWidth defined the error message on set column width in
 	// stream writing mode.
 	ErrStreamSetColWidth = errors.New("must call the SetColWidth function before the SetRow function")
+	// ErrStreamAutoFitColWidth defined the error message on enable auto fit
+	// column width in stream writing mode.
+	ErrStreamAutoFitColWidth = errors.New("must call the AutoFitColWidth function before the SetRow function")
 	// ErrStreamSetPanes defined the error message on set panes in stream
 	// writing mode.
 	ErrStreamSetPanes = errors.New("must call the SetPanes function before the SetRow function")
+	// ErrStreamCompactStyles defined the error message on compact styles of
+	// the workbook with the worksheets written by stream writer.
+	ErrStreamCompactStyles = errors.New("can not compact styles of the workbook with the worksheets written by stream writer")
 	// ErrColumnNumber defined the error message on receive an invalid column
 	// number.
 	ErrColumnNumber = fmt.Errorf(`the column number must be greater than or equal to %d and less than or equal to %d`, MinColumns, MaxColumns)
@@ -136,6 +160,9 @@ var (
 	// ErrAddVBAProject defined the error message on add the VBA project in
 	// the workbook.
 	ErrAddVBAProject = errors.New("unsupported VBA project")
+	// ErrThemeFile defined the error message on load an unsupported theme
+	// file.
+	ErrThemeFile = errors.New("unsupported theme file")
 	// ErrMaxRows defined the error message on receive a row number exceeds maximum limit.
 	ErrMaxRows = errors.New("row number exceeds maximum limit")
 	// ErrMaxRowHeight defined the error message on receive an invalid row
@@ -207,6 +234,9 @@ var (
 	// ErrOptionsUnzipSizeLimit defined the error message for receiving
 	// invalid UnzipSizeLimit and UnzipXMLSizeLimit.
 	ErrOptionsUnzipSizeLimit = errors.New("the value of UnzipSizeLimit should be greater than or equal to UnzipXMLSizeLimit")
+	// ErrOptionsStreamingSave defined the error message for receiving the
+	// StreamingSave option with the StrictOpenXML option.
+	ErrOptionsStreamingSave = errors.New("the StreamingSave option can not be used with the StrictOpenXML option")
 	// ErrSave defined the error message for saving file.
 	ErrSave = errors.New("no path defined for file, consider File.WriteTo or File.Write")
 	// ErrAttrValBool defined the error message on marshal and unmarshal
@@ -247,8 +277,14 @@ var (
 	ErrNameLength = fmt.Errorf("the name length exceeds the %d characters limit", MaxFieldLength)
 	// ErrExistsTableName defined the error message on given table already exists.
 	ErrExistsTableName = errors.New("the same name table already exists")
+	// ErrExistsTableStyle defined the error message on given table style
+	// already exists.
+	ErrExistsTableStyle = errors.New("the same name table style already exists")
 	// ErrCellStyles defined the error message on cell styles exceeds the limit.
 	ErrCellStyles = fmt.Errorf("the cell styles exceeds the %d limit", MaxCellStyles)
+	// ErrExistsNamedStyle defined the error message on given named cell style
+	// already exists.
+	ErrExistsNamedStyle = errors.New("the same name named style already exists")
 	// ErrUnprotectWorkbook defined the error message on workbook has set no
 	// protection.
 	ErrUnprotectWorkbook = errors.New("workbook has set no protect")
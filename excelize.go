@@ -32,9 +32,13 @@ type File struct {
 	options          *Options
 	xmlAttr          map[string][]xml.Attr
 	checked          map[string]bool
+	modifiedParts    sync.Map
 	sheetMap         map[string]string
 	streams          map[string]*StreamWriter
+	zipSource        io.ReaderAt
+	zipFiles         map[string]*zip.File
 	tempFiles        sync.Map
+	tables           sync.Map
 	sharedStringsMap map[string]int
 	sharedStringItem [][]uint
 	sharedStringTemp *os.File
@@ -93,7 +97,42 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // LongTimePattern specifies the long time number format code.
 //
 // CultureInfo specifies the country code for applying built-in language number
-// format code these effect by the system's local language settings.
+// format code these effect by the system's local language settings. The
+// culture decides the short date pattern of the built-in number formats 14
+// and 22, the currency symbol of the built-in number formats 5 to 8, the
+// decimal and thousands separators, and the month, weekday and AM/PM names
+// used in the formatted cell values. The ShortDatePattern, LongDatePattern
+// and LongTimePattern options take precedence over the date and time
+// patterns of the culture.
+//
+// StrictOpenXML specifies if save the spreadsheet in the Strict Open XML
+// (ISO/IEC 29500 Strict) conformance class, all parts will be written with
+// the Strict namespaces and relationship types. The VML drawing parts which
+// are not allowed in Strict will be removed, such as the shapes of the
+// comments and the header and footer pictures, and the date cells before
+// March 1st 1900 will be saved as ISO 8601 date values to avoid the 1900 leap
+// year quirk. The default value is false.
+//
+// StreamingSave specifies if save the spreadsheet by writing the parts into
+// the output writer one by one, the worksheets will be serialized into the
+// zip entries directly, and the parts will be compressed sequentially, so
+// that saving doesn't need extra memory for the serialized worksheets and
+// the compressed parts. When this option was specified on opening the
+// spreadsheet, the unchanged parts will be copied from the source
+// spreadsheet without recompressing. Note that the parts read on opening the
+// spreadsheet are still kept in memory until the file was closed, except the
+// worksheets and shared strings table over the UnzipXMLSizeLimit. This option
+// can not be used with the StrictOpenXML option. The default value is false.
+//
+// Deterministic specifies if save the spreadsheet with reproducible output,
+// the same workbook will be saved as the same bytes on every run. The parts
+// will be written into the zip archive in the order of the part path with
+// the fixed modification time 1980-01-01 00:00:00 UTC, the relationship IDs
+// are always allocated in the order of the operations, and the created and
+// modified timestamps in the core properties part are never set to the
+// current time, use the SetDocProps function to specify them if necessary.
+// This option doesn't take effect on the encrypted spreadsheet, which uses
+// the random salt. The default value is false.
 type Options struct {
 	MaxCalcIterations uint
 	Password          string
@@ -104,6 +143,9 @@ type Options struct {
 	LongDatePattern   string
 	LongTimePattern   string
 	CultureInfo       CultureName
+	StrictOpenXML     bool
+	StreamingSave     bool
+	Deterministic     bool
 }
 
 // OpenFile take the name of a spreadsheet file and returns a populated
@@ -112,6 +154,11 @@ type Options struct {
 //
 //	f, err := excelize.OpenFile("Book1.xlsx", excelize.Options{Password: "password"})
 //
+// The legacy Excel 97-2003 binary workbook (BIFF8), the Excel binary workbook
+// (XLSB) and the OpenDocument spreadsheet (ODS) could also be opened, and
+// they should be saved as XLSX by the SaveAs function, or saved as ODS by the
+// WriteODS function.
+//
 // Close the file by Close function after opening the spreadsheet.
 func OpenFile(filename string, opts ...Options) (*File, error) {
 	file, err := os.Open(filepath.Clean(filename))
@@ -166,6 +213,9 @@ func (f *File) checkOpenReaderOptions() error {
 	if f.options.UnzipXMLSizeLimit > f.options.UnzipSizeLimit {
 		return ErrOptionsUnzipSizeLimit
 	}
+	if f.options.StreamingSave && f.options.StrictOpenXML {
+		return ErrOptionsStreamingSave
+	}
 	return f.checkDateTimePattern()
 }
 
@@ -182,6 +232,9 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 		return nil, err
 	}
 	if bytes.Contains(b, oleIdentifier) {
+		if stream := extractWorkbookStream(b); stream != nil {
+			return openXLS(stream, f.options)
+		}
 		if b, err = Decrypt(b, f.options); err != nil {
 			return nil, ErrWorkbookFileFormat
 		}
@@ -193,6 +246,15 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 		}
 		return nil, err
 	}
+	if isXLSB(zr) {
+		return openXLSB(zr, f.options)
+	}
+	if isODS(zr) {
+		return openODS(zr, f.options)
+	}
+	if f.options.StreamingSave {
+		f.zipSource, f.zipFiles = bytes.NewReader(b), make(map[string]*zip.File, len(zr.File))
+	}
 	file, sheetCount, err := f.ReadZipReader(zr)
 	if err != nil {
 		return nil, err
@@ -207,7 +269,7 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	if f.sheetMap, err = f.getSheetMap(); err != nil {
 		return f, err
 	}
-	if f.Styles, err = f.stylesReader(); err != nil {
+	if f.Styles, err = f.loadStyles(); err != nil {
 		return f, err
 	}
 	f.Theme, err = f.themeReader()
@@ -251,12 +313,21 @@ func (f *File) setDefaultTimeStyle(sheet, cell string, format int) error {
 }
 
 // workSheetReader provides a function to get the pointer to the structure
-// after deserialization by given worksheet name.
-func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
-	var (
-		name string
-		ok   bool
-	)
+// after deserialization by given worksheet name, and mark the worksheet as
+// modified.
+func (f *File) workSheetReader(sheet string) (*xlsxWorksheet, error) {
+	ws, name, err := f.loadWorkSheet(sheet)
+	if err == nil {
+		f.setPartModified(name)
+	}
+	return ws, err
+}
+
+// loadWorkSheet provides a function to get the pointer to the structure after
+// deserialization by given worksheet name without marking the worksheet as
+// modified, it returns the worksheet and the path of the worksheet XML.
+func (f *File) loadWorkSheet(sheet string) (ws *xlsxWorksheet, name string, err error) {
+	var ok bool
 	if err = checkSheetName(sheet); err != nil {
 		return
 	}
@@ -268,11 +339,9 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 		ws = worksheet.(*xlsxWorksheet)
 		return
 	}
-	for _, sheetType := range []string{"xl/chartsheets", "xl/dialogsheet", "xl/macrosheet"} {
-		if strings.HasPrefix(name, sheetType) {
-			err = newNotWorksheetError(sheet)
-			return
-		}
+	if !isWorksheetPath(name) {
+		err = newNotWorksheetError(sheet)
+		return
 	}
 	ws = new(xlsxWorksheet)
 	if _, ok := f.xmlAttr[name]; !ok {
@@ -298,6 +367,17 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 	return
 }
 
+// isWorksheetPath provides a function to check if the sheet XML path is a
+// worksheet, rather than a chartsheet, dialogsheet or macrosheet.
+func isWorksheetPath(name string) bool {
+	for _, sheetType := range []string{"xl/chartsheets", "xl/dialogsheet", "xl/macrosheet"} {
+		if strings.HasPrefix(name, sheetType) {
+			return false
+		}
+	}
+	return true
+}
+
 // checkSheet provides a function to fill each row element and make that is
 // continuous in a worksheet of XML.
 func (ws *xlsxWorksheet) checkSheet() {
@@ -549,11 +629,13 @@ func (f *File) setContentTypePartProjectExtensions(contentType string) error {
 		}
 	}
 	for idx, o := range content.Overrides {
-		if o.PartName == "/xl/workbook.xml" {
+		if o.PartName == "/xl/workbook.xml" && o.ContentType != contentType {
+			f.setPartModified(defaultXMLPathContentTypes)
 			content.Overrides[idx].ContentType = contentType
 		}
 	}
 	if !ok {
+		f.setPartModified(defaultXMLPathContentTypes)
 		content.Defaults = append(content.Defaults, xlsxDefault{
 			Extension:   "bin",
 			ContentType: ContentTypeVBA,
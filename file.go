@@ -14,12 +14,33 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"compress/flate"
 	"encoding/xml"
+	"fmt"
+	"hash/crc32"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
+)
+
+var (
+	// deterministicModTime defined the modification time of the parts in the
+	// zip archive when saving the spreadsheet with deterministic output.
+	deterministicModTime     = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+	strictLegacyDrawingExp   = regexp.MustCompile(`<legacyDrawing(HF)?\s[^>]*?(/>|></legacyDrawing(HF)?>)`)
+	strictVMLRelationshipExp = regexp.MustCompile(`<Relationship\s[^>]*?Type="[^"]*/vmlDrawing"[^>]*?(/>|></Relationship>)`)
+	strictVMLContentTypeExp  = regexp.MustCompile(`<(Default|Override)\s[^>]*?(Extension="vml"|PartName="[^"]*\.vml")[^>]*?(/>|></(Default|Override)>)`)
+	strictNumericCellExp     = regexp.MustCompile(`<c(\s[^>]*?)?>\s*<v>([^<]*)</v>\s*</c>`)
+	strictCellTypeExp        = regexp.MustCompile(`\st="([^"]*)"`)
+	strictCellStyleExp       = regexp.MustCompile(`\ss="(\d+)"`)
 )
 
 // NewFile provides a function to create new file by default template.
@@ -98,6 +119,9 @@ func (f *File) Close() error {
 	})
 	for _, stream := range f.streams {
 		_ = stream.rawData.Close()
+		if stream.existingFile != nil {
+			_ = stream.existingFile.Close()
+		}
 	}
 	return err
 }
@@ -172,62 +196,505 @@ func (f *File) writeDirectToWriter(w io.Writer) error {
 
 // writeToZip provides a function to write to zip.Writer
 func (f *File) writeToZip(zw *zip.Writer) error {
+	var (
+		dateStyles map[int]bool
+		err        error
+	)
+	streaming := f.options != nil && f.options.StreamingSave
+	if streaming && f.options.StrictOpenXML {
+		return ErrOptionsStreamingSave
+	}
+	if f.options != nil && f.options.StrictOpenXML {
+		if dateStyles, err = f.getStrictDateStyles(); err != nil {
+			return err
+		}
+	}
 	f.calcChainWriter()
 	f.commentsWriter()
 	f.contentTypesWriter()
 	f.drawingsWriter()
 	f.vmlDrawingWriter()
 	f.workBookWriter()
-	f.workSheetWriter()
+	if !streaming {
+		f.workSheetWriter()
+	}
 	f.relsWriter()
 	_ = f.sharedStringsLoader()
 	f.sharedStringsWriter()
 	f.styleSheetWriter()
 	f.themeWriter()
+	if streaming {
+		return f.streamToZip(zw)
+	}
 
+	var parts []zipPart
 	for path, stream := range f.streams {
-		fi, err := zw.Create(path)
+		parts = append(parts, zipPart{path: path, stream: stream})
+	}
+	f.Pkg.Range(func(path, content interface{}) bool {
+		if _, ok := f.streams[path.(string)]; ok {
+			return true
+		}
+		data, _ := content.([]byte)
+		parts = append(parts, zipPart{path: path.(string), content: data})
+		return true
+	})
+	f.tempFiles.Range(func(path, content interface{}) bool {
+		if _, ok := f.Pkg.Load(path); ok {
+			return true
+		}
+		if _, ok := f.streams[path.(string)]; ok {
+			return true
+		}
+		parts = append(parts, zipPart{path: path.(string), content: f.readBytes(path.(string))})
+		return true
+	})
+	for i := range parts {
+		parts[i].dateStyles, parts[i].strict = dateStyles, f.options != nil && f.options.StrictOpenXML
+	}
+	if f.options != nil && f.options.Deterministic {
+		sort.Slice(parts, func(i, j int) bool { return parts[i].path < parts[j].path })
+	}
+	return f.writeZipParts(zw, parts)
+}
+
+// zipPart directly maps the part of the spreadsheet to be written into the
+// zip archive, the content of the part was provided by the bytes or the
+// stream writer. The date styles are the cell style indexes with the date
+// number format for saving the spreadsheet in the Strict Open XML conformance
+// class. The compressed data will be kept in memory, and moved into a
+// temporary file on disk when it grows over the stream chunk size.
+type zipPart struct {
+	path       string
+	content    []byte
+	stream     *StreamWriter
+	compressed *bufferedWriter
+	dateStyles map[int]bool
+	strict     bool
+	skip       bool
+	err        error
+}
+
+// reader provides a function to get the reader of the uncompressed content of
+// the part, the content of the stream writer will be converted row by row
+// when saving the spreadsheet in the Strict Open XML conformance class.
+func (part *zipPart) reader() (io.Reader, error) {
+	if part.stream == nil {
+		return bytes.NewReader(part.content), nil
+	}
+	content, err := part.stream.rawData.Reader()
+	if err != nil {
+		_ = part.stream.rawData.Close()
+		return content, err
+	}
+	if part.strict {
+		content = &strictWorksheetReader{path: part.path, src: content, dateStyles: part.dateStyles}
+	}
+	return content, err
+}
+
+// strictWorksheetReader directly maps the reader of the worksheet which
+// converts the content for saving the spreadsheet in the Strict Open XML
+// conformance class. The content will be converted in chunks which end with
+// the row end tag, so that only one chunk of the worksheet will be held in
+// memory.
+type strictWorksheetReader struct {
+	path       string
+	src        io.Reader
+	dateStyles map[int]bool
+	pending    []byte
+	converted  bytes.Buffer
+	eof        bool
+}
+
+// Read reads the converted content of the worksheet.
+func (sr *strictWorksheetReader) Read(p []byte) (int, error) {
+	for sr.converted.Len() == 0 && !sr.eof {
+		var (
+			buf    [32 << 10]byte
+			offset = len(sr.pending)
+		)
+		n, err := sr.src.Read(buf[:])
+		sr.pending = append(sr.pending, buf[:n]...)
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		end := len(sr.pending)
+		if sr.eof = err == io.EOF; !sr.eof {
+			if offset -= len("</row>"); offset < 0 {
+				offset = 0
+			}
+			if end = bytes.LastIndex(sr.pending[offset:], []byte("</row>")); end == -1 {
+				continue
+			}
+			end += offset + len("</row>")
+		}
+		content, _ := strictOpenXMLPart(sr.path, sr.pending[:end], sr.dateStyles)
+		sr.converted.Write(content)
+		sr.pending = append(sr.pending[:0], sr.pending[end:]...)
+	}
+	if sr.converted.Len() == 0 {
+		return 0, io.EOF
+	}
+	return sr.converted.Read(p)
+}
+
+// precompressedWriter directly maps the compressor of the zip.Writer, which
+// discards the uncompressed data and copies the compressed data of the part
+// into the archive on close.
+type precompressedWriter struct {
+	w      io.Writer
+	data   io.Reader
+	closer io.Closer
+}
+
+// Write discards the uncompressed data, it has been compressed before.
+func (pw *precompressedWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+// Close writes the compressed data into the underlying writer, and releases
+// the compressed data if the closer was given.
+func (pw *precompressedWriter) Close() error {
+	_, err := io.Copy(pw.w, pw.data)
+	if pw.closer != nil {
+		if closeErr := pw.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// compressedWriter directly maps the writer of the compressed data of the
+// part, which moves the compressed data into the temporary file when the
+// in-memory data grows large enough.
+type compressedWriter struct {
+	bw *bufferedWriter
+}
+
+// Write writes the compressed data into the buffered writer.
+func (cw *compressedWriter) Write(p []byte) (int, error) {
+	n, err := cw.bw.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, cw.bw.Sync()
+}
+
+// writeZipParts provides a function to compress the parts concurrently, and
+// write the compressed data into the zip.Writer in the given order. The
+// compressed data is the same as compressed by the zip.Writer with the default
+// compressor. At most GOMAXPROCS compressed parts will be held at the same
+// time, and the large compressed parts will be kept in temporary files.
+func (f *File) writeZipParts(zw *zip.Writer, parts []zipPart) error {
+	var source *zipPart
+	zw.RegisterCompressor(zip.Deflate, func(w io.Writer) (io.WriteCloser, error) {
+		if source == nil {
+			return flate.NewWriter(w, 5)
+		}
+		data, err := source.compressed.Reader()
 		if err != nil {
+			return nil, err
+		}
+		return &precompressedWriter{w: w, data: data, closer: source.compressed}, nil
+	})
+	results := make([]chan *zipPart, len(parts))
+	for i := range results {
+		results[i] = make(chan *zipPart, 1)
+	}
+	var wg sync.WaitGroup
+	tokens, done, stopped := make(chan struct{}, runtime.GOMAXPROCS(0)), make(chan struct{}), make(chan struct{})
+	defer func() {
+		close(done)
+		<-stopped
+		wg.Wait()
+		for i := range parts {
+			if parts[i].compressed != nil {
+				_ = parts[i].compressed.Close()
+			}
+		}
+	}()
+	go func() {
+		defer close(stopped)
+		for i := range parts {
+			select {
+			case tokens <- struct{}{}:
+			case <-done:
+				return
+			}
+			wg.Add(1)
+			go func(part *zipPart, result chan *zipPart) {
+				defer wg.Done()
+				part.err = f.compressZipPart(part)
+				result <- part
+			}(&parts[i], results[i])
+		}
+	}()
+	for _, result := range results {
+		part := <-result
+		<-tokens
+		if part.err != nil {
+			return part.err
+		}
+		if part.skip {
+			continue
+		}
+		source = part
+		fi, err := f.createZipPart(zw, part.path)
+		if source = nil; err != nil {
 			return err
 		}
-		var from io.Reader
-		from, err = stream.rawData.Reader()
+		// The compressed data will be released by the zip.Writer
+		part.compressed = nil
+		content, err := part.reader()
 		if err != nil {
-			_ = stream.rawData.Close()
 			return err
 		}
-		_, err = io.Copy(fi, from)
-		if err != nil {
+		if _, err = io.Copy(fi, content); err != nil {
 			return err
 		}
+		part.content = nil
 	}
-	var err error
-	f.Pkg.Range(func(path, content interface{}) bool {
-		if err != nil {
-			return false
+	return nil
+}
+
+// compressZipPart provides a function to compress the content of the part
+// with the same compression level as the default compressor of the
+// zip.Writer. The part will be converted when saving the spreadsheet in the
+// Strict Open XML conformance class.
+func (f *File) compressZipPart(part *zipPart) error {
+	content, err := part.reader()
+	if err != nil {
+		return err
+	}
+	if part.strict && part.stream == nil {
+		var ok bool
+		if part.content, ok = strictOpenXMLPart(part.path, part.content, part.dateStyles); !ok {
+			part.skip = true
+			return nil
 		}
-		if _, ok := f.streams[path.(string)]; ok {
-			return true
+		content = bytes.NewReader(part.content)
+	}
+	part.compressed = new(bufferedWriter)
+	fw, err := flate.NewWriter(&compressedWriter{bw: part.compressed}, 5)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(fw, content); err != nil {
+		return err
+	}
+	if err = fw.Close(); err != nil {
+		return err
+	}
+	return part.compressed.Flush()
+}
+
+// streamToZip provides a function to write the parts into the zip.Writer
+// one by one, the worksheets will be serialized into the zip entries directly,
+// and the unchanged parts will be copied from the source spreadsheet without
+// recompressing.
+func (f *File) streamToZip(zw *zip.Writer) error {
+	var source io.Reader
+	zw.RegisterCompressor(zip.Deflate, func(w io.Writer) (io.WriteCloser, error) {
+		if source != nil {
+			return &precompressedWriter{w: w, data: source}, nil
 		}
-		var fi io.Writer
-		fi, err = zw.Create(path.(string))
-		if err != nil {
-			return false
+		return flate.NewWriter(w, 5)
+	})
+	var paths []string
+	sheets, parts := make(map[string]*xlsxWorksheet), make(map[string]bool)
+	for path := range f.streams {
+		paths, parts[path] = append(paths, path), true
+	}
+	f.Sheet.Range(func(path, ws interface{}) bool {
+		if parts[path.(string)] || ws == nil || !f.isPartModified(path.(string)) {
+			return true
 		}
-		_, err = fi.Write(content.([]byte))
+		paths, parts[path.(string)] = append(paths, path.(string)), true
+		sheets[path.(string)] = ws.(*xlsxWorksheet)
 		return true
 	})
-	f.tempFiles.Range(func(path, content interface{}) bool {
-		if _, ok := f.Pkg.Load(path); ok {
-			return true
+	f.Pkg.Range(func(path, content interface{}) bool {
+		if !parts[path.(string)] {
+			paths, parts[path.(string)] = append(paths, path.(string)), true
 		}
-		var fi io.Writer
-		fi, err = zw.Create(path.(string))
-		if err != nil {
-			return false
+		return true
+	})
+	f.tempFiles.Range(func(path, tempFile interface{}) bool {
+		if !parts[path.(string)] {
+			paths, parts[path.(string)] = append(paths, path.(string)), true
 		}
-		_, err = fi.Write(f.readBytes(path.(string)))
 		return true
 	})
+	if f.options.Deterministic {
+		sort.Strings(paths)
+	}
+	for _, path := range paths {
+		source = nil
+		if err := f.streamZipPart(zw, path, sheets[path], &source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamZipPart provides a function to write the part into the zip.Writer by
+// given part path, the worksheet will be serialized into the zip entry
+// directly if it's not nil. The source will be set to the reader of the
+// compressed data in the source spreadsheet when the part is unchanged.
+func (f *File) streamZipPart(zw *zip.Writer, path string, ws *xlsxWorksheet, source *io.Reader) error {
+	if stream, ok := f.streams[path]; ok {
+		from, err := stream.rawData.Reader()
+		if err != nil {
+			_ = stream.rawData.Close()
+			return err
+		}
+		fi, err := f.createZipPart(zw, path)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(fi, from)
+		return err
+	}
+	if ws != nil {
+		fi, err := f.createZipPart(zw, path)
+		if err != nil {
+			return err
+		}
+		return f.writeWorkSheet(fi, path, ws)
+	}
+	if content, ok := f.Pkg.Load(path); ok {
+		data, _ := content.([]byte)
+		*source = f.getZipSource(path, data, true)
+		fi, err := f.createZipPart(zw, path)
+		if err != nil {
+			return err
+		}
+		_, err = fi.Write(data)
+		return err
+	}
+	tempFile, _ := f.tempFiles.Load(path)
+	file, err := os.Open(tempFile.(string))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	*source = f.getZipSource(path, nil, false)
+	fi, err := f.createZipPart(zw, path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fi, file)
 	return err
 }
+
+// createZipPart provides a function to add a file to the zip.Writer by given
+// part path, the modification time of the file will be fixed when saving the
+// spreadsheet with deterministic output, otherwise the current time will be
+// used.
+func (f *File) createZipPart(zw *zip.Writer, path string) (io.Writer, error) {
+	modified := time.Now()
+	if f.options != nil && f.options.Deterministic {
+		modified = deterministicModTime
+	}
+	return zw.CreateHeader(&zip.FileHeader{Name: path, Method: zip.Deflate, Modified: modified})
+}
+
+// getZipSource returns the reader of the compressed data of the part in the
+// source spreadsheet by given part path, it will return nil if the part
+// doesn't exist in the source spreadsheet, or the content of the part was
+// changed when the checksum is required.
+func (f *File) getZipSource(path string, content []byte, checksum bool) io.Reader {
+	file, ok := f.zipFiles[path]
+	if !ok || file.Method != zip.Deflate {
+		return nil
+	}
+	if checksum && (uint64(len(content)) != file.UncompressedSize64 || crc32.ChecksumIEEE(content) != file.CRC32) {
+		return nil
+	}
+	offset, err := file.DataOffset()
+	if err != nil {
+		return nil
+	}
+	return io.NewSectionReader(f.zipSource, offset, int64(file.CompressedSize64))
+}
+
+// getStrictDateStyles provides a function to get the cell style indexes with
+// the date number format for saving the spreadsheet in the Strict Open XML
+// conformance class, the date values before March 1st 1900 of the cells with
+// these styles will be converted to the ISO 8601 dates, it returns nil if the
+// workbook uses the 1904 date system.
+func (f *File) getStrictDateStyles() (map[int]bool, error) {
+	wb, err := f.loadWorkbook()
+	if err != nil {
+		return nil, err
+	}
+	if wb.WorkbookPr != nil && wb.WorkbookPr.Date1904 {
+		return nil, err
+	}
+	styleSheet, err := f.loadStyles()
+	if err != nil {
+		return nil, err
+	}
+	dateStyles := make(map[int]bool)
+	if styleSheet.CellXfs != nil {
+		for styleID, xf := range styleSheet.CellXfs.Xf {
+			if isDateNumFmt(f.getXfNumFmtCode(styleSheet, xf)) {
+				dateStyles[styleID] = true
+			}
+		}
+	}
+	return dateStyles, err
+}
+
+// strictDateCells provides a function to convert the numeric cells with the
+// date number format before March 1st 1900 in the worksheet into the ISO 8601
+// date cells by given cell style indexes with the date number format, these
+// date values are affected by the 1900 leap year quirk.
+func strictDateCells(content []byte, dateStyles map[int]bool) []byte {
+	return strictNumericCellExp.ReplaceAllFunc(content, func(cell []byte) []byte {
+		match := strictNumericCellExp.FindSubmatch(cell)
+		attrs := match[1]
+		if cellType := strictCellTypeExp.FindSubmatch(attrs); cellType != nil && string(cellType[1]) != "n" {
+			return cell
+		}
+		style := strictCellStyleExp.FindSubmatch(attrs)
+		if style == nil {
+			return cell
+		}
+		styleID, _ := strconv.Atoi(string(style[1]))
+		val, err := strconv.ParseFloat(string(match[2]), 64)
+		if !dateStyles[styleID] || err != nil || val < 1 || val >= 61 {
+			return cell
+		}
+		days := math.Floor(val)
+		date := excelMinTime1900.AddDate(0, 0, int(days)).Add(time.Duration((val - days) * nanosInADay)).Round(time.Second)
+		return []byte(fmt.Sprintf(`<c%s t="d"><v>%s</v></c>`,
+			strictCellTypeExp.ReplaceAll(attrs, nil), date.Format("2006-01-02T15:04:05Z")))
+	})
+}
+
+// strictOpenXMLPart provides a function to convert the content of the part
+// for saving the spreadsheet in the Strict Open XML conformance class. The
+// VML drawing parts and the references of them will be removed, the date
+// cells of the worksheets will be converted by given date styles, and it
+// returns false if the part should be skipped.
+func strictOpenXMLPart(path string, content []byte, dateStyles map[int]bool) ([]byte, bool) {
+	name := strings.ToLower(path)
+	if dateStyles != nil && strings.HasPrefix(name, "xl/worksheets/") && strings.HasSuffix(name, ".xml") {
+		content = strictDateCells(content, dateStyles)
+	}
+	switch {
+	case strings.HasSuffix(name, ".vml"):
+		return nil, false
+	case strings.HasSuffix(name, ".rels"):
+		content = strictVMLRelationshipExp.ReplaceAll(content, nil)
+	case name == strings.ToLower(defaultXMLPathContentTypes):
+		content = strictVMLContentTypeExp.ReplaceAll(content, nil)
+	case strings.HasSuffix(name, ".xml"):
+		content = strictLegacyDrawingExp.ReplaceAll(content, nil)
+	default:
+		return content, true
+	}
+	return namespaceTransitionalToStrict(content), true
+}
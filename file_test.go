@@ -1,13 +1,21 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bufio"
 	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -95,3 +103,448 @@ func TestClose(t *testing.T) {
 	f.tempFiles.Store("/d/", "/d/")
 	require.Error(t, f.Close())
 }
+
+func TestWriteStrictOpenXML(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 100))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", 15.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", 45306))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	style, err := f.NewStyle(&Style{NumFmt: 22})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A4", style))
+
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	parts := map[string]string{}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	for _, file := range zr.File {
+		content, err := readFile(file)
+		assert.NoError(t, err)
+		parts[file.Name] = string(content)
+	}
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], "<legacyDrawing")
+	expected := map[string]string{}
+	for _, cell := range []string{"A1", "A2", "A3", "A4"} {
+		expected[cell], err = f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+	}
+
+	buf.Reset()
+	assert.NoError(t, f.Write(buf, Options{StrictOpenXML: true}))
+	parts = map[string]string{}
+	zr, err = zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	for _, file := range zr.File {
+		assert.False(t, strings.HasSuffix(file.Name, ".vml"))
+		content, err := readFile(file)
+		assert.NoError(t, err)
+		parts[file.Name] = string(content)
+	}
+	for _, name := range []string{"xl/workbook.xml", "xl/worksheets/sheet1.xml", "xl/styles.xml", "xl/comments1.xml", "xl/sharedStrings.xml"} {
+		assert.Contains(t, parts[name], StrictNameSpaceSpreadSheet, name)
+		assert.NotContains(t, parts[name], NameSpaceSpreadSheet.Value, name)
+	}
+	assert.Contains(t, parts["xl/workbook.xml"], `conformance="strict"`)
+	assert.Contains(t, parts["xl/workbook.xml"], `dateCompatibility="false"`)
+	assert.Contains(t, parts["xl/_rels/workbook.xml.rels"], StrictSourceRelationshipOfficeDocument[:len(StrictSourceRelationship)])
+	assert.Contains(t, parts["docProps/app.xml"], StrictNameSpaceExtendedProperties)
+	assert.Contains(t, parts["_rels/.rels"], StrictSourceRelationshipOfficeDocument)
+	assert.NotContains(t, parts["xl/worksheets/sheet1.xml"], "<legacyDrawing")
+	assert.NotContains(t, parts["xl/worksheets/_rels/sheet1.xml.rels"], "vmlDrawing")
+	assert.NotContains(t, parts["[Content_Types].xml"], "vml")
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<c r="A3" s="1" t="d"><v>1900-01-15T12:00:00Z</v></c>`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<c r="A4" s="1"><v>45306</v></c>`)
+	// Test the workbook was not changed by saving in Strict Open XML
+	cellType, err := f.GetCellType("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeUnset, cellType)
+	assert.Nil(t, f.WorkBook.WorkbookPr.DateCompatibility)
+
+	// Test reopen the Strict Open XML workbook
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for cell, value := range expected {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, value, val, cell)
+	}
+	// Test save the Strict Open XML workbook as Transitional
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NotContains(t, buf.String(), `conformance="strict"`)
+	assert.NoError(t, f.Close())
+
+	// Test save the Strict Open XML workbook with stream writer
+	f = NewFile(Options{StrictOpenXML: true})
+	style, err = f.NewStyle(&Style{NumFmt: 22})
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Stream", Cell{StyleID: style, Value: 15.5}}))
+	assert.NoError(t, sw.Flush())
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	zr, err = zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	for _, file := range zr.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			content, err := readFile(file)
+			assert.NoError(t, err)
+			assert.Contains(t, string(content), `<c r="B1" s="1" t="d"><v>1900-01-15T12:00:00Z</v></c>`)
+		}
+	}
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Stream", val)
+	assert.NoError(t, f.Close())
+
+	// Test convert the worksheet of stream writer in chunks is the same as
+	// converting the whole worksheet
+	f = NewFile(Options{StrictOpenXML: true})
+	style, err = f.NewStyle(&Style{NumFmt: 22})
+	assert.NoError(t, err)
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	for row := 1; row <= 5000; row++ {
+		cell, _ := CoordinatesToCellName(1, row)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{"Stream", Cell{StyleID: style, Value: float64(row%60) + 1.5}}))
+	}
+	assert.NoError(t, sw.Flush())
+	raw, err := sw.rawData.Reader()
+	assert.NoError(t, err)
+	data, err := io.ReadAll(raw)
+	assert.NoError(t, err)
+	dateStyles, err := f.getStrictDateStyles()
+	assert.NoError(t, err)
+	converted, ok := strictOpenXMLPart("xl/worksheets/sheet1.xml", data, dateStyles)
+	assert.True(t, ok)
+	part := zipPart{path: "xl/worksheets/sheet1.xml", stream: sw, dateStyles: dateStyles, strict: true}
+	content, err := part.reader()
+	assert.NoError(t, err)
+	result, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.Equal(t, string(converted), string(result))
+	assert.NoError(t, f.Close())
+	// Test convert the worksheet of stream writer with read error
+	_, err = io.ReadAll(&strictWorksheetReader{src: iotest.ErrReader(ErrParameterInvalid)})
+	assert.Equal(t, ErrParameterInvalid, err)
+
+	// Test save the Strict Open XML workbook with unsupported charset
+	f = NewFile(Options{StrictOpenXML: true})
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.Write(buf), "XML syntax error on line 1: invalid UTF-8")
+	f = NewFile(Options{StrictOpenXML: true})
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.Write(buf), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestWriteZipParts(t *testing.T) {
+	f := NewFile()
+	for i := 1; i <= 5; i++ {
+		sheet := fmt.Sprintf("Sheet%d", i)
+		if i > 1 {
+			_, err := f.NewSheet(sheet)
+			assert.NoError(t, err)
+		}
+		sw, err := f.NewStreamWriter(sheet)
+		assert.NoError(t, err)
+		for rowID := 1; rowID <= 1000; rowID++ {
+			cell, _ := CoordinatesToCellName(1, rowID)
+			assert.NoError(t, sw.SetRow(cell, []interface{}{sheet, rowID, float64(rowID) / 3}))
+		}
+		assert.NoError(t, sw.Flush())
+	}
+	assert.NoError(t, f.SetDocProps(&DocProperties{Creator: "Excelize"}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, zr.File)
+	// Test the parallel compressed parts are identical to the parts compressed
+	// by the default compressor of the zip.Writer
+	for _, file := range zr.File {
+		content, err := readFile(file)
+		assert.NoError(t, err)
+		ref := new(bytes.Buffer)
+		zw := zip.NewWriter(ref)
+		fi, err := zw.Create(file.Name)
+		assert.NoError(t, err)
+		_, err = fi.Write(content)
+		assert.NoError(t, err)
+		assert.NoError(t, zw.Close())
+		refReader, err := zip.NewReader(bytes.NewReader(ref.Bytes()), int64(ref.Len()))
+		assert.NoError(t, err)
+		assert.Equal(t, rawZipFileData(t, refReader.File[0], ref.Bytes()), rawZipFileData(t, file, buf.Bytes()), file.Name)
+		assert.Equal(t, refReader.File[0].CRC32, file.CRC32, file.Name)
+		assert.WithinDuration(t, time.Now(), file.Modified, time.Minute, file.Name)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test write parts with the large compressed data in the temporary file
+	content := make([]byte, StreamChunkSize+1024)
+	_, err = rand.New(rand.NewSource(1)).Read(content)
+	assert.NoError(t, err)
+	part := zipPart{path: "large.bin", content: content}
+	assert.NoError(t, f.compressZipPart(&part))
+	assert.NotNil(t, part.compressed.tmp)
+	assert.NoError(t, part.compressed.Close())
+	buf.Reset()
+	zw := zip.NewWriter(buf)
+	assert.NoError(t, f.writeZipParts(zw, []zipPart{{path: "large.bin", content: content}, {path: "small.bin", content: []byte("s")}}))
+	assert.NoError(t, zw.Close())
+	zr, err = zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	for i, expected := range [][]byte{content, []byte("s")} {
+		data, err := readFile(zr.File[i])
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data)
+	}
+
+	// Test write parts with error before all parts compressed
+	parts := []zipPart{{path: strings.Repeat("s", 1<<16)}}
+	for i := 0; i < 100; i++ {
+		parts = append(parts, zipPart{path: strconv.Itoa(i), content: []byte("s")})
+	}
+	assert.EqualError(t, f.writeZipParts(zip.NewWriter(io.Discard), parts), "zip: FileHeader.Name too long")
+}
+
+// rawZipFileData returns the compressed data of the file in the zip archive.
+func rawZipFileData(t *testing.T, file *zip.File, archive []byte) []byte {
+	offset, err := file.DataOffset()
+	assert.NoError(t, err)
+	return archive[offset : offset+int64(file.CompressedSize64)]
+}
+
+func TestStreamingSave(t *testing.T) {
+	readZipParts := func(b []byte) (map[string][]byte, map[string]*zip.File) {
+		zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		assert.NoError(t, err)
+		contents, files := make(map[string][]byte), make(map[string]*zip.File)
+		for _, file := range zr.File {
+			content, err := readFile(file)
+			assert.NoError(t, err)
+			contents[file.Name], files[file.Name] = content, file
+		}
+		return contents, files
+	}
+	source, err := os.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	_, sourceFiles := readZipParts(source)
+
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Streaming"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	expected, _ := readZipParts(buf.Bytes())
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{StreamingSave: true, UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Streaming"))
+	// Test the loaded worksheet without modification will be copied
+	_, err = f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	contents, files := readZipParts(buf.Bytes())
+	assert.Len(t, contents, len(expected))
+	for name, content := range expected {
+		assert.Equal(t, string(content), string(contents[name]), name)
+	}
+	// Test the unchanged parts were copied from the source spreadsheet
+	for _, name := range []string{"xl/media/image1.jpeg", "xl/charts/chart1.xml", "xl/worksheets/sheet2.xml"} {
+		assert.Equal(t, rawZipFileData(t, sourceFiles[name], source), rawZipFileData(t, files[name], buf.Bytes()), name)
+	}
+	// Test save the workbook twice in streaming mode
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	contents, _ = readZipParts(buf.Bytes())
+	assert.Equal(t, string(expected["xl/worksheets/sheet1.xml"]), string(contents["xl/worksheets/sheet1.xml"]))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Streaming", val)
+	assert.NoError(t, f.Close())
+
+	// Test streaming save with stream writer
+	f = NewFile(Options{StreamingSave: true})
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Stream"}))
+	assert.NoError(t, sw.Flush())
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "Sheet"))
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for sheet, expected := range map[string]string{"Sheet1": "Stream", "Sheet2": "Sheet"} {
+		val, err := f.GetCellValue(sheet, "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test streaming save with invalid part path
+	f = NewFile(Options{StreamingSave: true})
+	f.Pkg.Store("/d/", []byte("s"))
+	assert.EqualError(t, f.Write(io.Discard), "zip: write to directory")
+	f = NewFile(Options{StreamingSave: true})
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	f.Sheet.Store(strings.Repeat("s", 1<<16), ws)
+	assert.EqualError(t, f.Write(io.Discard), "zip: FileHeader.Name too long")
+	f = NewFile(Options{StreamingSave: true})
+	f.tempFiles.Store(strings.Repeat("s", 1<<16), "")
+	assert.Error(t, f.Write(io.Discard))
+	f = NewFile(Options{StreamingSave: true})
+	f.tempFiles.Store("s", filepath.Join("test", "Book1.xlsx"))
+	f.tempFiles.Store(strings.Repeat("s", 1<<16), filepath.Join("test", "Book1.xlsx"))
+	assert.EqualError(t, f.Write(io.Discard), "zip: FileHeader.Name too long")
+	f.tempFiles = sync.Map{}
+	f.streams = map[string]*StreamWriter{strings.Repeat("s", 1<<16): {}}
+	assert.EqualError(t, f.Write(io.Discard), "zip: FileHeader.Name too long")
+	// Test get the source of the part without the source spreadsheet
+	assert.Nil(t, f.getZipSource("xl/workbook.xml", nil, false))
+	// Test streaming save with the Strict Open XML conformance class
+	_, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{StreamingSave: true, StrictOpenXML: true})
+	assert.Equal(t, ErrOptionsStreamingSave, err)
+	f = NewFile()
+	assert.Equal(t, ErrOptionsStreamingSave, f.Write(io.Discard, Options{StreamingSave: true, StrictOpenXML: true}))
+	assert.NoError(t, f.Close())
+}
+
+func TestWriteUnchangedParts(t *testing.T) {
+	readZipParts := func(b []byte) map[string][]byte {
+		zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		assert.NoError(t, err)
+		contents := make(map[string][]byte)
+		for _, file := range zr.File {
+			content, err := readFile(file)
+			assert.NoError(t, err)
+			contents[file.Name] = content
+		}
+		return contents
+	}
+	source, err := os.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	expected := readZipParts(source)
+	for _, opts := range []Options{{}, {UnzipXMLSizeLimit: 128}} {
+		f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), opts)
+		assert.NoError(t, err)
+		for _, sheet := range f.GetSheetList() {
+			_, err = f.GetCellValue(sheet, "A1")
+			assert.NoError(t, err)
+			_, _, err = f.GetCellHyperLink(sheet, "A1")
+			assert.NoError(t, err)
+			assert.NotZero(t, f.getColWidth(sheet, 1))
+			assert.NotZero(t, f.getRowHeight(sheet, 1))
+		}
+		_, err = f.GetPictures("Sheet2", "I1")
+		assert.NoError(t, err)
+		_, err = f.GetComments("Sheet2")
+		assert.NoError(t, err)
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		// Test the untouched parts were kept byte for byte
+		contents := readZipParts(buf.Bytes())
+		for name, content := range expected {
+			assert.Equal(t, string(content), string(contents[name]), name)
+		}
+
+		// Test only the modified parts were serialized
+		assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Modified"))
+		buf, err = f.WriteToBuffer()
+		assert.NoError(t, err)
+		contents = readZipParts(buf.Bytes())
+		for name, content := range expected {
+			if name == "xl/worksheets/sheet1.xml" || name == "xl/sharedStrings.xml" {
+				assert.NotEqual(t, string(content), string(contents[name]), name)
+				continue
+			}
+			assert.Equal(t, string(content), string(contents[name]), name)
+		}
+		assert.NoError(t, f.Close())
+	}
+
+	// Test check modified parts
+	f := NewFile()
+	assert.True(t, f.isPartModified("xl/worksheets/sheet2.xml"))
+	f.Pkg.Store("xl/worksheets/sheet2.xml", []byte(templateSheet))
+	assert.False(t, f.isPartModified("xl/worksheets/sheet2.xml"))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.True(t, f.isPartModified("xl/worksheets/sheet2.xml"))
+	assert.NoError(t, f.Close())
+}
+
+func TestDeterministicOutput(t *testing.T) {
+	newWorkbook := func(opts Options) []byte {
+		f := NewFile(opts)
+		_, err := f.NewSheet("Sheet2")
+		assert.NoError(t, err)
+		for _, sheet := range f.GetSheetList() {
+			assert.NoError(t, f.SetCellValue(sheet, "A1", sheet))
+			assert.NoError(t, f.AddComment(sheet, Comment{Cell: "B2", Author: "Excelize", Text: sheet}))
+			assert.NoError(t, f.AddPicture(sheet, "D4", filepath.Join("test", "images", "excel.png"), nil))
+			assert.NoError(t, f.AddChart(sheet, "J1", &Chart{Type: Col, Series: []ChartSeries{{Values: sheet + "!$A$1:$A$2"}}}))
+			assert.NoError(t, f.SetCellHyperLink(sheet, "A5", "https://github.com/xuri/excelize", "External"))
+		}
+		assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A10:B12"}))
+		assert.NoError(t, f.AddPicture("Sheet1", "D8", filepath.Join("test", "images", "excel.jpg"), nil))
+		sw, err := f.NewStreamWriter("Sheet2")
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow("A1", []interface{}{1, 2}))
+		assert.NoError(t, sw.Flush())
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		// Test save the same workbook twice with the same bytes
+		again, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		assert.Equal(t, buf.Bytes(), again.Bytes())
+		assert.NoError(t, f.Close())
+		return buf.Bytes()
+	}
+	for _, opts := range []Options{{Deterministic: true}, {Deterministic: true, StreamingSave: true}} {
+		expected := newWorkbook(opts)
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, expected, newWorkbook(opts))
+		}
+		zr, err := zip.NewReader(bytes.NewReader(expected), int64(len(expected)))
+		assert.NoError(t, err)
+		var paths []string
+		for _, file := range zr.File {
+			assert.True(t, file.Modified.Equal(deterministicModTime), file.Name)
+			paths = append(paths, file.Name)
+		}
+		assert.True(t, sort.StringsAreSorted(paths))
+		assert.Equal(t, defaultXMLPathContentTypes, paths[0])
+		// Test the relationship IDs are allocated in the order of the operations
+		f, err := OpenReader(bytes.NewReader(expected))
+		assert.NoError(t, err)
+		rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+		assert.NoError(t, err)
+		var IDs []string
+		for _, rel := range rels.Relationships {
+			IDs = append(IDs, rel.ID+" "+rel.Target)
+		}
+		assert.Equal(t, []string{
+			"rId1 ../drawings/vmlDrawing1.vml", "rId2 ../comments1.xml", "rId3 ../drawings/drawing1.xml",
+			"rId4 https://github.com/xuri/excelize", "rId5 ../tables/table1.xml",
+		}, IDs)
+		// Test the timestamps in the core properties are not set to the current time
+		props, err := f.GetDocProps()
+		assert.NoError(t, err)
+		assert.Equal(t, "2006-09-16T00:00:00Z", props.Created)
+		assert.Equal(t, "2006-09-16T00:00:00Z", props.Modified)
+		assert.NoError(t, f.Close())
+	}
+}
@@ -0,0 +1,617 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// HTMLOptions directly maps the settings of the HTML export.
+//
+// Range specifies the cell range reference to export, the default value is
+// the used range of the worksheet.
+//
+// RawCellValue specifies if export the raw value of the cells, or apply the
+// number format for the cell value.
+//
+// Title specifies the title of the HTML document, the default value is the
+// worksheet name.
+//
+// Fragment specifies if only write the style and table elements without the
+// HTML document wrapper, this is useful to embed the table in another
+// document.
+type HTMLOptions struct {
+	Range        string
+	RawCellValue bool
+	Title        string
+	Fragment     bool
+}
+
+// htmlBorderStyles defined the mapping of cell border styles and CSS border
+// declarations.
+var htmlBorderStyles = map[string]string{
+	"thin":             "1px solid",
+	"medium":           "2px solid",
+	"thick":            "3px solid",
+	"dashed":           "1px dashed",
+	"dotted":           "1px dotted",
+	"double":           "3px double",
+	"hair":             "1px dotted",
+	"mediumDashed":     "2px dashed",
+	"dashDot":          "1px dashed",
+	"mediumDashDot":    "2px dashed",
+	"dashDotDot":       "1px dotted",
+	"mediumDashDotDot": "2px dotted",
+	"slantDashDot":     "2px dashed",
+}
+
+// htmlCell defined the cell value, style and layout for HTML export.
+type htmlCell struct {
+	value            string
+	styleID          int
+	numeric          bool
+	link             string
+	colSpan, rowSpan int
+}
+
+// htmlSheet defined the worksheet snapshot for HTML export.
+type htmlSheet struct {
+	coordinates   []int
+	defaultHeight int
+	cells         map[int]map[int]*htmlCell
+	covered       map[int]map[int]bool
+	hiddenRows    map[int]bool
+	hiddenCols    map[int]bool
+	rowHeights    map[int]int
+	colWidths     map[int]int
+	styles        []int
+}
+
+// WriteHTML provides a function to write the worksheet as an HTML document to
+// io.Writer by given worksheet name and HTML options. The cells are rendered
+// with the number formatted values, fonts, fills, borders and alignment of
+// cell styles, the merged cells are rendered as spanned table cells, the
+// hyperlinks are rendered as links, and the hidden rows and columns will be
+// skipped. For example, write the preview of the first 20 rows as a fragment:
+//
+//	var buf bytes.Buffer
+//	if err := f.WriteHTML("Sheet1", &buf, &excelize.HTMLOptions{
+//	    Range:    "A1:H20",
+//	    Fragment: true,
+//	}); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) WriteHTML(sheet string, w io.Writer, opts *HTMLOptions) error {
+	options := HTMLOptions{}
+	if opts != nil {
+		options = *opts
+	}
+	if options.Title == "" {
+		options.Title = sheet
+	}
+	hs, err := f.newHTMLSheet(sheet, &options)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	if !options.Fragment {
+		_, _ = bw.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>")
+		_, _ = bw.WriteString(html.EscapeString(options.Title))
+		_, _ = bw.WriteString("</title>\n")
+	}
+	if err = f.writeHTMLStyles(bw, hs); err != nil {
+		return err
+	}
+	if !options.Fragment {
+		_, _ = bw.WriteString("</head>\n<body>\n")
+	}
+	hs.writeTable(bw)
+	if !options.Fragment {
+		_, _ = bw.WriteString("</body>\n</html>\n")
+	}
+	return bw.Flush()
+}
+
+// newHTMLSheet provides a function to take a snapshot of the cell values,
+// styles, merged cells, hyperlinks, hidden rows and columns, row heights and
+// column widths of the worksheet for HTML export.
+func (f *File) newHTMLSheet(sheet string, opts *HTMLOptions) (*htmlSheet, error) {
+	f.mu.Lock()
+	ws, _, err := f.loadWorkSheet(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return nil, err
+	}
+	hs := &htmlSheet{
+		cells:      make(map[int]map[int]*htmlCell),
+		covered:    make(map[int]map[int]bool),
+		hiddenRows: make(map[int]bool),
+		hiddenCols: make(map[int]bool),
+		rowHeights: make(map[int]int),
+		colWidths:  make(map[int]int),
+	}
+	if opts.Range != "" {
+		if hs.coordinates, err = rangeRefToCoordinates(opts.Range); err != nil {
+			return nil, err
+		}
+		_ = sortCoordinates(hs.coordinates)
+	}
+	hs.defaultHeight = int(defaultRowHeightPixels)
+	ws.mu.Lock()
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultRowHeight > 0 {
+		hs.defaultHeight = int(convertRowHeightToPixels(ws.SheetFormatPr.DefaultRowHeight))
+	}
+	usedRange := []int{MaxColumns, TotalRows, 0, 0}
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		hs.hiddenRows[row.R] = row.Hidden
+		if hs.rowHeights[row.R] = hs.defaultHeight; row.Ht != nil {
+			hs.rowHeights[row.R] = int(convertRowHeightToPixels(*row.Ht))
+		}
+		for colIdx := range row.C {
+			c := row.C[colIdx]
+			if !c.hasValue() {
+				continue
+			}
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				ws.mu.Unlock()
+				return nil, err
+			}
+			cellType := cellTypes[c.T]
+			val, err := c.getValueFrom(f, sst, opts.RawCellValue)
+			if err != nil {
+				ws.mu.Unlock()
+				return nil, err
+			}
+			if hs.cells[row.R] == nil {
+				hs.cells[row.R] = make(map[int]*htmlCell)
+			}
+			hs.cells[row.R][col] = &htmlCell{
+				value: val, styleID: c.S, colSpan: 1, rowSpan: 1,
+				numeric: (cellType == CellTypeUnset || cellType == CellTypeNumber) && c.V != "",
+			}
+			usedRange = []int{
+				int(math.Min(float64(usedRange[0]), float64(col))),
+				int(math.Min(float64(usedRange[1]), float64(row.R))),
+				int(math.Max(float64(usedRange[2]), float64(col))),
+				int(math.Max(float64(usedRange[3]), float64(row.R))),
+			}
+		}
+	}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			for c := col.Min; c <= col.Max; c++ {
+				hs.hiddenCols[c] = col.Hidden
+			}
+		}
+	}
+	var mergeCells []string
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			mergeCells = append(mergeCells, mergeCell.Ref)
+		}
+	}
+	hyperlinks := map[string]xlsxHyperlink{}
+	if ws.Hyperlinks != nil {
+		for _, link := range ws.Hyperlinks.Hyperlink {
+			hyperlinks[link.Ref] = link
+		}
+	}
+	ws.mu.Unlock()
+	if hs.coordinates == nil {
+		for _, ref := range mergeCells {
+			if rect, err := rangeRefToCoordinates(ref); err == nil && usedRange[2] != 0 {
+				_ = sortCoordinates(rect)
+				usedRange = []int{
+					int(math.Min(float64(usedRange[0]), float64(rect[0]))),
+					int(math.Min(float64(usedRange[1]), float64(rect[1]))),
+					int(math.Max(float64(usedRange[2]), float64(rect[2]))),
+					int(math.Max(float64(usedRange[3]), float64(rect[3]))),
+				}
+			}
+		}
+		if usedRange[2] == 0 {
+			usedRange = []int{1, 1, 1, 1}
+		}
+		hs.coordinates = usedRange
+	}
+	for c := hs.coordinates[0]; c <= hs.coordinates[2]; c++ {
+		hs.colWidths[c] = f.getColWidth(sheet, c)
+	}
+	if err = f.setHTMLHyperlinks(sheet, hs, hyperlinks); err != nil {
+		return nil, err
+	}
+	return hs, hs.setMergeCells(mergeCells)
+}
+
+// setMergeCells provides a function to set the column and row spans of the
+// merged cells for HTML export, the hidden rows and columns in the merged
+// range will not be spanned, and the merged cell will be written on the first
+// visible cell of the range.
+func (hs *htmlSheet) setMergeCells(mergeCells []string) error {
+	for _, ref := range mergeCells {
+		rect, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(rect)
+		if !isOverlap(rect, hs.coordinates) {
+			continue
+		}
+		// Clip the merged range to the exported range
+		rect = []int{
+			int(math.Max(float64(rect[0]), float64(hs.coordinates[0]))),
+			int(math.Max(float64(rect[1]), float64(hs.coordinates[1]))),
+			int(math.Min(float64(rect[2]), float64(hs.coordinates[2]))),
+			int(math.Min(float64(rect[3]), float64(hs.coordinates[3]))),
+		}
+		// Emit the merged cell from the first visible cell of the range
+		originCol, originRow := rect[0], rect[1]
+		anchorCol, anchorRow, colSpan, rowSpan := 0, 0, 0, 0
+		for c := rect[0]; c <= rect[2]; c++ {
+			if !hs.hiddenCols[c] {
+				if colSpan == 0 {
+					anchorCol = c
+				}
+				colSpan++
+			}
+		}
+		for r := rect[1]; r <= rect[3]; r++ {
+			if !hs.hiddenRows[r] {
+				if rowSpan == 0 {
+					anchorRow = r
+				}
+				rowSpan++
+			}
+		}
+		if colSpan == 0 || rowSpan == 0 {
+			continue
+		}
+		for r := rect[1]; r <= rect[3]; r++ {
+			for c := rect[0]; c <= rect[2]; c++ {
+				if r == anchorRow && c == anchorCol {
+					continue
+				}
+				if hs.covered[r] == nil {
+					hs.covered[r] = make(map[int]bool)
+				}
+				hs.covered[r][c] = true
+			}
+		}
+		cell, ok := hs.cells[originRow][originCol]
+		if !ok {
+			cell = &htmlCell{}
+		}
+		delete(hs.cells[originRow], originCol)
+		if hs.cells[anchorRow] == nil {
+			hs.cells[anchorRow] = make(map[int]*htmlCell)
+		}
+		hs.cells[anchorRow][anchorCol] = cell
+		cell.colSpan, cell.rowSpan = colSpan, rowSpan
+	}
+	return nil
+}
+
+// setHTMLHyperlinks provides a function to set the target of the hyperlinks
+// for HTML export, the internal location will be rendered as a fragment
+// identifier.
+func (f *File) setHTMLHyperlinks(sheet string, hs *htmlSheet, hyperlinks map[string]xlsxHyperlink) error {
+	for ref, link := range hyperlinks {
+		rect, err := rangeRefToCoordinates(ref + ":" + ref)
+		if strings.Contains(ref, ":") {
+			rect, err = rangeRefToCoordinates(ref)
+		}
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(rect)
+		target := "#" + link.Location
+		if link.RID != "" {
+			if target = f.getSheetRelationshipsTargetByID(sheet, link.RID); !isSafeHTMLLink(target) {
+				continue
+			}
+		}
+		for r := rect[1]; r <= rect[3]; r++ {
+			for c := rect[0]; c <= rect[2]; c++ {
+				if cell, ok := hs.cells[r][c]; ok {
+					cell.link = target
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isSafeHTMLLink returns if the external hyperlink target uses the http,
+// https or mailto scheme, the other targets such as javascript: and data: URLs
+// will not be rendered as links.
+func isSafeHTMLLink(target string) bool {
+	u, err := url.Parse(strings.TrimSpace(target))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+// writeHTMLStyles provides a function to write the CSS style sheet of the
+// used cell styles for HTML export.
+func (f *File) writeHTMLStyles(w *bufio.Writer, hs *htmlSheet) error {
+	styleSheet, err := f.loadStyles()
+	if err != nil {
+		return err
+	}
+	styleIDs := map[int]bool{}
+	for _, row := range hs.cells {
+		for _, cell := range row {
+			styleIDs[cell.styleID] = true
+		}
+	}
+	styleIDs[0] = true
+	for styleID := range styleIDs {
+		hs.styles = append(hs.styles, styleID)
+	}
+	sort.Ints(hs.styles)
+	_, _ = w.WriteString("<style>\n")
+	_, _ = w.WriteString("table.excelize{border-collapse:collapse;table-layout:fixed}\n")
+	_, _ = w.WriteString("table.excelize td{padding:0 3px;overflow:hidden;white-space:pre;vertical-align:bottom}\n")
+	_, _ = w.WriteString("table.excelize td.n{text-align:right}\n")
+	styleSheet.mu.Lock()
+	defer styleSheet.mu.Unlock()
+	for _, styleID := range hs.styles {
+		if declarations := f.getStyleCSS(styleSheet, styleID); len(declarations) > 0 {
+			_, _ = fmt.Fprintf(w, "table.excelize td.s%d{%s}\n", styleID, strings.Join(declarations, ";"))
+		}
+	}
+	_, _ = w.WriteString("</style>\n")
+	return err
+}
+
+// getStyleCSS provides a function to get the CSS declarations by given style
+// sheet and cell style ID.
+func (f *File) getStyleCSS(styleSheet *xlsxStyleSheet, styleID int) []string {
+	var declarations []string
+	if styleSheet.CellXfs == nil || styleID < 0 || styleID >= len(styleSheet.CellXfs.Xf) {
+		return declarations
+	}
+	xf := styleSheet.CellXfs.Xf[styleID]
+	if xf.FontID != nil && styleSheet.Fonts != nil && *xf.FontID < len(styleSheet.Fonts.Font) {
+		declarations = append(declarations, f.getFontCSS(styleSheet.Fonts.Font[*xf.FontID])...)
+	}
+	if xf.FillID != nil && styleSheet.Fills != nil && *xf.FillID < len(styleSheet.Fills.Fill) {
+		declarations = append(declarations, f.getFillCSS(styleSheet.Fills.Fill[*xf.FillID])...)
+	}
+	if xf.BorderID != nil && styleSheet.Borders != nil && *xf.BorderID < len(styleSheet.Borders.Border) {
+		declarations = append(declarations, f.getBorderCSS(styleSheet.Borders.Border[*xf.BorderID])...)
+	}
+	return append(declarations, getAlignmentCSS(xf.Alignment)...)
+}
+
+// getCSSColor provides a function to get the CSS hex color by given color
+// settings.
+func (f *File) getCSSColor(clr *xlsxColor) string {
+	if rgb := f.getColorRGB(clr); len(rgb) == 8 {
+		if _, err := strconv.ParseUint(rgb[2:], 16, 32); err == nil {
+			return "#" + rgb[2:]
+		}
+	}
+	return ""
+}
+
+// getCSSFontFamily provides a function to get the CSS font family by given
+// font name, only the letters, digits, spaces, hyphens, underscores and dots
+// will be kept to avoid breaking out of the CSS style sheet.
+func getCSSFontFamily(name string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(" -_.", r) {
+			return r
+		}
+		return -1
+	}, name))
+}
+
+// getFontCSS provides a function to get the CSS declarations by given font.
+func (f *File) getFontCSS(font *xlsxFont) []string {
+	var declarations, decorations []string
+	if font == nil {
+		return declarations
+	}
+	if font.Name != nil && font.Name.Val != nil {
+		if family := getCSSFontFamily(*font.Name.Val); family != "" {
+			declarations = append(declarations, fmt.Sprintf("font-family:'%s'", family))
+		}
+	}
+	if font.Sz != nil && font.Sz.Val != nil {
+		declarations = append(declarations, fmt.Sprintf("font-size:%spt", strconv.FormatFloat(*font.Sz.Val, 'f', -1, 64)))
+	}
+	if font.B != nil && (font.B.Val == nil || *font.B.Val) {
+		declarations = append(declarations, "font-weight:bold")
+	}
+	if font.I != nil && (font.I.Val == nil || *font.I.Val) {
+		declarations = append(declarations, "font-style:italic")
+	}
+	if font.U != nil && (font.U.Val == nil || *font.U.Val != "none") {
+		decorations = append(decorations, "underline")
+	}
+	if font.Strike != nil && (font.Strike.Val == nil || *font.Strike.Val) {
+		decorations = append(decorations, "line-through")
+	}
+	if len(decorations) > 0 {
+		declarations = append(declarations, "text-decoration:"+strings.Join(decorations, " "))
+	}
+	if color := f.getCSSColor(font.Color); color != "" {
+		declarations = append(declarations, "color:"+color)
+	}
+	return declarations
+}
+
+// getFillCSS provides a function to get the CSS declarations by given fill.
+func (f *File) getFillCSS(fill *xlsxFill) []string {
+	var declarations []string
+	if fill == nil {
+		return declarations
+	}
+	if fill.PatternFill != nil && fill.PatternFill.PatternType != "" && fill.PatternFill.PatternType != "none" {
+		color := f.getCSSColor(fill.PatternFill.FgColor)
+		if color == "" {
+			color = f.getCSSColor(fill.PatternFill.BgColor)
+		}
+		if color != "" {
+			declarations = append(declarations, "background-color:"+color)
+		}
+	}
+	if fill.GradientFill != nil && len(fill.GradientFill.Stop) > 1 {
+		var stops []string
+		for _, stop := range fill.GradientFill.Stop {
+			color := f.getCSSColor(&stop.Color)
+			if color == "" {
+				color = "#FFFFFF"
+			}
+			stops = append(stops, fmt.Sprintf("%s %s%%", color, strconv.FormatFloat(stop.Position*100, 'f', -1, 64)))
+		}
+		declarations = append(declarations, fmt.Sprintf("background:linear-gradient(%sdeg,%s)",
+			strconv.FormatFloat(fill.GradientFill.Degree+90, 'f', -1, 64), strings.Join(stops, ",")))
+	}
+	return declarations
+}
+
+// getBorderCSS provides a function to get the CSS declarations by given
+// border.
+func (f *File) getBorderCSS(border *xlsxBorder) []string {
+	var declarations []string
+	if border == nil {
+		return declarations
+	}
+	for _, side := range []struct {
+		name string
+		line xlsxLine
+	}{
+		{"left", border.Left}, {"right", border.Right},
+		{"top", border.Top}, {"bottom", border.Bottom},
+	} {
+		style, ok := htmlBorderStyles[side.line.Style]
+		if !ok {
+			continue
+		}
+		color := f.getCSSColor(side.line.Color)
+		if color == "" {
+			color = "#000000"
+		}
+		declarations = append(declarations, fmt.Sprintf("border-%s:%s %s", side.name, style, color))
+	}
+	return declarations
+}
+
+// getAlignmentCSS provides a function to get the CSS declarations by given
+// alignment.
+func getAlignmentCSS(alignment *xlsxAlignment) []string {
+	var declarations []string
+	if alignment == nil {
+		return declarations
+	}
+	switch alignment.Horizontal {
+	case "left", "right", "center", "justify":
+		declarations = append(declarations, "text-align:"+alignment.Horizontal)
+	case "centerContinuous", "distributed":
+		declarations = append(declarations, "text-align:center")
+	}
+	switch alignment.Vertical {
+	case "top", "bottom":
+		declarations = append(declarations, "vertical-align:"+alignment.Vertical)
+	case "center", "justify", "distributed":
+		declarations = append(declarations, "vertical-align:middle")
+	}
+	if alignment.Indent > 0 {
+		declarations = append(declarations, fmt.Sprintf("padding-left:%dpx", alignment.Indent*9+3))
+	}
+	if alignment.WrapText {
+		declarations = append(declarations, "white-space:pre-wrap")
+	}
+	return declarations
+}
+
+// writeTable provides a function to write the table element of the
+// worksheet snapshot for HTML export.
+func (hs *htmlSheet) writeTable(w *bufio.Writer) {
+	_, _ = w.WriteString("<table class=\"excelize\">\n<colgroup>")
+	for c := hs.coordinates[0]; c <= hs.coordinates[2]; c++ {
+		if !hs.hiddenCols[c] {
+			_, _ = fmt.Fprintf(w, "<col style=\"width:%dpx\">", hs.colWidths[c])
+		}
+	}
+	_, _ = w.WriteString("</colgroup>\n")
+	for r := hs.coordinates[1]; r <= hs.coordinates[3]; r++ {
+		if hs.hiddenRows[r] {
+			continue
+		}
+		height, ok := hs.rowHeights[r]
+		if !ok {
+			height = hs.defaultHeight
+		}
+		_, _ = fmt.Fprintf(w, "<tr style=\"height:%dpx\">", height)
+		for c := hs.coordinates[0]; c <= hs.coordinates[2]; c++ {
+			if hs.hiddenCols[c] || hs.covered[r][c] {
+				continue
+			}
+			cell, ok := hs.cells[r][c]
+			if !ok {
+				_, _ = w.WriteString("<td></td>")
+				continue
+			}
+			cell.writeHTML(w)
+		}
+		_, _ = w.WriteString("</tr>\n")
+	}
+	_, _ = w.WriteString("</table>\n")
+}
+
+// writeHTML provides a function to write the td element of the cell for
+// HTML export.
+func (cell *htmlCell) writeHTML(w *bufio.Writer) {
+	var classes []string
+	if cell.numeric {
+		classes = append(classes, "n")
+	}
+	classes = append(classes, "s"+strconv.Itoa(cell.styleID))
+	_, _ = fmt.Fprintf(w, "<td class=\"%s\"", strings.Join(classes, " "))
+	if cell.colSpan > 1 {
+		_, _ = fmt.Fprintf(w, " colspan=\"%d\"", cell.colSpan)
+	}
+	if cell.rowSpan > 1 {
+		_, _ = fmt.Fprintf(w, " rowspan=\"%d\"", cell.rowSpan)
+	}
+	_, _ = w.WriteString(">")
+	value := strings.ReplaceAll(html.EscapeString(cell.value), "\n", "<br>")
+	if cell.link != "" {
+		_, _ = fmt.Fprintf(w, "<a href=\"%s\">%s</a>", html.EscapeString(cell.link), value)
+	} else {
+		_, _ = w.WriteString(value)
+	}
+	_, _ = w.WriteString("</td>")
+}
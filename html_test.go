@@ -0,0 +1,144 @@
+package excelize
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteHTML(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Price", "Note", "Hidden"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"<Apple>", 0.5, "a\nb", "x"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Total", 1}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]interface{}{"Hidden row"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A5", &[]interface{}{"Merged"}))
+	assert.NoError(t, f.MergeCell("Sheet1", "A5", "D6"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A3", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "javascript:alert(1)", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C2", " DATA:text/html,<script>", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B3", "mailto:support@example.com", "External"))
+	assert.NoError(t, f.SetColVisible("Sheet1", "D", false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 4, false))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 1, 30))
+	theme := 4
+	headerStyle, err := f.NewStyle(&Style{
+		Font:      &Font{Bold: true, Italic: true, Underline: "single", Strike: true, Family: "Arial", Size: 12, ColorTheme: &theme},
+		Fill:      Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		Border:    []Border{{Type: "bottom", Style: 2, Color: "FF0000"}, {Type: "left", Style: 1}},
+		Alignment: &Alignment{Horizontal: "center", Vertical: "center", WrapText: true, Indent: 1},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "C1", headerStyle))
+	numStyle, err := f.NewStyle(&Style{NumFmt: 10, Fill: Fill{Type: "gradient", Shading: 1, Color: []string{"FFFFFF", "E0EBF5"}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", numStyle))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.WriteHTML("Sheet1", &buf, nil))
+	output := buf.String()
+	for _, expected := range []string{
+		"<title>Sheet1</title>",
+		"<col style=\"width:146px\"><col style=\"width:64px\"><col style=\"width:64px\"></colgroup>",
+		"<tr style=\"height:36px\"><td class=\"s1\"><a href=\"https://github.com/xuri/excelize\">Name</a></td>",
+		"<td class=\"n s2\">50.00%</td><td class=\"s0\">a<br>b</td></tr>",
+		"<a href=\"#Sheet1!A1\">Total</a>",
+		"<td class=\"s0\" colspan=\"3\" rowspan=\"2\">Merged</td></tr>\n<tr style=\"height:18px\"></tr>",
+		"<td class=\"s0\">&lt;Apple&gt;</td>",
+		"<a href=\"mailto:support@example.com\">1</a>",
+		"font-family:'Arial';font-size:12pt;font-weight:bold;font-style:italic;text-decoration:underline line-through;color:#5B9BD5",
+		"background-color:#FFFF00",
+		"border-left:1px solid #000000;border-bottom:2px solid #FF0000",
+		"text-align:center;vertical-align:middle;padding-left:12px;white-space:pre-wrap",
+		"background:linear-gradient(360deg,#FFFFFF 0%,#E0EBF5 100%)",
+	} {
+		assert.Contains(t, output, expected)
+	}
+	for _, unexpected := range []string{"Hidden", "x</td>", "javascript:", "data:"} {
+		assert.NotContains(t, output, unexpected)
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join("test", "TestWriteHTML.html"), buf.Bytes(), 0o600))
+
+	// Test write HTML fragment with the given range and raw cell value
+	buf.Reset()
+	assert.NoError(t, f.WriteHTML("Sheet1", &buf, &HTMLOptions{Range: "B3:A2", Fragment: true, RawCellValue: true}))
+	output = buf.String()
+	assert.True(t, strings.HasPrefix(output, "<style>"))
+	assert.Contains(t, output, "<td class=\"n s2\">0.5</td>")
+	assert.NotContains(t, output, "<html>")
+	assert.NotContains(t, output, "Name")
+
+	// Test write HTML with the merged cell overlapped with the given range
+	buf.Reset()
+	assert.NoError(t, f.WriteHTML("Sheet1", &buf, &HTMLOptions{Range: "B5:C6"}))
+	assert.Contains(t, buf.String(), "<td class=\"s0\" colspan=\"2\" rowspan=\"2\"></td>")
+
+	// Test write HTML with the top-left cell of the merged cell hidden
+	f2 := NewFile()
+	assert.NoError(t, f2.SetCellValue("Sheet1", "B2", "Merged"))
+	assert.NoError(t, f2.MergeCell("Sheet1", "B2", "D4"))
+	assert.NoError(t, f2.SetRowVisible("Sheet1", 2, false))
+	assert.NoError(t, f2.SetColVisible("Sheet1", "B", false))
+	buf.Reset()
+	assert.NoError(t, f2.WriteHTML("Sheet1", &buf, &HTMLOptions{Range: "A1:D4", Fragment: true}))
+	assert.Contains(t, buf.String(), "<tr style=\"height:18px\"><td></td><td class=\"s0\" colspan=\"2\" rowspan=\"2\">Merged</td></tr>\n"+
+		"<tr style=\"height:18px\"><td></td></tr>\n")
+
+	// Test write HTML keep the worksheet unmodified
+	file, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f3, err := OpenReader(file)
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, f3.WriteHTML("Sheet1", &buf, nil))
+	assert.False(t, f3.isPartModified("xl/worksheets/sheet1.xml"))
+	assert.NoError(t, f3.Close())
+
+	// Test write HTML for the empty worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, f.WriteHTML("Sheet2", &buf, &HTMLOptions{Title: "<Empty>"}))
+	assert.Contains(t, buf.String(), "<title>&lt;Empty&gt;</title>")
+	assert.Contains(t, buf.String(), "<tr style=\"height:20px\"><td></td></tr>")
+
+	// Test write HTML with the gradient fill stop without color
+	assert.Equal(t, []string{"background:linear-gradient(90deg,#FFFFFF 0%,#FF0000 100%)"}, f.getFillCSS(&xlsxFill{
+		GradientFill: &xlsxGradientFill{Stop: []*xlsxGradientFillStop{{}, {Position: 1, Color: xlsxColor{RGB: "FF0000"}}}},
+	}))
+
+	// Test write HTML with the font name and color breaking out of the style sheet
+	assert.Equal(t, []string{"font-family:'xstylescriptalert1script'"}, f.getFontCSS(&xlsxFont{
+		Name:  &attrValString{Val: stringPtr("x</style><script>alert(1)</script>")},
+		Color: &xlsxColor{RGB: "FF}</st"},
+	}))
+	assert.Equal(t, []string{"font-family:'宋体'"}, f.getFontCSS(&xlsxFont{Name: &attrValString{Val: stringPtr("宋体")}}))
+	assert.Empty(t, f.getFontCSS(&xlsxFont{Name: &attrValString{Val: stringPtr("'\\;{}<>")}}))
+
+	// Test write HTML with invalid range
+	assert.EqualError(t, f.WriteHTML("Sheet1", &buf, &HTMLOptions{Range: "A1"}), ErrParameterInvalid.Error())
+	// Test write HTML on not exists worksheet
+	assert.EqualError(t, f.WriteHTML("SheetN", &buf, nil), "sheet SheetN does not exist")
+	// Test write HTML with invalid merged cell and hyperlink reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ref := ws.(*xlsxWorksheet).Hyperlinks.Hyperlink[0].Ref
+	ws.(*xlsxWorksheet).Hyperlinks.Hyperlink[0].Ref = "A"
+	assert.EqualError(t, f.WriteHTML("Sheet1", &buf, nil), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	ws.(*xlsxWorksheet).Hyperlinks.Hyperlink[0].Ref = ref
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "A"
+	assert.EqualError(t, f.WriteHTML("Sheet1", &buf, nil), ErrParameterInvalid.Error())
+	// Test write HTML with invalid cell reference
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].R = "A"
+	assert.EqualError(t, f.WriteHTML("Sheet1", &buf, nil), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test write HTML with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.WriteHTML("Sheet2", &buf, nil), "XML syntax error on line 1: invalid UTF-8")
+}
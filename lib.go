@@ -47,6 +47,9 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 		if partName, ok := docPart[strings.ToLower(fileName)]; ok {
 			fileName = partName
 		}
+		if f.zipFiles != nil {
+			f.zipFiles[fileName] = v
+		}
 		if strings.EqualFold(fileName, defaultXMLPathSharedStrings) && fileSize > f.options.UnzipXMLSizeLimit {
 			if tempFile, err := f.unzipToTemp(v); err == nil {
 				f.tempFiles.Store(fileName, tempFile)
@@ -132,6 +135,28 @@ func (f *File) saveFileList(name string, content []byte) {
 	f.Pkg.Store(name, append([]byte(xml.Header), content...))
 }
 
+// setPartModified provides a function to mark the loaded part as modified by
+// given part path, the modified part will be serialized on saving the
+// spreadsheet.
+func (f *File) setPartModified(path string) {
+	f.modifiedParts.Store(path, true)
+}
+
+// isPartModified provides a function to check if the loaded part should be
+// serialized on saving the spreadsheet by given part path. It returns false
+// if the part was never modified after reading from the package, so that the
+// original content of the part can be kept byte for byte.
+func (f *File) isPartModified(path string) bool {
+	if _, ok := f.modifiedParts.Load(path); ok {
+		return true
+	}
+	if _, ok := f.Pkg.Load(path); ok {
+		return false
+	}
+	_, ok := f.tempFiles.Load(path)
+	return !ok
+}
+
 // Read file content as string in an archive file.
 func readFile(file *zip.File) ([]byte, error) {
 	rc, err := file.Open()
@@ -390,6 +415,15 @@ func inCoordinates(a [][]int, x []int) int {
 	return -1
 }
 
+// quoteSheetName provides a function to quote the worksheet name in the
+// formula reference if it contains special characters or starts with digits.
+func quoteSheetName(name string) string {
+	if strings.ContainsAny(name, " '!-+()&,;:.") || (name != "" && '0' <= name[0] && name[0] <= '9') {
+		return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+	}
+	return name
+}
+
 // inStrSlice provides a method to check if an element is present in an array,
 // and return the index of its location, otherwise return -1.
 func inStrSlice(a []string, x string, caseSensitive bool) int {
@@ -514,6 +548,26 @@ func namespaceStrictToTransitional(content []byte) []byte {
 	return content
 }
 
+// namespaceTransitionalToStrict provides a method to convert Transitional
+// namespaces and relationship types to Strict. The namespaces which have a
+// different local name in Strict will be converted before the common
+// prefixes.
+func namespaceTransitionalToStrict(content []byte) []byte {
+	content = append([]byte(nil), content...)
+	for _, pair := range [][2]string{
+		{NameSpaceExtendedProperties, StrictNameSpaceExtendedProperties},
+		{SourceRelationshipExtendProperties, StrictSourceRelationshipExtendProperties},
+		{"http://schemas.openxmlformats.org/officeDocument/2006/custom-properties", "http://purl.oclc.org/ooxml/officeDocument/customProperties"},
+		{"http://schemas.openxmlformats.org/officeDocument/2006/relationships/custom-properties", "http://purl.oclc.org/ooxml/officeDocument/relationships/customProperties"},
+		{"http://schemas.openxmlformats.org/officeDocument/2006/", "http://purl.oclc.org/ooxml/officeDocument/"},
+		{"http://schemas.openxmlformats.org/drawingml/2006/", "http://purl.oclc.org/ooxml/drawingml/"},
+		{NameSpaceSpreadSheet.Value, StrictNameSpaceSpreadSheet},
+	} {
+		content = bytesReplace(content, []byte(pair[0]), []byte(pair[1]), -1)
+	}
+	return content
+}
+
 // bytesReplace replace source bytes with given target.
 func bytesReplace(s, source, target []byte, n int) []byte {
 	if n == 0 {
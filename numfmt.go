@@ -29,6 +29,17 @@ type languageInfo struct {
 	localMonth func(t time.Time, abbr int) string
 }
 
+// cultureInfo defined the locale settings of the culture for applying number
+// format, including the language ID for the month and weekday names, the
+// date and time patterns, the decimal and thousands separators and the
+// currency number format pattern without decimal places.
+type cultureInfo struct {
+	localCode, langNumFmt                              string
+	shortDatePattern, longDatePattern, longTimePattern string
+	decimalSeparator, thousandsSeparator               string
+	currencyPattern                                    string
+}
+
 // numberFormat directly maps the number format parser runtime required
 // fields.
 type numberFormat struct {
@@ -56,6 +67,21 @@ const (
 	CultureNameUnknown CultureName = iota
 	CultureNameEnUS
 	CultureNameZhCN
+	CultureNameDeDE
+	CultureNameEnGB
+	CultureNameEsES
+	CultureNameFrFR
+	CultureNameItIT
+	CultureNameJaJP
+	CultureNameKoKR
+	CultureNameNlNL
+	CultureNamePlPL
+	CultureNamePtBR
+	CultureNameRuRU
+	CultureNameSvSE
+	CultureNameThTH
+	CultureNameTrTR
+	CultureNameZhTW
 )
 
 var (
@@ -787,16 +813,25 @@ var (
 		"409":  {tags: []string{"en-US"}, localMonth: localMonthsNameEnglish, apFmt: nfp.AmPm[0]},
 		"3009": {tags: []string{"en-ZW"}, localMonth: localMonthsNameEnglish, apFmt: nfp.AmPm[0]},
 		"C":    {tags: []string{"fr"}, localMonth: localMonthsNameFrench, apFmt: nfp.AmPm[0]},
+		"40C":  {tags: []string{"fr-FR"}, localMonth: localMonthsNameFrench, apFmt: nfp.AmPm[0]},
 		"7":    {tags: []string{"de"}, localMonth: localMonthsNameGerman, apFmt: nfp.AmPm[0]},
 		"C07":  {tags: []string{"de-AT"}, localMonth: localMonthsNameAustria, apFmt: nfp.AmPm[0]},
 		"407":  {tags: []string{"de-DE"}, localMonth: localMonthsNameGerman, apFmt: nfp.AmPm[0]},
 		"3C":   {tags: []string{"ga"}, localMonth: localMonthsNameIrish, apFmt: apFmtIrish},
 		"83C":  {tags: []string{"ga-IE"}, localMonth: localMonthsNameIrish, apFmt: apFmtIrish},
 		"10":   {tags: []string{"it"}, localMonth: localMonthsNameItalian, apFmt: nfp.AmPm[0]},
+		"410":  {tags: []string{"it-IT"}, localMonth: localMonthsNameItalian, apFmt: nfp.AmPm[0]},
 		"11":   {tags: []string{"ja"}, localMonth: localMonthsNameChinese3, apFmt: apFmtJapanese},
 		"411":  {tags: []string{"ja-JP"}, localMonth: localMonthsNameChinese3, apFmt: apFmtJapanese},
 		"12":   {tags: []string{"ko"}, localMonth: localMonthsNameKorean, apFmt: apFmtKorean},
 		"412":  {tags: []string{"ko-KR"}, localMonth: localMonthsNameKorean, apFmt: apFmtKorean},
+		"13":   {tags: []string{"nl"}, localMonth: localMonthsNameDutch, apFmt: nfp.AmPm[0]},
+		"413":  {tags: []string{"nl-NL"}, localMonth: localMonthsNameDutch, apFmt: nfp.AmPm[0]},
+		"15":   {tags: []string{"pl"}, localMonth: localMonthsNamePolish, apFmt: nfp.AmPm[0]},
+		"415":  {tags: []string{"pl-PL"}, localMonth: localMonthsNamePolish, apFmt: nfp.AmPm[0]},
+		"16":   {tags: []string{"pt"}, localMonth: localMonthsNamePortuguese, apFmt: nfp.AmPm[0]},
+		"416":  {tags: []string{"pt-BR"}, localMonth: localMonthsNamePortuguese, apFmt: nfp.AmPm[0]},
+		"816":  {tags: []string{"pt-PT"}, localMonth: localMonthsNamePortuguese, apFmt: nfp.AmPm[0]},
 		"7C50": {tags: []string{"mn-Mong"}, localMonth: localMonthsNameTraditionalMongolian, apFmt: nfp.AmPm[0]},
 		"850":  {tags: []string{"mn-Mong-CN"}, localMonth: localMonthsNameTraditionalMongolian, apFmt: nfp.AmPm[0]},
 		"C50":  {tags: []string{"mn-Mong-MN"}, localMonth: localMonthsNameTraditionalMongolian, apFmt: nfp.AmPm[0]},
@@ -804,6 +839,7 @@ var (
 		"819":  {tags: []string{"ru-MD"}, localMonth: localMonthsNameRussian, apFmt: nfp.AmPm[0]},
 		"419":  {tags: []string{"ru-RU"}, localMonth: localMonthsNameRussian, apFmt: nfp.AmPm[0]},
 		"A":    {tags: []string{"es"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish},
+		"C0A":  {tags: []string{"es-ES"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish},
 		"2C0A": {tags: []string{"es-AR"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish},
 		"200A": {tags: []string{"es-VE"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish},
 		"400A": {tags: []string{"es-BO"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish},
@@ -814,6 +850,8 @@ var (
 		"1C0A": {tags: []string{"es-DO"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish},
 		"300A": {tags: []string{"es-EC"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish},
 		"440A": {tags: []string{"es-SV"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish},
+		"1D":   {tags: []string{"sv"}, localMonth: localMonthsNameSwedish, apFmt: nfp.AmPm[0]},
+		"41D":  {tags: []string{"sv-SE"}, localMonth: localMonthsNameSwedish, apFmt: nfp.AmPm[0]},
 		"1E":   {tags: []string{"th"}, localMonth: localMonthsNameThai, apFmt: nfp.AmPm[0]},
 		"41E":  {tags: []string{"th-TH"}, localMonth: localMonthsNameThai, apFmt: nfp.AmPm[0]},
 		"51":   {tags: []string{"bo"}, localMonth: localMonthsNameTibetan, apFmt: apFmtTibetan},
@@ -862,6 +900,10 @@ var (
 	monthNamesKoreanAbbrPlus = []string{"0월", "1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월"}
 	// monthNamesTradMongolian lists the month number for use with traditional Mongolian
 	monthNamesTradMongolian = []string{"M01", "M02", "M03", "M04", "M05", "M06", "M07", "M08", "M09", "M10", "M11", "M12"}
+	// monthNamesDutch list the month names in the Dutch.
+	monthNamesDutch = []string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"}
+	// monthNamesDutchAbbr lists the month name abbreviations in Dutch
+	monthNamesDutchAbbr = []string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"}
 	// monthNamesFrench list the month names in the French.
 	monthNamesFrench = []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"}
 	// monthNamesFrenchAbbr lists the month name abbreviations in French
@@ -882,6 +924,14 @@ var (
 	monthNamesItalian = []string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"}
 	// monthNamesItalianAbbr list the month name abbreviations in Italian
 	monthNamesItalianAbbr = []string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"}
+	// monthNamesPolish list the month names in the Polish.
+	monthNamesPolish = []string{"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec", "lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"}
+	// monthNamesPolishAbbr lists the month name abbreviations in Polish
+	monthNamesPolishAbbr = []string{"sty", "lut", "mar", "kwi", "maj", "cze", "lip", "sie", "wrz", "paź", "lis", "gru"}
+	// monthNamesPortuguese list the month names in the Portuguese.
+	monthNamesPortuguese = []string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"}
+	// monthNamesPortugueseAbbr lists the month name abbreviations in Portuguese
+	monthNamesPortugueseAbbr = []string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"}
 	// monthNamesRussian list the month names in the Russian.
 	monthNamesRussian = []string{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"}
 	// monthNamesRussianAbbr list the month abbreviations for Russian.
@@ -890,6 +940,10 @@ var (
 	monthNamesSpanish = []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"}
 	// monthNamesSpanishAbbr list the month abbreviations in Spanish
 	monthNamesSpanishAbbr = []string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"}
+	// monthNamesSwedish list the month names in the Swedish.
+	monthNamesSwedish = []string{"januari", "februari", "mars", "april", "maj", "juni", "juli", "augusti", "september", "oktober", "november", "december"}
+	// monthNamesSwedishAbbr lists the month name abbreviations in Swedish
+	monthNamesSwedishAbbr = []string{"jan", "feb", "mar", "apr", "maj", "jun", "jul", "aug", "sep", "okt", "nov", "dec"}
 	// monthNamesThai list the month names in the Thai.
 	monthNamesThai = []string{
 		"\u0e21\u0e01\u0e23\u0e32\u0e04\u0e21",
@@ -989,6 +1043,67 @@ var (
 	apFmtYi = "\ua3b8\ua111/\ua06f\ua2d2"
 	// apFmtWelsh defined the AM/PM name in the Welsh.
 	apFmtWelsh = "yb/yh"
+	// weekdayNames defined the weekday names from Sunday to Saturday by the
+	// language tag or the primary language subtag.
+	weekdayNames = map[string][]string{
+		"de":      {"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		"es":      {"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		"fr":      {"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		"it":      {"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		"ja":      {"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		"ko":      {"일요일", "월요일", "화요일", "수요일", "목요일", "금요일", "토요일"},
+		"nl":      {"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		"pl":      {"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+		"pt":      {"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		"ru":      {"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		"sv":      {"söndag", "måndag", "tisdag", "onsdag", "torsdag", "fredag", "lördag"},
+		"th":      {"อาทิตย์", "จันทร์", "อังคาร", "พุธ", "พฤหัสบดี", "ศุกร์", "เสาร์"},
+		"tr":      {"Pazar", "Pazartesi", "Salı", "Çarşamba", "Perşembe", "Cuma", "Cumartesi"},
+		"zh":      {"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		"zh-Hant": {"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		"zh-TW":   {"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+	}
+	// weekdayNamesAbbr defined the weekday name abbreviations from Sunday to
+	// Saturday by the language tag or the primary language subtag.
+	weekdayNamesAbbr = map[string][]string{
+		"de":      {"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		"es":      {"dom.", "lun.", "mar.", "mié.", "jue.", "vie.", "sáb."},
+		"fr":      {"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		"it":      {"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		"ja":      {"日", "月", "火", "水", "木", "金", "土"},
+		"ko":      {"일", "월", "화", "수", "목", "금", "토"},
+		"nl":      {"zo", "ma", "di", "wo", "do", "vr", "za"},
+		"pl":      {"niedz.", "pon.", "wt.", "śr.", "czw.", "pt.", "sob."},
+		"pt":      {"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+		"ru":      {"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"},
+		"sv":      {"sön", "mån", "tis", "ons", "tor", "fre", "lör"},
+		"th":      {"อา.", "จ.", "อ.", "พ.", "พฤ.", "ศ.", "ส."},
+		"tr":      {"Paz", "Pzt", "Sal", "Çar", "Per", "Cum", "Cmt"},
+		"zh":      {"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		"zh-Hant": {"週日", "週一", "週二", "週三", "週四", "週五", "週六"},
+		"zh-TW":   {"週日", "週一", "週二", "週三", "週四", "週五", "週六"},
+	}
+	// supportedCultureInfo directly maps the supported culture names and the
+	// locale settings for applying number format.
+	supportedCultureInfo = map[CultureName]cultureInfo{
+		CultureNameEnUS: {localCode: "409", shortDatePattern: "M/d/yy", longDatePattern: "dddd, mmmm d, yyyy", longTimePattern: "h:mm:ss", decimalSeparator: ".", thousandsSeparator: ",", currencyPattern: `"$"#,##0`},
+		CultureNameZhCN: {localCode: "804", langNumFmt: "zh-cn", shortDatePattern: "yyyy/m/d", longDatePattern: `yyyy"年"m"月"d"日"`, longTimePattern: "h:mm:ss", decimalSeparator: ".", thousandsSeparator: ",", currencyPattern: `"¥"#,##0`},
+		CultureNameDeDE: {localCode: "407", shortDatePattern: `dd\.mm\.yyyy`, longDatePattern: `dddd, d\. mmmm yyyy`, longTimePattern: "hh:mm:ss", decimalSeparator: ",", thousandsSeparator: ".", currencyPattern: `#,##0 "€"`},
+		CultureNameEnGB: {localCode: "809", shortDatePattern: "dd/mm/yyyy", longDatePattern: "dd mmmm yyyy", longTimePattern: "hh:mm:ss", decimalSeparator: ".", thousandsSeparator: ",", currencyPattern: `"£"#,##0`},
+		CultureNameEsES: {localCode: "C0A", shortDatePattern: "dd/mm/yyyy", longDatePattern: `dddd, d "de" mmmm "de" yyyy`, longTimePattern: "h:mm:ss", decimalSeparator: ",", thousandsSeparator: ".", currencyPattern: `#,##0 "€"`},
+		CultureNameFrFR: {localCode: "40C", shortDatePattern: "dd/mm/yyyy", longDatePattern: "dddd d mmmm yyyy", longTimePattern: "hh:mm:ss", decimalSeparator: ",", thousandsSeparator: "\u00a0", currencyPattern: `#,##0 "€"`},
+		CultureNameItIT: {localCode: "410", shortDatePattern: "dd/mm/yyyy", longDatePattern: "dddd d mmmm yyyy", longTimePattern: "hh:mm:ss", decimalSeparator: ",", thousandsSeparator: ".", currencyPattern: `#,##0 "€"`},
+		CultureNameJaJP: {localCode: "411", langNumFmt: "ja-jp", shortDatePattern: "yyyy/m/d", longDatePattern: `yyyy"年"m"月"d"日"`, longTimePattern: "h:mm:ss", decimalSeparator: ".", thousandsSeparator: ",", currencyPattern: `"¥"#,##0`},
+		CultureNameKoKR: {localCode: "412", langNumFmt: "ko-kr", shortDatePattern: "yyyy-mm-dd", longDatePattern: `yyyy"년" m"월" d"일" dddd`, longTimePattern: "h:mm:ss", decimalSeparator: ".", thousandsSeparator: ",", currencyPattern: `"₩"#,##0`},
+		CultureNameNlNL: {localCode: "413", shortDatePattern: "d-m-yyyy", longDatePattern: "dddd d mmmm yyyy", longTimePattern: "hh:mm:ss", decimalSeparator: ",", thousandsSeparator: ".", currencyPattern: `"€" #,##0`},
+		CultureNamePlPL: {localCode: "415", shortDatePattern: `dd\.mm\.yyyy`, longDatePattern: "dddd, d mmmm yyyy", longTimePattern: "hh:mm:ss", decimalSeparator: ",", thousandsSeparator: "\u00a0", currencyPattern: `#,##0 "zł"`},
+		CultureNamePtBR: {localCode: "416", shortDatePattern: "dd/mm/yyyy", longDatePattern: `dddd, d "de" mmmm "de" yyyy`, longTimePattern: "hh:mm:ss", decimalSeparator: ",", thousandsSeparator: ".", currencyPattern: `"R$" #,##0`},
+		CultureNameRuRU: {localCode: "419", shortDatePattern: `dd\.mm\.yyyy`, longDatePattern: `d mmmm yyyy "г."`, longTimePattern: "h:mm:ss", decimalSeparator: ",", thousandsSeparator: "\u00a0", currencyPattern: `#,##0 "₽"`},
+		CultureNameSvSE: {localCode: "41D", shortDatePattern: "yyyy-mm-dd", longDatePattern: `"den "d mmmm yyyy`, longTimePattern: "hh:mm:ss", decimalSeparator: ",", thousandsSeparator: "\u00a0", currencyPattern: `#,##0 "kr"`},
+		CultureNameThTH: {localCode: "41E", langNumFmt: "th-th", shortDatePattern: "d/m/yyyy", longDatePattern: "d mmmm yyyy", longTimePattern: "h:mm:ss", decimalSeparator: ".", thousandsSeparator: ",", currencyPattern: `"฿"#,##0`},
+		CultureNameTrTR: {localCode: "41F", shortDatePattern: `d\.mm\.yyyy`, longDatePattern: "d mmmm yyyy dddd", longTimePattern: "hh:mm:ss", decimalSeparator: ",", thousandsSeparator: ".", currencyPattern: `"₺"#,##0`},
+		CultureNameZhTW: {localCode: "404", langNumFmt: "zh-tw", shortDatePattern: "yyyy/m/d", longDatePattern: `yyyy"年"m"月"d"日"`, longTimePattern: "hh:mm:ss", decimalSeparator: ".", thousandsSeparator: ",", currencyPattern: `"NT$"#,##0`},
+	}
 	// switchArgumentFunc defined the switch argument printer function
 	switchArgumentFunc = map[string]func(s string) string{
 		"[DBNum1]": func(s string) string {
@@ -1029,10 +1144,20 @@ func (f *File) applyBuiltInNumFmt(c *xlsxC, fmtCode string, numFmtID int, date19
 	return format(c.V, fmtCode, date1904, cellType, f.options)
 }
 
-// langNumFmtFuncEnUS returns number format code by given date and time pattern
-// for country code en-us.
-func (f *File) langNumFmtFuncEnUS(numFmtID int) string {
-	shortDatePattern, longTimePattern := "M/d/yy", "h:mm:ss"
+// langNumFmtFunc returns number format code by given culture settings and
+// the date and time pattern options for the language number format.
+func (f *File) langNumFmtFunc(culture cultureInfo, numFmtID int) string {
+	if culture.langNumFmt != "" {
+		fmtCode, ok := langNumFmt[culture.langNumFmt][numFmtID]
+		if ok && numFmtID == 30 && f.options.ShortDatePattern != "" {
+			return f.options.ShortDatePattern
+		}
+		if ok && (32 <= numFmtID && numFmtID <= 33) && f.options.LongTimePattern != "" {
+			return f.options.LongTimePattern
+		}
+		return fmtCode
+	}
+	shortDatePattern, longTimePattern := culture.shortDatePattern, culture.longTimePattern
 	if f.options.ShortDatePattern != "" {
 		shortDatePattern = f.options.ShortDatePattern
 	}
@@ -1063,33 +1188,126 @@ func (f *File) checkDateTimePattern() error {
 	return nil
 }
 
-// langNumFmtFuncZhCN returns number format code by given date and time pattern
-// for country code zh-cn.
-func (f *File) langNumFmtFuncZhCN(numFmtID int) string {
-	if numFmtID == 30 && f.options.ShortDatePattern != "" {
-		return f.options.ShortDatePattern
+// getNumFmtCode returns the currency and date number format code by given
+// built-in number format index for the culture, the empty string will be
+// returned if the number format doesn't depend on the culture.
+func (culture cultureInfo) getNumFmtCode(numFmtID int) string {
+	currencyPattern := culture.currencyPattern
+	if numFmtID == 7 || numFmtID == 8 {
+		currencyPattern = strings.Replace(currencyPattern, "#,##0", "#,##0.00", 1)
+	}
+	switch numFmtID {
+	case 5, 7:
+		return currencyPattern + ";-" + currencyPattern
+	case 6, 8:
+		return currencyPattern + ";[Red]-" + currencyPattern
+	case 14:
+		return culture.shortDatePattern
+	case 22:
+		return culture.shortDatePattern + " hh:mm"
 	}
-	if (32 <= numFmtID && numFmtID <= 33) && f.options.LongTimePattern != "" {
-		return f.options.LongTimePattern
+	return ""
+}
+
+// currencySymbol returns the quoted currency symbol in the currency pattern of
+// the culture.
+func (culture cultureInfo) currencySymbol() string {
+	parts := strings.Split(culture.currencyPattern, "\"")
+	if len(parts) < 3 {
+		return ""
 	}
-	return langNumFmt["zh-cn"][numFmtID]
+	return parts[1]
+}
+
+// dateOrder returns the order of the year, month and day in the short date
+// pattern of the culture, such as "mdy", "dmy" or "ymd".
+func (culture cultureInfo) dateOrder() string {
+	var order string
+	for _, r := range strings.ToLower(culture.shortDatePattern) {
+		if strings.ContainsRune("ymd", r) && !strings.ContainsRune(order, r) {
+			order += string(r)
+		}
+	}
+	return order
 }
 
 // getBuiltInNumFmtCode convert number format index to number format code with
 // specified locale and language.
 func (f *File) getBuiltInNumFmtCode(numFmtID int) (string, bool) {
+	culture, ok := supportedCultureInfo[f.options.CultureInfo]
+	if ok {
+		if fmtCode := culture.getNumFmtCode(numFmtID); fmtCode != "" {
+			return fmtCode, true
+		}
+	}
 	if fmtCode, ok := builtInNumFmt[numFmtID]; ok {
 		return fmtCode, true
 	}
-	if (27 <= numFmtID && numFmtID <= 36) || (50 <= numFmtID && numFmtID <= 81) {
-		if f.options.CultureInfo == CultureNameEnUS {
-			return f.langNumFmtFuncEnUS(numFmtID), true
+	if ok && ((27 <= numFmtID && numFmtID <= 36) || (50 <= numFmtID && numFmtID <= 81)) {
+		return f.langNumFmtFunc(culture, numFmtID), true
+	}
+	return "", false
+}
+
+// getXfNumFmtCode provides a function to get the number format code by given
+// style sheet and cell formatting record.
+func (f *File) getXfNumFmtCode(styleSheet *xlsxStyleSheet, xf xlsxXf) string {
+	if xf.NumFmtID == nil {
+		return ""
+	}
+	if code, ok := f.getBuiltInNumFmtCode(*xf.NumFmtID); ok {
+		return code
+	}
+	if styleSheet.NumFmts != nil {
+		for _, numFmt := range styleSheet.NumFmts.NumFmt {
+			if numFmt.NumFmtID == *xf.NumFmtID {
+				return numFmt.FormatCode
+			}
 		}
-		if f.options.CultureInfo == CultureNameZhCN {
-			return f.langNumFmtFuncZhCN(numFmtID), true
+	}
+	return ""
+}
+
+// isDateNumFmt provides a function to check if the given number format code
+// is a date format, which contains the year, month or day in the first
+// section. The "m" and "mm" will be treated as minutes after the hours or
+// before the seconds.
+func isDateNumFmt(code string) bool {
+	p := nfp.NumberFormatParser()
+	sections := p.Parse(code)
+	if len(sections) == 0 {
+		return false
+	}
+	var hours bool
+	items := sections[0].Items
+	for i, token := range items {
+		if token.TType != nfp.TokenTypeDateTimes {
+			continue
+		}
+		value := strings.ToUpper(token.TValue)
+		if inStrSlice(nfp.AmPm, value, false) != -1 {
+			continue
+		}
+		switch value[0] {
+		case 'Y', 'D', 'E', 'G', 'B':
+			return true
+		case 'H':
+			hours = true
+		case 'M':
+			var secondsNext bool
+			for _, next := range items[i+1:] {
+				if next.TType == nfp.TokenTypeDateTimes {
+					secondsNext = strings.HasPrefix(strings.ToUpper(next.TValue), "S")
+					break
+				}
+			}
+			if len(value) > 2 || (!hours && !secondsNext) {
+				return true
+			}
+			hours = false
 		}
 	}
-	return "", false
+	return false
 }
 
 // prepareNumberic split the number into two before and after parts by a
@@ -1109,6 +1327,9 @@ func (nf *numberFormat) prepareNumberic(value string) {
 func format(value, numFmt string, date1904 bool, cellType CellType, opts *Options) string {
 	p := nfp.NumberFormatParser()
 	nf := numberFormat{opts: opts, section: p.Parse(numFmt), value: value, date1904: date1904, cellType: cellType}
+	if culture, ok := nf.getCultureInfo(); ok {
+		nf.localCode = culture.localCode
+	}
 	nf.number, nf.valueSectionType = nf.getValueSectionType(value)
 	nf.prepareNumberic(value)
 	for i, section := range nf.section {
@@ -1131,6 +1352,38 @@ func format(value, numFmt string, date1904 bool, cellType CellType, opts *Option
 	return value
 }
 
+// getCultureInfo returns the locale settings of the culture specified by the
+// options.
+func (nf *numberFormat) getCultureInfo() (cultureInfo, bool) {
+	if nf.opts == nil {
+		return cultureInfo{}, false
+	}
+	culture, ok := supportedCultureInfo[nf.opts.CultureInfo]
+	return culture, ok
+}
+
+// localNumber returns the pre-formatted number text with the decimal and
+// thousands separators of the culture.
+func (nf *numberFormat) localNumber(text string) string {
+	culture, ok := nf.getCultureInfo()
+	if !ok || (culture.decimalSeparator == "." && culture.thousandsSeparator == ",") {
+		return text
+	}
+	return strings.NewReplacer(".", culture.decimalSeparator, ",", culture.thousandsSeparator).Replace(text)
+}
+
+// localGeneralNumber returns the numeric cell value in the General number
+// format with the decimal separator of the culture specified by the options.
+func localGeneralNumber(value string, cellType CellType, opts *Options) string {
+	if cellType != CellTypeNumber && cellType != CellTypeDate {
+		return value
+	}
+	if isNum, _, _ := isNumeric(value); !isNum {
+		return value
+	}
+	return (&numberFormat{opts: opts}).localNumber(value)
+}
+
 // getNumberPartLen returns the length of integer and fraction parts for the
 // numeric.
 func getNumberPartLen(n float64) (int, int) {
@@ -1207,7 +1460,7 @@ func (nf *numberFormat) printNumberLiteral(text string) string {
 			}
 			if !useZeroPlaceHolder {
 				useZeroPlaceHolder = true
-				result += text
+				result += nf.localNumber(text)
 			}
 		}
 	}
@@ -1342,7 +1595,7 @@ func (nf *numberFormat) dateTimeHandler() string {
 			continue
 		}
 		if token.TType == nfp.TokenTypeDecimalPoint {
-			nf.result += "."
+			nf.result += nf.localNumber(".")
 		}
 		if token.TType == nfp.TokenTypeSwitchArgument {
 			nf.switchArgument = token.TValue
@@ -1363,7 +1616,10 @@ func (nf *numberFormat) dateTimeHandler() string {
 func (nf *numberFormat) positiveHandler() string {
 	var fmtNum bool
 	for _, token := range nf.section[nf.sectionIdx].Items {
-		if inStrSlice(supportedTokenTypes, token.TType, true) == -1 || token.TType == nfp.TokenTypeGeneral {
+		if token.TType == nfp.TokenTypeGeneral {
+			return nf.localNumber(nf.value)
+		}
+		if inStrSlice(supportedTokenTypes, token.TType, true) == -1 {
 			return nf.value
 		}
 		if inStrSlice(supportedNumberTokenTypes, token.TType, true) != -1 {
@@ -1403,16 +1659,23 @@ func (nf *numberFormat) currencyLanguageHandler(token nfp.Token) (bool, error) {
 			return false, ErrUnsupportedNumberFormat
 		}
 		if part.Token.TType == nfp.TokenSubTypeLanguageInfo {
+			culture, _ := nf.getCultureInfo()
 			if strings.EqualFold(part.Token.TValue, "F800") { // [$-x-sysdate]
 				if nf.opts != nil && nf.opts.LongDatePattern != "" {
-					nf.value = format(nf.value, nf.opts.LongDatePattern, nf.date1904, nf.cellType, nf.opts)
+					culture.longDatePattern = nf.opts.LongDatePattern
+				}
+				if culture.longDatePattern != "" {
+					nf.value = format(nf.value, culture.longDatePattern, nf.date1904, nf.cellType, nf.opts)
 					return true, nil
 				}
 				part.Token.TValue = "409"
 			}
 			if strings.EqualFold(part.Token.TValue, "F400") { // [$-x-systime]
 				if nf.opts != nil && nf.opts.LongTimePattern != "" {
-					nf.value = format(nf.value, nf.opts.LongTimePattern, nf.date1904, nf.cellType, nf.opts)
+					culture.longTimePattern = nf.opts.LongTimePattern
+				}
+				if culture.longTimePattern != "" {
+					nf.value = format(nf.value, culture.longTimePattern, nf.date1904, nf.cellType, nf.opts)
 					return true, nil
 				}
 				part.Token.TValue = "409"
@@ -1478,6 +1741,17 @@ func localMonthsNameBangla(t time.Time, abbr int) string {
 	return string([]rune(monthNamesBangla[int(t.Month())-1])[:1])
 }
 
+// localMonthsNameDutch returns the Dutch name of the month.
+func localMonthsNameDutch(t time.Time, abbr int) string {
+	if abbr == 3 {
+		return monthNamesDutchAbbr[int(t.Month())-1]
+	}
+	if abbr == 4 {
+		return monthNamesDutch[int(t.Month())-1]
+	}
+	return monthNamesDutchAbbr[int(t.Month())-1][:1]
+}
+
 // localMonthsNameFrench returns the French name of the month.
 func localMonthsNameFrench(t time.Time, abbr int) string {
 	if abbr == 3 {
@@ -1570,6 +1844,28 @@ func localMonthsNameTraditionalMongolian(t time.Time, abbr int) string {
 	return monthNamesTradMongolian[t.Month()-1]
 }
 
+// localMonthsNamePolish returns the Polish name of the month.
+func localMonthsNamePolish(t time.Time, abbr int) string {
+	if abbr == 3 {
+		return monthNamesPolishAbbr[int(t.Month())-1]
+	}
+	if abbr == 4 {
+		return monthNamesPolish[int(t.Month())-1]
+	}
+	return monthNamesPolishAbbr[int(t.Month())-1][:1]
+}
+
+// localMonthsNamePortuguese returns the Portuguese name of the month.
+func localMonthsNamePortuguese(t time.Time, abbr int) string {
+	if abbr == 3 {
+		return monthNamesPortugueseAbbr[int(t.Month())-1]
+	}
+	if abbr == 4 {
+		return monthNamesPortuguese[int(t.Month())-1]
+	}
+	return monthNamesPortugueseAbbr[int(t.Month())-1][:1]
+}
+
 // localMonthsNameRussian returns the Russian name of the month.
 func localMonthsNameRussian(t time.Time, abbr int) string {
 	if abbr == 3 {
@@ -1596,6 +1892,17 @@ func localMonthsNameSpanish(t time.Time, abbr int) string {
 	return monthNamesSpanishAbbr[int(t.Month())-1][:1]
 }
 
+// localMonthsNameSwedish returns the Swedish name of the month.
+func localMonthsNameSwedish(t time.Time, abbr int) string {
+	if abbr == 3 {
+		return monthNamesSwedishAbbr[int(t.Month())-1]
+	}
+	if abbr == 4 {
+		return monthNamesSwedish[int(t.Month())-1]
+	}
+	return monthNamesSwedishAbbr[int(t.Month())-1][:1]
+}
+
 // localMonthsNameThai returns the Thai name of the month.
 func localMonthsNameThai(t time.Time, abbr int) string {
 	if abbr == 3 {
@@ -1704,6 +2011,25 @@ func (nf *numberFormat) localMonthsName(abbr int) string {
 	return localMonthsNameEnglish(nf.t, abbr)
 }
 
+// localWeekdayName return weekday name by supported language ID.
+func (nf *numberFormat) localWeekdayName(abbr bool) string {
+	names, weekday := weekdayNames, int(nf.t.Weekday())
+	if abbr {
+		names = weekdayNamesAbbr
+	}
+	if languageInfo, ok := supportedLanguageInfo[nf.localCode]; ok {
+		for _, tag := range []string{languageInfo.tags[0], strings.Split(languageInfo.tags[0], "-")[0]} {
+			if localNames, ok := names[tag]; ok {
+				return localNames[weekday]
+			}
+		}
+	}
+	if abbr {
+		return nf.t.Weekday().String()[:3]
+	}
+	return nf.t.Weekday().String()
+}
+
 // dateTimesHandler will be handling date and times types tokens for a number
 // format expression.
 func (nf *numberFormat) dateTimesHandler(i int, token nfp.Token) {
@@ -1775,10 +2101,10 @@ func (nf *numberFormat) daysHandler(token nfp.Token) {
 			nf.result += fmt.Sprintf("%02d", nf.t.Day())
 			return
 		case 3:
-			nf.result += nf.t.Weekday().String()[:3]
+			nf.result += nf.localWeekdayName(true)
 			return
 		default:
-			nf.result += nf.t.Weekday().String()
+			nf.result += nf.localWeekdayName(false)
 			return
 		}
 	}
@@ -1914,7 +2240,10 @@ func (nf *numberFormat) secondsNext(i int) bool {
 // expression.
 func (nf *numberFormat) negativeHandler() (result string) {
 	for _, token := range nf.section[nf.sectionIdx].Items {
-		if inStrSlice(supportedTokenTypes, token.TType, true) == -1 || token.TType == nfp.TokenTypeGeneral {
+		if token.TType == nfp.TokenTypeGeneral {
+			return nf.localNumber(nf.value)
+		}
+		if inStrSlice(supportedTokenTypes, token.TType, true) == -1 {
 			return nf.value
 		}
 		if inStrSlice(supportedDateTimeTokenTypes, token.TType, true) != -1 {
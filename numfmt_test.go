@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -1097,3 +1098,96 @@ func TestNumFmt(t *testing.T) {
 	assert.Equal(t, ErrUnsupportedNumberFormat, err)
 	assert.False(t, changeNumFmtCode)
 }
+
+func TestNumFmtCulture(t *testing.T) {
+	for culture, expected := range map[CultureName][]string{
+		CultureNameUnknown: {"1,234.50", "-1234.5", "1234.56", "08-24-23", "8/24/23 12:00", "Thursday August", "00:01.5", "1234567.891"},
+		CultureNameEnUS:    {"1,234.50", "-$1,234.50", "$1,235", "8/24/23", "8/24/23 12:00", "Thursday August", "00:01.5", "1234567.891"},
+		CultureNameZhCN:    {"1,234.50", "-¥1,234.50", "¥1,235", "2023/8/24", "2023/8/24 12:00", "星期四 八月", "00:01.5", "1234567.891"},
+		CultureNameDeDE:    {"1.234,50", "-1.234,50 €", "1.235 €", "24.08.2023", "24.08.2023 12:00", "Donnerstag August", "00:01,5", "1234567,891"},
+		CultureNameEnGB:    {"1,234.50", "-£1,234.50", "£1,235", "24/08/2023", "24/08/2023 12:00", "Thursday August", "00:01.5", "1234567.891"},
+		CultureNameEsES:    {"1.234,50", "-1.234,50 €", "1.235 €", "24/08/2023", "24/08/2023 12:00", "jueves agosto", "00:01,5", "1234567,891"},
+		CultureNameFrFR:    {"1 234,50", "-1 234,50 €", "1 235 €", "24/08/2023", "24/08/2023 12:00", "jeudi août", "00:01,5", "1234567,891"},
+		CultureNameItIT:    {"1.234,50", "-1.234,50 €", "1.235 €", "24/08/2023", "24/08/2023 12:00", "giovedì agosto", "00:01,5", "1234567,891"},
+		CultureNameJaJP:    {"1,234.50", "-¥1,234.50", "¥1,235", "2023/8/24", "2023/8/24 12:00", "木曜日 8月", "00:01.5", "1234567.891"},
+		CultureNameKoKR:    {"1,234.50", "-₩1,234.50", "₩1,235", "2023-08-24", "2023-08-24 12:00", "목요일 8월", "00:01.5", "1234567.891"},
+		CultureNameNlNL:    {"1.234,50", "-€ 1.234,50", "€ 1.235", "24-8-2023", "24-8-2023 12:00", "donderdag augustus", "00:01,5", "1234567,891"},
+		CultureNamePlPL:    {"1 234,50", "-1 234,50 zł", "1 235 zł", "24.08.2023", "24.08.2023 12:00", "czwartek sierpień", "00:01,5", "1234567,891"},
+		CultureNamePtBR:    {"1.234,50", "-R$ 1.234,50", "R$ 1.235", "24/08/2023", "24/08/2023 12:00", "quinta-feira agosto", "00:01,5", "1234567,891"},
+		CultureNameRuRU:    {"1 234,50", "-1 234,50 ₽", "1 235 ₽", "24.08.2023", "24.08.2023 12:00", "четверг август", "00:01,5", "1234567,891"},
+		CultureNameSvSE:    {"1 234,50", "-1 234,50 kr", "1 235 kr", "2023-08-24", "2023-08-24 12:00", "torsdag augusti", "00:01,5", "1234567,891"},
+		CultureNameThTH:    {"1,234.50", "-฿1,234.50", "฿1,235", "24/8/2023", "24/8/2023 12:00", "พฤหัสบดี สิงหาคม", "00:01.5", "1234567.891"},
+		CultureNameTrTR:    {"1.234,50", "-₺1.234,50", "₺1.235", "24.08.2023", "24.08.2023 12:00", "Perşembe Ağustos", "00:01,5", "1234567,891"},
+		CultureNameZhTW:    {"1,234.50", "-NT$1,234.50", "NT$1,235", "2023/8/24", "2023/8/24 12:00", "星期四 8月", "00:01.5", "1234567.891"},
+	} {
+		f := NewFile(Options{CultureInfo: culture})
+		for idx, item := range []struct {
+			value    float64
+			numFmt   int
+			fmtCode  string
+			expected string
+		}{
+			{value: 1234.5, numFmt: 4},
+			{value: -1234.5, numFmt: 8},
+			{value: 1234.56, numFmt: 5},
+			{value: 45162.5, numFmt: 14},
+			{value: 45162.5, numFmt: 22},
+			{value: 45162.5, fmtCode: "dddd mmmm"},
+			{value: 1.5 / 86400, fmtCode: "mm:ss.0"},
+			{value: 1234567.891},
+		} {
+			style := &Style{NumFmt: item.numFmt}
+			if item.fmtCode != "" {
+				style.CustomNumFmt = stringPtr(item.fmtCode)
+			}
+			styleID, err := f.NewStyle(style)
+			assert.NoError(t, err)
+			if item.numFmt >= 5 && item.numFmt <= 8 {
+				// The currency formats are implied by the culture, add them directly
+				f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, xlsxXf{NumFmtID: intPtr(item.numFmt)})
+				f.Styles.CellXfs.Count++
+				styleID = len(f.Styles.CellXfs.Xf) - 1
+			}
+			cell, err := CoordinatesToCellName(1, idx+1)
+			assert.NoError(t, err)
+			assert.NoError(t, f.SetCellValue("Sheet1", cell, item.value))
+			assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, styleID))
+			val, err := f.GetCellValue("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected[idx], val, fmt.Sprintf("culture %d, cell %s", culture, cell))
+		}
+		assert.NoError(t, f.Close())
+	}
+	// Test format number with the long date and time patterns of the culture
+	for _, item := range [][]string{
+		{"45162.5", "[$-F800]dddd, mmmm dd, yyyy", "Donnerstag, 24. August 2023"},
+		{"45162.5", "[$-F400]h:mm:ss AM/PM", "12:00:00"},
+		{"45162.5", "[$-409]dddd ddd mmmm", "Thursday Thu August"},
+		{"45162.5", "[$-404]dddd ddd", "星期四 週四"},
+		{"1234567.891", "General", "1234567,891"},
+		{"-1234.5", "General", "-1234,5"},
+	} {
+		assert.Equal(t, item[2], format(item[0], item[1], false, CellTypeNumber, &Options{CultureInfo: CultureNameDeDE}), item)
+	}
+}
+
+func TestIsDateNumFmt(t *testing.T) {
+	for code, expected := range map[string]bool{
+		"":                       false,
+		"General":                false,
+		"0.00":                   false,
+		"h:mm":                   false,
+		"mm:ss":                  false,
+		"[h]:mm:ss":              false,
+		"h:mm AM/PM":             false,
+		"m/d/yy h:mm":            true,
+		"mm-dd-yy":               true,
+		"mmm":                    true,
+		"d-mmm":                  true,
+		"yyyy\"年\"m\"月\"":        true,
+		"[$-409]mmmm d, yyyy;@":  true,
+		"hh:mm:ss;[Red]mm/dd/yy": false,
+	} {
+		assert.Equal(t, expected, isDateNumFmt(code), code)
+	}
+}
@@ -0,0 +1,1941 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/xuri/efp"
+	"github.com/xuri/nfp"
+)
+
+// odsBorderStyles defined the mapping of cell border styles and the
+// OpenDocument border declarations.
+var odsBorderStyles = map[string]string{
+	"thin":             "0.74pt solid",
+	"medium":           "1.76pt solid",
+	"thick":            "2.49pt solid",
+	"dashed":           "0.74pt dashed",
+	"dotted":           "0.74pt dotted",
+	"double":           "2.49pt double",
+	"hair":             "0.74pt dotted",
+	"mediumDashed":     "1.76pt dashed",
+	"dashDot":          "0.74pt dashed",
+	"mediumDashDot":    "1.76pt dashed",
+	"dashDotDot":       "0.74pt dotted",
+	"mediumDashDotDot": "1.76pt dotted",
+	"slantDashDot":     "1.76pt dashed",
+}
+
+// odsFunctionNames defined the mapping of the OpenFormula function names and
+// the Excel function names which are different in the two syntaxes.
+var odsFunctionNames = map[string]string{
+	"LEGACY.CHIDIST":   "CHIDIST",
+	"LEGACY.CHIINV":    "CHIINV",
+	"LEGACY.CHITEST":   "CHITEST",
+	"LEGACY.FDIST":     "FDIST",
+	"LEGACY.FINV":      "FINV",
+	"LEGACY.FTEST":     "FTEST",
+	"LEGACY.NORMSDIST": "NORMSDIST",
+	"LEGACY.NORMSINV":  "NORMSINV",
+	"LEGACY.TDIST":     "TDIST",
+}
+
+// odsDateLayouts defined the layouts of the date value in the OpenDocument
+// spreadsheet, the fractional seconds are accepted by each layout.
+var odsDateLayouts = []string{
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+	"2006-01-02",
+	"2006-01-02Z07:00",
+}
+
+// odsReader defined the styles and the workbook for reading the OpenDocument
+// spreadsheet.
+type odsReader struct {
+	file       *File
+	styles     map[string]*odsStyle
+	dataStyles map[string]*odsNumberStyle
+	styleIDs   map[string]int
+}
+
+// isODS provides a function to check if the zip archive is an OpenDocument
+// spreadsheet by the media type in the mimetype entry.
+func isODS(zr *zip.Reader) bool {
+	for _, v := range zr.File {
+		if v.Name == "mimetype" {
+			content, err := readFile(v)
+			return err == nil && strings.TrimSpace(string(content)) == ContentTypeODS
+		}
+	}
+	return false
+}
+
+// openODS provides a function to read the OpenDocument spreadsheet (ODS) into
+// a new workbook. The table cells, number value types, cell styles, merged
+// cells, column widths, row heights and hidden rows and columns are mapped
+// onto the workbook, and the formulas are translated from OpenFormula to the
+// Excel syntax. The functions specific to the other applications, such as
+// ORG.OPENOFFICE.* and ORG.LIBREOFFICE.* functions, will be kept as is, and
+// the date values which can't be parsed will be kept as the cell text.
+func openODS(zr *zip.Reader, opts *Options) (*File, error) {
+	r := &odsReader{
+		file:       NewFile(*opts),
+		styles:     make(map[string]*odsStyle),
+		dataStyles: make(map[string]*odsNumberStyle),
+		styleIDs:   make(map[string]int),
+	}
+	var unzipSize int64
+	parts := make(map[string]*zip.File, len(zr.File))
+	for _, v := range zr.File {
+		if unzipSize += v.FileInfo().Size(); unzipSize > opts.UnzipSizeLimit {
+			return nil, newUnzipSizeLimitError(opts.UnzipSizeLimit)
+		}
+		parts[v.Name] = v
+	}
+	var content odsDocument
+	for _, name := range []string{"styles.xml", "content.xml"} {
+		part, ok := parts[name]
+		if !ok {
+			continue
+		}
+		data, err := readFile(part)
+		if err != nil {
+			return nil, err
+		}
+		var doc odsDocument
+		if err = r.file.xmlNewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+			return nil, err
+		}
+		r.addStyles(&doc.Styles)
+		r.addStyles(&doc.AutomaticStyles)
+		content = doc
+	}
+	if len(content.Tables) == 0 {
+		return nil, ErrWorkbookFileFormat
+	}
+	for i, table := range content.Tables {
+		if i == 0 {
+			if err := r.file.SetSheetName(r.file.GetSheetName(0), table.Name); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if _, err := r.file.NewSheet(table.Name); err != nil {
+			return nil, err
+		}
+	}
+	for i := range content.Tables {
+		if err := r.readTable(&content.Tables[i]); err != nil {
+			return nil, err
+		}
+	}
+	for _, table := range content.Tables {
+		if style := r.styles["table:"+table.StyleName]; style != nil && style.TableProperties.get("display") == "false" {
+			if err := r.file.SetSheetVisible(table.Name, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return r.file, nil
+}
+
+// addStyles provides a function to register the styles and data styles by
+// the style family and name.
+func (r *odsReader) addStyles(styles *odsStyles) {
+	for i := range styles.Style {
+		style := &styles.Style[i]
+		r.styles[style.Family+":"+style.Name] = style
+	}
+	for i := range styles.DataStyles {
+		if dataStyle := &styles.DataStyles[i]; dataStyle.XMLName.Space == NameSpaceODFNumber {
+			r.dataStyles[dataStyle.Name] = dataStyle
+		}
+	}
+}
+
+// readTable provides a function to read the columns, rows and cells of the
+// table into the worksheet. The repeated rows without any value are expanded
+// only when they have row formats and don't fill the rest of the table, and
+// the trailing repeated cells without any value are collapsed into the row
+// format, so the rows and cells which only used to fill the table are skipped.
+func (r *odsReader) readTable(t *odsTable) error {
+	ws, err := r.file.workSheetReader(t.Name)
+	if err != nil {
+		return err
+	}
+	col := 1
+	for _, column := range t.Columns {
+		if col > MaxColumns {
+			break
+		}
+		last := col + int(math.Max(float64(column.Repeated), 1)) - 1
+		if last > MaxColumns {
+			last = MaxColumns
+		}
+		if err = r.readColumn(t.Name, col, last, &column); err != nil {
+			return err
+		}
+		col = last + 1
+	}
+	row := 1
+	for i := range t.Rows {
+		tr := &t.Rows[i]
+		n := int(math.Min(math.Max(float64(tr.Repeated), 1), float64(TotalRows-row+1)))
+		if n <= 0 {
+			break
+		}
+		if n == 1 || tr.hasValue() || (i < len(t.Rows)-1 && row+n-1 < TotalRows && r.hasRowFormat(tr)) {
+			for j := 0; j < n; j++ {
+				if err = r.readRow(ws, t.Name, row+j, tr); err != nil {
+					return err
+				}
+			}
+		}
+		row += n
+	}
+	return err
+}
+
+// readColumn provides a function to read the width and visibility of the
+// table columns.
+func (r *odsReader) readColumn(sheet string, first, last int, column *odsColumn) error {
+	start, err := ColumnNumberToName(first)
+	if err != nil {
+		return err
+	}
+	end, err := ColumnNumberToName(last)
+	if err != nil {
+		return err
+	}
+	if style := r.styles["table-column:"+column.StyleName]; style != nil {
+		if width := parseODSLength(style.ColumnProperties.get("column-width")); width > 0 {
+			if err = r.file.SetColWidth(sheet, start, end, math.Max(math.Round((width*96/72-5)/7*100)/100, 0)); err != nil {
+				return err
+			}
+		}
+	}
+	if column.Visibility == "collapse" || column.Visibility == "filter" {
+		return r.file.SetColVisible(sheet, start+":"+end, false)
+	}
+	return nil
+}
+
+// hasRowFormat provides a function to check if the table row without value
+// has the custom height, visibility or cell formats.
+func (r *odsReader) hasRowFormat(tr *odsRow) bool {
+	if style := r.styles["table-row:"+tr.StyleName]; style != nil && style.RowProperties.get("use-optimal-row-height") != "true" &&
+		parseODSLength(style.RowProperties.get("row-height")) > 0 {
+		return true
+	}
+	if tr.Visibility == "collapse" || tr.Visibility == "filter" {
+		return true
+	}
+	for i := range tr.Cells {
+		if tc := &tr.Cells[i]; tc.XMLName.Local != "covered-table-cell" && tc.StyleName != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasValue provides a function to check if the table row contains any cell
+// with value, formula or merged range.
+func (tr *odsRow) hasValue() bool {
+	for i := range tr.Cells {
+		if tr.Cells[i].hasValue() {
+			return true
+		}
+	}
+	return false
+}
+
+// hasValue provides a function to check if the table cell contains value,
+// formula or merged range.
+func (tc *odsCell) hasValue() bool {
+	return tc.ValueType != "" || tc.Formula != "" || len(tc.Paragraphs) > 0 ||
+		tc.ColsSpanned > 1 || tc.RowsSpanned > 1
+}
+
+// text provides a function to get the text content of the table cell.
+func (tc *odsCell) text() string {
+	if tc.StringValue != "" {
+		return tc.StringValue
+	}
+	lines := make([]string, len(tc.Paragraphs))
+	for i, p := range tc.Paragraphs {
+		lines[i] = p.Text
+	}
+	return strings.Join(lines, "\n")
+}
+
+// readRow provides a function to read the height, visibility and cells of the
+// table row.
+func (r *odsReader) readRow(ws *xlsxWorksheet, sheet string, row int, tr *odsRow) error {
+	if style := r.styles["table-row:"+tr.StyleName]; style != nil && style.RowProperties.get("use-optimal-row-height") != "true" {
+		if height := parseODSLength(style.RowProperties.get("row-height")); height > 0 {
+			if err := r.file.SetRowHeight(sheet, row, math.Min(height, MaxRowHeight)); err != nil {
+				return err
+			}
+		}
+	}
+	if tr.Visibility == "collapse" || tr.Visibility == "filter" {
+		if err := r.file.SetRowVisible(sheet, row, false); err != nil {
+			return err
+		}
+	}
+	col := 1
+	for i := range tr.Cells {
+		tc := &tr.Cells[i]
+		n := int(math.Min(math.Max(float64(tc.Repeated), 1), float64(MaxColumns-col+1)))
+		if n <= 0 {
+			break
+		}
+		if tc.XMLName.Local == "covered-table-cell" || (!tc.hasValue() && tc.StyleName == "") {
+			col += n
+			continue
+		}
+		if !tc.hasValue() && n > 1 && (i == len(tr.Cells)-1 || col+n-1 == MaxColumns) {
+			if err := r.setRowStyle(ws, row, tc.StyleName); err != nil {
+				return err
+			}
+			col += n
+			continue
+		}
+		for j := 0; j < n; j++ {
+			if err := r.readCell(ws, sheet, col+j, row, tc); err != nil {
+				return err
+			}
+		}
+		col += n
+	}
+	return nil
+}
+
+// setRowStyle provides a function to set the row format by given cell style
+// name of the repeated cells without value which fill the rest of the row.
+func (r *odsReader) setRowStyle(ws *xlsxWorksheet, row int, styleName string) error {
+	styleID, err := r.getStyleID(styleName, 0)
+	if err != nil || styleID == 0 {
+		return err
+	}
+	ws.prepareSheetXML(0, row)
+	ws.SheetData.Row[row-1].S, ws.SheetData.Row[row-1].CustomFormat = styleID, true
+	return err
+}
+
+// readCell provides a function to set the value, formula, style and merged
+// range of the cell by given table cell.
+func (r *odsReader) readCell(ws *xlsxWorksheet, sheet string, col, row int, tc *odsCell) error {
+	cell, err := CoordinatesToCellName(col, row)
+	if err != nil {
+		return err
+	}
+	if tc.ColsSpanned > 1 || tc.RowsSpanned > 1 {
+		bottomRight, err := CoordinatesToCellName(
+			int(math.Min(float64(col+tc.ColsSpanned-1), MaxColumns)),
+			int(math.Min(float64(row+tc.RowsSpanned-1), TotalRows)))
+		if err != nil {
+			return err
+		}
+		if cell != bottomRight {
+			if err = r.file.MergeCell(sheet, cell, bottomRight); err != nil {
+				return err
+			}
+		}
+	}
+	c, _, _, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	var numFmt int
+	if numFmt, err = r.setCellValue(c, tc); err != nil {
+		return err
+	}
+	if tc.Formula != "" {
+		c.F = &xlsxF{Content: odsFormulaToExcel(tc.Formula)}
+	}
+	c.S, err = r.getStyleID(tc.StyleName, numFmt)
+	return err
+}
+
+// setCellValue provides a function to set the cell value by given value type
+// of the table cell. It returns the default number format ID for the date and
+// time values.
+func (r *odsReader) setCellValue(c *xlsxC, tc *odsCell) (int, error) {
+	var numFmt int
+	switch tc.ValueType {
+	case "float", "percentage", "currency":
+		val, err := strconv.ParseFloat(tc.Value, 64)
+		if err != nil {
+			return numFmt, ErrWorkbookFileFormat
+		}
+		c.T, c.V = setCellFloat(val, -1, 64)
+	case "date":
+		t, ok := parseODSDate(tc.DateValue)
+		if !ok {
+			if err := r.setCellText(c, tc); err != nil {
+				return numFmt, err
+			}
+			break
+		}
+		val, _ := timeToExcelTime(t, false)
+		if c.T, c.V = setCellFloat(val, -1, 64); val != math.Trunc(val) {
+			return 22, nil
+		}
+		numFmt = 14
+	case "time":
+		val, err := parseODSDuration(tc.TimeValue)
+		if err != nil {
+			return numFmt, err
+		}
+		c.T, c.V = setCellFloat(val, -1, 64)
+		numFmt = 21
+	case "boolean":
+		c.T, c.V = setCellBool(tc.BooleanValue == "true")
+	default:
+		if err := r.setCellText(c, tc); err != nil {
+			return numFmt, err
+		}
+	}
+	if tc.CalcExtType == "error" && tc.Formula != "" {
+		c.T, c.V = "e", tc.text()
+	}
+	return numFmt, nil
+}
+
+// setCellText provides a function to set the cell value by given text of the
+// table cell, the text of the formula cell will be set as the formula result.
+func (r *odsReader) setCellText(c *xlsxC, tc *odsCell) error {
+	text := tc.text()
+	if text == "" {
+		return nil
+	}
+	if tc.Formula != "" {
+		c.T, c.V = "str", text
+		return nil
+	}
+	var err error
+	c.T, c.V, err = r.file.setCellString(text)
+	return err
+}
+
+// parseODSDate provides a function to parse the date value of the table cell,
+// the wall clock of the date value with time zone offset will be kept.
+func parseODSDate(value string) (time.Time, bool) {
+	for _, layout := range odsDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(),
+				t.Second(), t.Nanosecond(), time.UTC), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseODSDuration provides a function to parse the ISO 8601 duration of the
+// time value, such as PT12H30M00S, into the fraction of days.
+func parseODSDuration(duration string) (float64, error) {
+	var result float64
+	s, neg := strings.TrimPrefix(duration, "-"), strings.HasPrefix(duration, "-")
+	if !strings.HasPrefix(s, "P") {
+		return result, ErrWorkbookFileFormat
+	}
+	var num string
+	var timePart bool
+	for _, ch := range s[1:] {
+		if ch == '.' || ('0' <= ch && ch <= '9') {
+			num += string(ch)
+			continue
+		}
+		if ch == 'T' && num == "" {
+			timePart = true
+			continue
+		}
+		val, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return result, ErrWorkbookFileFormat
+		}
+		switch {
+		case ch == 'D' && !timePart:
+			result += val
+		case ch == 'H' && timePart:
+			result += val / 24
+		case ch == 'M' && timePart:
+			result += val / 1440
+		case ch == 'S' && timePart:
+			result += val / 86400
+		default:
+			return result, ErrWorkbookFileFormat
+		}
+		num = ""
+	}
+	if num != "" {
+		return result, ErrWorkbookFileFormat
+	}
+	if neg {
+		result = -result
+	}
+	return result, nil
+}
+
+// parseODSLength provides a function to convert the length with the unit
+// into points, it returns 0 if the length is invalid.
+func parseODSLength(length string) float64 {
+	for unit, ratio := range map[string]float64{
+		"cm": 72 / 2.54, "mm": 72 / 25.4, "in": 72, "pt": 1, "pc": 12, "px": 0.75,
+	} {
+		if strings.HasSuffix(length, unit) {
+			if val, err := strconv.ParseFloat(strings.TrimSuffix(length, unit), 64); err == nil {
+				return val * ratio
+			}
+		}
+	}
+	return 0
+}
+
+// getStyleID provides a function to get the cell style ID by given cell
+// style name and the default number format for the date and time values.
+func (r *odsReader) getStyleID(name string, numFmt int) (int, error) {
+	key := name + ":" + strconv.Itoa(numFmt)
+	if styleID, ok := r.styleIDs[key]; ok {
+		return styleID, nil
+	}
+	style, ok := r.getStyle(name)
+	if numFmt != 0 && style.NumFmt == 0 && style.CustomNumFmt == nil {
+		style.NumFmt, ok = numFmt, true
+	}
+	var styleID int
+	var err error
+	if ok {
+		styleID, err = r.file.NewStyle(style)
+	}
+	r.styleIDs[key] = styleID
+	return styleID, err
+}
+
+// getStyleProps provides a function to get the formatting properties and the
+// data style name of the cell style, the properties of the parent styles are
+// inherited.
+func (r *odsReader) getStyleProps(name string) (map[string]string, string) {
+	var chain []*odsStyle
+	for name != "" && len(chain) < 16 {
+		style := r.styles["table-cell:"+name]
+		if style == nil {
+			break
+		}
+		chain = append(chain, style)
+		name = style.ParentStyleName
+	}
+	props, dataStyle := map[string]string{}, ""
+	for i := len(chain) - 1; i >= 0; i-- {
+		for prefix, p := range map[string]*odsProperties{
+			"cell": chain[i].TableCellProperties, "para": chain[i].ParagraphProperties, "text": chain[i].TextProperties,
+		} {
+			if p != nil {
+				for _, attr := range p.Attrs {
+					props[prefix+":"+attr.Name.Local] = attr.Value
+				}
+			}
+		}
+		if chain[i].DataStyleName != "" {
+			dataStyle = chain[i].DataStyleName
+		}
+	}
+	return props, dataStyle
+}
+
+// getStyle provides a function to convert the cell style into the style
+// settings, it returns false if the cell style doesn't contain any supported
+// formatting.
+func (r *odsReader) getStyle(name string) (*Style, bool) {
+	props, dataStyle := r.getStyleProps(name)
+	style := &Style{}
+	if color := props["cell:background-color"]; strings.HasPrefix(color, "#") {
+		style.Fill = Fill{Type: "pattern", Pattern: 1, Color: []string{color}}
+	}
+	for _, side := range []string{"left", "right", "top", "bottom"} {
+		value, ok := props["cell:border-"+side]
+		if !ok {
+			value = props["cell:border"]
+		}
+		if border, ok := parseODSBorder(side, value); ok {
+			style.Border = append(style.Border, border)
+		}
+	}
+	alignment := getODSAlignment(props)
+	if alignment != (Alignment{}) {
+		style.Alignment = &alignment
+	}
+	font := getODSFont(props)
+	if font != (Font{}) {
+		style.Font = &font
+	}
+	if code := r.getNumFmtCode(dataStyle); code != "" {
+		style.CustomNumFmt = &code
+	}
+	return style, style.Fill.Type != "" || style.Border != nil || style.Alignment != nil ||
+		style.Font != nil || style.CustomNumFmt != nil
+}
+
+// parseODSBorder provides a function to convert the border declaration, such
+// as "0.74pt solid #000000", into the border settings.
+func parseODSBorder(side, value string) (Border, bool) {
+	border := Border{Type: side}
+	var width float64
+	var lineStyle string
+	for _, field := range strings.Fields(value) {
+		switch {
+		case strings.HasPrefix(field, "#"):
+			border.Color = field
+		case parseODSLength(field) > 0:
+			width = parseODSLength(field)
+		default:
+			lineStyle = field
+		}
+	}
+	switch lineStyle {
+	case "solid":
+		border.Style = 1
+		if width > 2 {
+			border.Style = 5
+		} else if width > 1 {
+			border.Style = 2
+		}
+	case "dashed":
+		if border.Style = 3; width > 1 {
+			border.Style = 8
+		}
+	case "dotted":
+		border.Style = 4
+	case "double":
+		border.Style = 6
+	}
+	return border, border.Style != 0
+}
+
+// getODSAlignment provides a function to get the alignment settings by given
+// formatting properties.
+func getODSAlignment(props map[string]string) Alignment {
+	var alignment Alignment
+	switch props["para:text-align"] {
+	case "start", "left":
+		alignment.Horizontal = "left"
+	case "end", "right":
+		alignment.Horizontal = "right"
+	case "center", "justify":
+		alignment.Horizontal = props["para:text-align"]
+	}
+	switch props["cell:vertical-align"] {
+	case "top", "bottom":
+		alignment.Vertical = props["cell:vertical-align"]
+	case "middle":
+		alignment.Vertical = "center"
+	}
+	if indent := parseODSLength(props["para:margin-left"]); indent > 0 && alignment.Horizontal == "left" {
+		alignment.Indent = int(math.Round(indent / 6.75))
+	}
+	if angle, err := strconv.Atoi(props["cell:rotation-angle"]); err == nil {
+		if angle %= 360; 0 < angle && angle <= 90 {
+			alignment.TextRotation = angle
+		} else if angle >= 270 {
+			alignment.TextRotation = 450 - angle
+		}
+	}
+	alignment.WrapText = props["cell:wrap-option"] == "wrap"
+	alignment.ShrinkToFit = props["cell:shrink-to-fit"] == "true"
+	return alignment
+}
+
+// getODSFont provides a function to get the font settings by given
+// formatting properties.
+func getODSFont(props map[string]string) Font {
+	var font Font
+	weight := props["text:font-weight"]
+	if n, err := strconv.Atoi(weight); weight == "bold" || (err == nil && n >= 600) {
+		font.Bold = true
+	}
+	font.Italic = props["text:font-style"] == "italic" || props["text:font-style"] == "oblique"
+	if underline := props["text:text-underline-style"]; underline != "" && underline != "none" {
+		if font.Underline = "single"; props["text:text-underline-type"] == "double" {
+			font.Underline = "double"
+		}
+	}
+	if strike := props["text:text-line-through-style"]; strike != "" && strike != "none" {
+		font.Strike = true
+	}
+	if color := props["text:color"]; strings.HasPrefix(color, "#") {
+		font.Color = color
+	}
+	if size := props["text:font-size"]; strings.HasSuffix(size, "pt") {
+		font.Size = parseODSLength(size)
+	}
+	if font.Family = props["text:font-family"]; font.Family == "" {
+		font.Family = props["text:font-name"]
+	}
+	font.Family = strings.Trim(font.Family, "'\"")
+	return font
+}
+
+// getNumFmtCode provides a function to convert the data style into the number
+// format code by given data style name.
+func (r *odsReader) getNumFmtCode(name string) string {
+	dataStyle, ok := r.dataStyles[name]
+	if !ok {
+		return ""
+	}
+	var code strings.Builder
+	for _, item := range dataStyle.Items {
+		if item.XMLName.Space != NameSpaceODFNumber {
+			continue
+		}
+		long := item.get("style") == "long"
+		switch item.XMLName.Local {
+		case "number":
+			code.WriteString(getODSNumberCode(&item))
+		case "scientific-number":
+			digits, _ := strconv.Atoi(item.get("min-exponent-digits"))
+			code.WriteString(getODSNumberCode(&item) + "E+" + strings.Repeat("0", int(math.Max(float64(digits), 2))))
+		case "fraction":
+			numerator, _ := strconv.Atoi(item.get("min-numerator-digits"))
+			denominator := item.get("denominator-value")
+			if denominator == "" {
+				digits, _ := strconv.Atoi(item.get("min-denominator-digits"))
+				denominator = strings.Repeat("?", int(math.Max(float64(digits), 1)))
+			}
+			code.WriteString("# " + strings.Repeat("?", int(math.Max(float64(numerator), 1))) + "/" + denominator)
+		case "text", "currency-symbol":
+			code.WriteString(getNumFmtLiteral(item.Text, dataStyle.XMLName.Local == "percentage-style"))
+		case "text-content":
+			code.WriteString("@")
+		case "year":
+			code.WriteString(map[bool]string{true: "yyyy", false: "yy"}[long])
+		case "month":
+			if item.get("textual") == "true" {
+				code.WriteString(map[bool]string{true: "mmmm", false: "mmm"}[long])
+				break
+			}
+			code.WriteString(map[bool]string{true: "mm", false: "m"}[long])
+		case "day":
+			code.WriteString(map[bool]string{true: "dd", false: "d"}[long])
+		case "day-of-week":
+			code.WriteString(map[bool]string{true: "dddd", false: "ddd"}[long])
+		case "hours":
+			if dataStyle.TruncateOnOverflow == "false" {
+				code.WriteString(map[bool]string{true: "[hh]", false: "[h]"}[long])
+				break
+			}
+			code.WriteString(map[bool]string{true: "hh", false: "h"}[long])
+		case "minutes":
+			code.WriteString(map[bool]string{true: "mm", false: "m"}[long])
+		case "seconds":
+			code.WriteString(map[bool]string{true: "ss", false: "s"}[long])
+			if places, _ := strconv.Atoi(item.get("decimal-places")); places > 0 {
+				code.WriteString("." + strings.Repeat("0", places))
+			}
+		case "am-pm":
+			code.WriteString("AM/PM")
+		}
+	}
+	return code.String()
+}
+
+// getODSNumberCode provides a function to convert the number element of the
+// data style into the number format code.
+func getODSNumberCode(item *odsNumberItem) string {
+	places := item.get("decimal-places")
+	if places == "" {
+		return "General"
+	}
+	decimals, _ := strconv.Atoi(places)
+	minInt, _ := strconv.Atoi(item.get("min-integer-digits"))
+	code := strings.Repeat("0", minInt)
+	if item.get("grouping") == "true" {
+		code = strings.Repeat("#", int(math.Max(float64(4-minInt), 0))) + code
+		code = code[:len(code)-3] + "," + code[len(code)-3:]
+	}
+	if code == "" {
+		code = "#"
+	}
+	if decimals > 0 {
+		minDecimals := decimals
+		if value := item.get("min-decimal-places"); value != "" {
+			minDecimals, _ = strconv.Atoi(value)
+		}
+		code += "." + strings.Repeat("0", minDecimals) + strings.Repeat("#", int(math.Max(float64(decimals-minDecimals), 0)))
+	}
+	return code
+}
+
+// getNumFmtLiteral provides a function to escape the literal text in the
+// number format code, the percent sign will not be escaped in the percentage
+// data style.
+func getNumFmtLiteral(text string, percentage bool) string {
+	var code, quoted strings.Builder
+	flush := func() {
+		if quoted.Len() > 0 {
+			code.WriteString("\"" + quoted.String() + "\"")
+			quoted.Reset()
+		}
+	}
+	for _, ch := range text {
+		if strings.ContainsRune(" -/:()$+", ch) || (ch == '%' && percentage) {
+			flush()
+			code.WriteRune(ch)
+			continue
+		}
+		if ch == '"' {
+			flush()
+			code.WriteString("\\\"")
+			continue
+		}
+		quoted.WriteRune(ch)
+	}
+	flush()
+	return code.String()
+}
+
+// splitFormulaReference provides a function to split the reference by given
+// separator outside the single-quoted sheet names.
+func splitFormulaReference(ref string, sep rune) []string {
+	var parts []string
+	var quoted bool
+	var start int
+	for i, ch := range ref {
+		if ch == '\'' {
+			quoted = !quoted
+		}
+		if ch == sep && !quoted {
+			parts = append(parts, ref[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, ref[start:])
+}
+
+// unquoteSheetName provides a function to remove the quotes of the sheet name
+// in the formula reference.
+func unquoteSheetName(name string) string {
+	if len(name) > 1 && strings.HasPrefix(name, "'") && strings.HasSuffix(name, "'") {
+		return strings.ReplaceAll(name[1:len(name)-1], "''", "'")
+	}
+	return name
+}
+
+// odsFormulaToExcel provides a function to translate the OpenFormula into the
+// Excel formula syntax. For example, of:=SUM([.A1:.B2];[Sheet2.C3]) will be
+// translated to SUM(A1:B2,Sheet2!C3).
+func odsFormulaToExcel(formula string) string {
+	for _, prefix := range []string{"of:=", "oooc:=", "msoxl:=", "="} {
+		if strings.HasPrefix(formula, prefix) {
+			formula = formula[len(prefix):]
+			break
+		}
+	}
+	var b strings.Builder
+	runes := []rune(formula)
+	for i := 0; i < len(runes); i++ {
+		switch ch := runes[i]; ch {
+		case '"':
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == '"' {
+					if j+1 < len(runes) && runes[j+1] == '"' {
+						j++
+						continue
+					}
+					break
+				}
+			}
+			b.WriteString(string(runes[i:int(math.Min(float64(j+1), float64(len(runes))))]))
+			i = j
+		case '[':
+			j, quoted := i+1, false
+			for ; j < len(runes) && (quoted || runes[j] != ']'); j++ {
+				if runes[j] == '\'' {
+					quoted = !quoted
+				}
+			}
+			b.WriteString(odsReferenceToExcel(string(runes[i+1 : int(math.Min(float64(j), float64(len(runes))))])))
+			i = j
+		case ';', '~':
+			b.WriteRune(',')
+		case '|':
+			b.WriteRune(';')
+		case '!':
+			b.WriteRune(' ')
+		default:
+			if !unicode.IsLetter(ch) || (i > 0 && isODSNameRune(runes[i-1])) {
+				b.WriteRune(ch)
+				break
+			}
+			j := i + 1
+			for j < len(runes) && isODSNameRune(runes[j]) {
+				j++
+			}
+			name := string(runes[i:j])
+			if j < len(runes) && runes[j] == '(' {
+				name = odsFunctionToExcel(name)
+			}
+			b.WriteString(name)
+			i = j - 1
+		}
+	}
+	return b.String()
+}
+
+// isODSNameRune provides a function to check if the rune is a part of the
+// function name or defined name in the OpenFormula.
+func isODSNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_'
+}
+
+// odsFunctionToExcel provides a function to translate the OpenFormula
+// function name into the Excel function name.
+func odsFunctionToExcel(name string) string {
+	name = strings.TrimPrefix(strings.ToUpper(name), "COM.MICROSOFT.")
+	if excelName, ok := odsFunctionNames[name]; ok {
+		return excelName
+	}
+	return name
+}
+
+// excelFunctionToODS provides a function to translate the Excel function name
+// into the OpenFormula function name.
+func excelFunctionToODS(name string) string {
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "_xlfn."), "_xlws.")
+	for odsName, excelName := range odsFunctionNames {
+		if strings.EqualFold(name, excelName) {
+			return odsName
+		}
+	}
+	return name
+}
+
+// odsReferenceToExcel provides a function to translate the OpenFormula
+// reference without brackets into the Excel reference.
+func odsReferenceToExcel(ref string) string {
+	var sheets, cells []string
+	for _, part := range splitFormulaReference(ref, ':') {
+		fields := splitFormulaReference(part, '.')
+		sheets = append(sheets, unquoteSheetName(strings.TrimPrefix(strings.Join(fields[:len(fields)-1], "."), "$")))
+		cells = append(cells, fields[len(fields)-1])
+	}
+	if sheets[0] == "" {
+		return strings.Join(cells, ":")
+	}
+	sheet := quoteSheetName(sheets[0])
+	if len(sheets) > 1 && sheets[1] != "" && sheets[1] != sheets[0] {
+		if sheet = sheets[0] + ":" + sheets[1]; quoteSheetName(sheets[0])+quoteSheetName(sheets[1]) != sheets[0]+sheets[1] {
+			sheet = "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+		}
+	}
+	if len(cells) == 2 && cells[0] == cells[1] && isODSCellReference(cells[0], false) {
+		cells = cells[:1]
+	}
+	return sheet + "!" + strings.Join(cells, ":")
+}
+
+// excelFormulaToODS provides a function to translate the Excel formula into
+// the OpenFormula syntax. For example, SUM(A1:B2,Sheet2!C3) will be
+// translated to of:=SUM([.A1:.B2];[Sheet2.C3]).
+func excelFormulaToODS(formula string) string {
+	var b strings.Builder
+	var stack []string
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(formula) {
+		switch token.TType {
+		case efp.TokenTypeOperand:
+			switch token.TSubType {
+			case efp.TokenSubTypeText:
+				b.WriteString("\"" + strings.ReplaceAll(token.TValue, "\"", "\"\"") + "\"")
+			case efp.TokenSubTypeRange:
+				b.WriteString(excelReferenceToODS(token.TValue))
+			case efp.TokenSubTypeLogical:
+				b.WriteString(strings.ToUpper(token.TValue) + "()")
+			default:
+				b.WriteString(token.TValue)
+			}
+		case efp.TokenTypeFunction:
+			if token.TSubType == efp.TokenSubTypeStart {
+				stack = append(stack, token.TValue)
+				switch token.TValue {
+				case "ARRAY":
+					b.WriteString("{")
+				case "ARRAYROW":
+				default:
+					b.WriteString(excelFunctionToODS(token.TValue) + "(")
+				}
+				break
+			}
+			var name string
+			if len(stack) > 0 {
+				name, stack = stack[len(stack)-1], stack[:len(stack)-1]
+			}
+			switch name {
+			case "ARRAY":
+				b.WriteString("}")
+			case "ARRAYROW":
+			default:
+				b.WriteString(")")
+			}
+		case efp.TokenTypeSubexpression:
+			b.WriteString(map[bool]string{true: "(", false: ")"}[token.TSubType == efp.TokenSubTypeStart])
+		case efp.TokenTypeArgument:
+			if len(stack) > 0 && stack[len(stack)-1] == "ARRAY" {
+				b.WriteString("|")
+				break
+			}
+			b.WriteString(";")
+		case efp.TokenTypeOperatorInfix:
+			switch token.TSubType {
+			case efp.TokenSubTypeUnion:
+				b.WriteString("~")
+			case efp.TokenSubTypeIntersection:
+				b.WriteString("!")
+			default:
+				b.WriteString(token.TValue)
+			}
+		case efp.TokenTypeWhitespace:
+			b.WriteString(" ")
+		default:
+			b.WriteString(token.TValue)
+		}
+	}
+	return "of:=" + b.String()
+}
+
+// excelReferenceToODS provides a function to translate the Excel reference
+// into the bracketed OpenFormula reference, the defined names and structured
+// references will be kept.
+func excelReferenceToODS(ref string) string {
+	var sheets []string
+	cells := ref
+	if idx := strings.LastIndex(ref, "!"); idx != -1 {
+		for _, name := range splitFormulaReference(ref[:idx], ':') {
+			sheets = append(sheets, strings.Split(unquoteSheetName(name), ":")...)
+		}
+		cells = ref[idx+1:]
+	}
+	parts := strings.Split(cells, ":")
+	if len(parts) > 2 || (len(parts) == 1 && !isODSCellReference(parts[0], false)) {
+		return ref
+	}
+	for _, part := range parts {
+		if !isODSCellReference(part, true) {
+			return ref
+		}
+	}
+	if len(parts) == 1 && len(sheets) == 2 {
+		parts = append(parts, parts[0])
+	}
+	for i := range parts {
+		var sheet string
+		if i < len(sheets) {
+			sheet = quoteSheetName(sheets[i])
+		}
+		parts[i] = sheet + "." + parts[i]
+	}
+	return "[" + strings.Join(parts, ":") + "]"
+}
+
+// isODSCellReference provides a function to check if the reference is a cell
+// reference, the whole column or row reference is allowed in the range.
+func isODSCellReference(ref string, inRange bool) bool {
+	ref = strings.ReplaceAll(ref, "$", "")
+	col, row, err := CellNameToCoordinates(ref)
+	if err == nil && col > 0 && row > 0 {
+		return true
+	}
+	if !inRange {
+		return false
+	}
+	if _, err = ColumnNameToNumber(ref); err == nil {
+		return true
+	}
+	row, err = strconv.Atoi(ref)
+	return err == nil && 0 < row && row <= TotalRows
+}
+
+// odsCellSnapshot defined the cell value, formula and style for the
+// OpenDocument spreadsheet export.
+type odsCellSnapshot struct {
+	value, text, formula, cellType string
+	styleID, colSpan, rowSpan      int
+	covered, hasFormula            bool
+}
+
+// odsRowSnapshot defined the row height, visibility and cells for the
+// OpenDocument spreadsheet export.
+type odsRowSnapshot struct {
+	height *float64
+	hidden bool
+	cells  map[int]*odsCellSnapshot
+}
+
+// odsWriter defined the automatic styles and the table content for writing
+// the OpenDocument spreadsheet.
+type odsWriter struct {
+	file                  *File
+	styleSheet            *xlsxStyleSheet
+	date1904              bool
+	body, styles          bytes.Buffer
+	colStyles, rowStyles  map[string]string
+	cellStyles, cellKinds map[int]string
+	dataStyles            map[string][2]string
+}
+
+// WriteODS provides a function to write the workbook as an OpenDocument
+// spreadsheet (ODS) to io.Writer. The cell values, number formats, fonts,
+// fills, borders and alignment of cell styles, merged cells, column widths,
+// row heights, hidden rows, columns and worksheets will be written, and the
+// formulas are translated from the Excel syntax to OpenFormula. For example,
+// save the workbook as Book1.ods:
+//
+//	file, err := os.Create("Book1.ods")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	if err := f.WriteODS(file); err != nil {
+//	    fmt.Println(err)
+//	}
+//
+// The OpenDocument spreadsheet could be opened by the OpenFile and
+// OpenReader functions.
+func (f *File) WriteODS(w io.Writer) error {
+	ow := &odsWriter{
+		file:       f,
+		colStyles:  make(map[string]string),
+		rowStyles:  make(map[string]string),
+		cellStyles: make(map[int]string),
+		cellKinds:  make(map[int]string),
+		dataStyles: make(map[string][2]string),
+	}
+	var err error
+	f.mu.Lock()
+	if ow.styleSheet, err = f.loadStyles(); err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	wb, err := f.loadWorkbook()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if wb != nil && wb.WorkbookPr != nil {
+		ow.date1904 = wb.WorkbookPr.Date1904
+	}
+	for _, sheet := range f.GetSheetList() {
+		if err = ow.writeTable(sheet); err != nil {
+			return err
+		}
+	}
+	zw := zip.NewWriter(w)
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	_, _ = fw.Write([]byte(ContentTypeODS))
+	for _, part := range []struct {
+		name    string
+		content []byte
+	}{
+		{"META-INF/manifest.xml", []byte(xml.Header + `<manifest:manifest xmlns:manifest="` + NameSpaceODFManifest + `" manifest:version="1.2">` +
+			`<manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + ContentTypeODS + `"/>` +
+			`<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>` +
+			`<manifest:file-entry manifest:full-path="styles.xml" manifest:media-type="text/xml"/></manifest:manifest>`)},
+		{"styles.xml", []byte(xml.Header + `<office:document-styles ` + odsNamespaces + ` office:version="1.2"><office:styles>` +
+			`<style:style style:name="Default" style:family="table-cell"/></office:styles></office:document-styles>`)},
+		{"content.xml", ow.content()},
+	} {
+		if fw, err = zw.Create(part.name); err != nil {
+			return err
+		}
+		_, _ = fw.Write(part.content)
+	}
+	return zw.Close()
+}
+
+// odsNamespaces defined the namespace declarations of the OpenDocument
+// spreadsheet parts.
+const odsNamespaces = `xmlns:office="` + NameSpaceODFOffice + `" xmlns:style="` + NameSpaceODFStyle +
+	`" xmlns:table="` + NameSpaceODFTable + `" xmlns:text="` + NameSpaceODFText + `" xmlns:fo="` + NameSpaceODFFo +
+	`" xmlns:number="` + NameSpaceODFNumber + `" xmlns:of="` + NameSpaceODFOf + `"`
+
+// content provides a function to get the content.xml part with the automatic
+// styles and tables.
+func (ow *odsWriter) content() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header + `<office:document-content ` + odsNamespaces + ` office:version="1.2"><office:automatic-styles>`)
+	buf.WriteString(`<style:style style:name="ta1" style:family="table"><style:table-properties table:display="true"/></style:style>`)
+	buf.WriteString(`<style:style style:name="ta2" style:family="table"><style:table-properties table:display="false"/></style:style>`)
+	buf.Write(ow.styles.Bytes())
+	buf.WriteString(`</office:automatic-styles><office:body><office:spreadsheet>`)
+	buf.Write(ow.body.Bytes())
+	buf.WriteString(`</office:spreadsheet></office:body></office:document-content>`)
+	return buf.Bytes()
+}
+
+// writeODSEscaped provides a function to write the XML escaped text.
+func writeODSEscaped(buf *bytes.Buffer, text string) {
+	_ = xml.EscapeText(buf, []byte(text))
+}
+
+// snapshotTable provides a function to take a snapshot of the rows, cells and
+// merged cells of the worksheet for the OpenDocument spreadsheet export.
+func (ow *odsWriter) snapshotTable(sheet string) (map[int]*odsRowSnapshot, []xlsxCol, float64, error) {
+	f := ow.file
+	f.mu.Lock()
+	ws, _, err := f.loadWorkSheet(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	rows := make(map[int]*odsRowSnapshot)
+	getRow := func(r int) *odsRowSnapshot {
+		if rows[r] == nil {
+			rows[r] = &odsRowSnapshot{cells: make(map[int]*odsCellSnapshot)}
+		}
+		return rows[r]
+	}
+	ws.mu.Lock()
+	defaultHeight := defaultRowHeight
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultRowHeight > 0 {
+		defaultHeight = ws.SheetFormatPr.DefaultRowHeight
+	}
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		tr := getRow(row.R)
+		tr.height, tr.hidden = row.Ht, row.Hidden
+		for colIdx := range row.C {
+			c := &row.C[colIdx]
+			if !c.hasValue() && c.S == 0 {
+				continue
+			}
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				ws.mu.Unlock()
+				return nil, nil, 0, err
+			}
+			text, err := c.getValueFrom(f, sst, false)
+			if err != nil {
+				ws.mu.Unlock()
+				return nil, nil, 0, err
+			}
+			tr.cells[col] = &odsCellSnapshot{
+				value: c.V, text: text, cellType: c.T, styleID: c.S,
+				colSpan: 1, rowSpan: 1, hasFormula: c.F != nil,
+			}
+			if c.T == "s" || c.T == "inlineStr" {
+				tr.cells[col].value = text
+			}
+		}
+	}
+	var cols []xlsxCol
+	if ws.Cols != nil {
+		cols = append(cols, ws.Cols.Col...)
+	}
+	var mergeCells []string
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			mergeCells = append(mergeCells, mergeCell.Ref)
+		}
+	}
+	ws.mu.Unlock()
+	for r, tr := range rows {
+		for col, cell := range tr.cells {
+			if !cell.hasFormula {
+				continue
+			}
+			ref, err := CoordinatesToCellName(col, r)
+			if err != nil {
+				return nil, nil, 0, err
+			}
+			if cell.formula, err = f.GetCellFormula(sheet, ref); err != nil {
+				return nil, nil, 0, err
+			}
+		}
+	}
+	for _, ref := range mergeCells {
+		rect, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		_ = sortCoordinates(rect)
+		for r := rect[1]; r <= rect[3]; r++ {
+			for c := rect[0]; c <= rect[2]; c++ {
+				cell := getRow(r).cells[c]
+				if cell == nil {
+					cell = &odsCellSnapshot{colSpan: 1, rowSpan: 1}
+					rows[r].cells[c] = cell
+				}
+				if r == rect[1] && c == rect[0] {
+					cell.colSpan, cell.rowSpan = rect[2]-rect[0]+1, rect[3]-rect[1]+1
+					continue
+				}
+				cell.covered = true
+			}
+		}
+	}
+	return rows, cols, defaultHeight, err
+}
+
+// writeTable provides a function to write the table element of the worksheet
+// for the OpenDocument spreadsheet export.
+func (ow *odsWriter) writeTable(sheet string) error {
+	rows, cols, defaultHeight, err := ow.snapshotTable(sheet)
+	if err != nil {
+		return err
+	}
+	visible, err := ow.file.GetSheetVisible(sheet)
+	if err != nil {
+		return err
+	}
+	tableStyle := map[bool]string{true: "ta1", false: "ta2"}[visible]
+	ow.body.WriteString(`<table:table table:name="`)
+	writeODSEscaped(&ow.body, sheet)
+	ow.body.WriteString(`" table:style-name="` + tableStyle + `">`)
+	var rowNums []int
+	maxCol := 1
+	for r, tr := range rows {
+		rowNums = append(rowNums, r)
+		for c := range tr.cells {
+			if c > maxCol {
+				maxCol = c
+			}
+		}
+	}
+	sort.Ints(rowNums)
+	ow.writeColumns(cols, maxCol)
+	defaultRowStyle := ow.getRowStyle(defaultHeight, false)
+	next := 1
+	for _, r := range rowNums {
+		if r > next {
+			fmt.Fprintf(&ow.body, `<table:table-row table:style-name="%s" table:number-rows-repeated="%d"><table:table-cell/></table:table-row>`, defaultRowStyle, r-next)
+		}
+		tr := rows[r]
+		rowStyle := defaultRowStyle
+		if tr.height != nil {
+			rowStyle = ow.getRowStyle(*tr.height, true)
+		}
+		ow.body.WriteString(`<table:table-row table:style-name="` + rowStyle + `"`)
+		if tr.hidden {
+			ow.body.WriteString(` table:visibility="collapse"`)
+		}
+		ow.body.WriteString(">")
+		if err = ow.writeCells(tr); err != nil {
+			return err
+		}
+		ow.body.WriteString("</table:table-row>")
+		next = r + 1
+	}
+	if len(rowNums) == 0 {
+		ow.body.WriteString(`<table:table-row table:style-name="` + defaultRowStyle + `"><table:table-cell/></table:table-row>`)
+	}
+	ow.body.WriteString("</table:table>")
+	return err
+}
+
+// writeColumns provides a function to write the table columns with the width
+// and visibility for the OpenDocument spreadsheet export.
+func (ow *odsWriter) writeColumns(cols []xlsxCol, maxCol int) {
+	widths, hidden := map[int]float64{}, map[int]bool{}
+	for _, col := range cols {
+		for c := col.Min; c <= col.Max && c <= MaxColumns; c++ {
+			if col.Width != nil && *col.Width > 0 {
+				widths[c] = *col.Width
+			}
+			hidden[c] = col.Hidden
+			if c > maxCol {
+				maxCol = c
+			}
+		}
+	}
+	for c := 1; c <= maxCol; {
+		width, ok := widths[c]
+		if !ok {
+			width = defaultColWidth
+		}
+		n := 1
+		for c+n <= maxCol && widths[c+n] == widths[c] && hidden[c+n] == hidden[c] {
+			n++
+		}
+		ow.body.WriteString(`<table:table-column table:style-name="` + ow.getColumnStyle(width) + `"`)
+		if n > 1 {
+			fmt.Fprintf(&ow.body, ` table:number-columns-repeated="%d"`, n)
+		}
+		if hidden[c] {
+			ow.body.WriteString(` table:visibility="collapse"`)
+		}
+		ow.body.WriteString(` table:default-cell-style-name="Default"/>`)
+		c += n
+	}
+}
+
+// getColumnStyle provides a function to get the automatic column style name
+// by given column width.
+func (ow *odsWriter) getColumnStyle(width float64) string {
+	value := strconv.FormatFloat((width*7+5)/96, 'f', 4, 64) + "in"
+	if name, ok := ow.colStyles[value]; ok {
+		return name
+	}
+	name := fmt.Sprintf("co%d", len(ow.colStyles)+1)
+	ow.colStyles[value] = name
+	fmt.Fprintf(&ow.styles, `<style:style style:name="%s" style:family="table-column"><style:table-column-properties fo:break-before="auto" style:column-width="%s"/></style:style>`, name, value)
+	return name
+}
+
+// getRowStyle provides a function to get the automatic row style name by
+// given row height.
+func (ow *odsWriter) getRowStyle(height float64, custom bool) string {
+	value := strconv.FormatFloat(height, 'f', -1, 64) + "pt"
+	key := value + strconv.FormatBool(custom)
+	if name, ok := ow.rowStyles[key]; ok {
+		return name
+	}
+	name := fmt.Sprintf("ro%d", len(ow.rowStyles)+1)
+	ow.rowStyles[key] = name
+	fmt.Fprintf(&ow.styles, `<style:style style:name="%s" style:family="table-row"><style:table-row-properties style:row-height="%s" fo:break-before="auto" style:use-optimal-row-height="%t"/></style:style>`, name, value, !custom)
+	return name
+}
+
+// writeCells provides a function to write the table cells of the row for the
+// OpenDocument spreadsheet export.
+func (ow *odsWriter) writeCells(tr *odsRowSnapshot) error {
+	var cols []int
+	for c := range tr.cells {
+		cols = append(cols, c)
+	}
+	sort.Ints(cols)
+	if len(cols) == 0 {
+		ow.body.WriteString("<table:table-cell/>")
+	}
+	next := 1
+	for i := 0; i < len(cols); i++ {
+		c := cols[i]
+		if c > next {
+			fmt.Fprintf(&ow.body, `<table:table-cell table:number-columns-repeated="%d"/>`, c-next)
+		}
+		next = c + 1
+		cell := tr.cells[c]
+		if cell.covered {
+			n := 1
+			for i+n < len(cols) && cols[i+n] == c+n && tr.cells[c+n].covered {
+				n++
+			}
+			if ow.body.WriteString("<table:covered-table-cell"); n > 1 {
+				fmt.Fprintf(&ow.body, ` table:number-columns-repeated="%d"`, n)
+			}
+			ow.body.WriteString("/>")
+			i, next = i+n-1, c+n
+			continue
+		}
+		if err := ow.writeCell(cell); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCell provides a function to write the table cell with the value,
+// formula, style and spans for the OpenDocument spreadsheet export.
+func (ow *odsWriter) writeCell(cell *odsCellSnapshot) error {
+	styleName, kind := ow.getCellStyle(cell.styleID)
+	ow.body.WriteString("<table:table-cell")
+	if styleName != "" {
+		ow.body.WriteString(` table:style-name="` + styleName + `"`)
+	}
+	if cell.colSpan > 1 || cell.rowSpan > 1 {
+		fmt.Fprintf(&ow.body, ` table:number-columns-spanned="%d" table:number-rows-spanned="%d"`, cell.colSpan, cell.rowSpan)
+	}
+	if cell.formula != "" {
+		ow.body.WriteString(` table:formula="`)
+		writeODSEscaped(&ow.body, excelFormulaToODS(cell.formula))
+		ow.body.WriteString(`"`)
+	}
+	switch cell.cellType {
+	case "b":
+		fmt.Fprintf(&ow.body, ` office:value-type="boolean" office:boolean-value="%t"`, cell.value == "1")
+	case "s", "inlineStr", "str", "e":
+		ow.body.WriteString(` office:value-type="string"`)
+	case "d":
+		ow.body.WriteString(` office:value-type="date" office:date-value="`)
+		writeODSEscaped(&ow.body, strings.TrimSuffix(cell.value, "Z"))
+		ow.body.WriteString(`"`)
+	default:
+		if cell.value == "" {
+			break
+		}
+		val, err := strconv.ParseFloat(cell.value, 64)
+		if err != nil {
+			ow.body.WriteString(` office:value-type="string"`)
+			break
+		}
+		ow.writeNumberValue(val, kind)
+	}
+	if cell.text == "" {
+		ow.body.WriteString("/>")
+		return nil
+	}
+	ow.body.WriteString(">")
+	writeODSParagraphs(&ow.body, cell.text)
+	ow.body.WriteString("</table:table-cell>")
+	return nil
+}
+
+// writeNumberValue provides a function to write the value type and value
+// attributes of the numeric cell by given data style kind.
+func (ow *odsWriter) writeNumberValue(val float64, kind string) {
+	switch kind {
+	case "date-style":
+		t := timeFromExcelTime(val, ow.date1904).Round(time.Millisecond)
+		layout := "2006-01-02T15:04:05.999"
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+			layout = "2006-01-02"
+		}
+		fmt.Fprintf(&ow.body, ` office:value-type="date" office:date-value="%s"`, t.Format(layout))
+	case "time-style":
+		fmt.Fprintf(&ow.body, ` office:value-type="time" office:time-value="%s"`, getODSDuration(val))
+	case "percentage-style":
+		fmt.Fprintf(&ow.body, ` office:value-type="percentage" office:value="%s"`, strconv.FormatFloat(val, 'f', -1, 64))
+	default:
+		fmt.Fprintf(&ow.body, ` office:value-type="float" office:value="%s"`, strconv.FormatFloat(val, 'f', -1, 64))
+	}
+}
+
+// getODSDuration provides a function to convert the fraction of days into the
+// ISO 8601 duration of the time value, such as PT12H30M00S.
+func getODSDuration(val float64) string {
+	var sign string
+	if val < 0 {
+		sign, val = "-", -val
+	}
+	ms := int64(math.Round(val * 86400000))
+	hours, ms := ms/3600000, ms%3600000
+	minutes, ms := ms/60000, ms%60000
+	seconds := strconv.FormatFloat(float64(ms)/1000, 'f', -1, 64)
+	if ms < 10000 {
+		seconds = "0" + seconds
+	}
+	return fmt.Sprintf("%sPT%02dH%02dM%sS", sign, hours, minutes, seconds)
+}
+
+// writeODSParagraphs provides a function to write the text as paragraphs, the
+// consecutive spaces and tabs are written as the space and tab elements.
+func writeODSParagraphs(buf *bytes.Buffer, text string) {
+	for _, line := range strings.Split(text, "\n") {
+		buf.WriteString("<text:p>")
+		runes := []rune(line)
+		for i := 0; i < len(runes); i++ {
+			switch runes[i] {
+			case ' ':
+				n := 1
+				for i+n < len(runes) && runes[i+n] == ' ' {
+					n++
+				}
+				if i > 0 {
+					buf.WriteString(" ")
+					n--
+				}
+				if n > 0 {
+					fmt.Fprintf(buf, `<text:s text:c="%d"/>`, n)
+				}
+				for i+1 < len(runes) && runes[i+1] == ' ' {
+					i++
+				}
+			case '\t':
+				buf.WriteString("<text:tab/>")
+			default:
+				writeODSEscaped(buf, string(runes[i]))
+			}
+		}
+		buf.WriteString("</text:p>")
+	}
+}
+
+// getCellStyle provides a function to get the automatic cell style name and
+// the kind of the data style by given cell style ID.
+func (ow *odsWriter) getCellStyle(styleID int) (string, string) {
+	if name, ok := ow.cellStyles[styleID]; ok {
+		return name, ow.cellKinds[styleID]
+	}
+	ow.styleSheet.mu.Lock()
+	defer ow.styleSheet.mu.Unlock()
+	if ow.styleSheet.CellXfs == nil || styleID < 0 || styleID >= len(ow.styleSheet.CellXfs.Xf) {
+		return "", ""
+	}
+	xf := ow.styleSheet.CellXfs.Xf[styleID]
+	dataStyle := ow.getDataStyle(ow.file.getXfNumFmtCode(ow.styleSheet, xf))
+	ow.cellKinds[styleID] = dataStyle[1]
+	if styleID == 0 {
+		ow.cellStyles[styleID] = ""
+		return "", dataStyle[1]
+	}
+	name := fmt.Sprintf("ce%d", len(ow.cellStyles)+1)
+	ow.cellStyles[styleID] = name
+	ow.styles.WriteString(`<style:style style:name="` + name + `" style:family="table-cell" style:parent-style-name="Default"`)
+	if dataStyle[0] != "" {
+		ow.styles.WriteString(` style:data-style-name="` + dataStyle[0] + `"`)
+	}
+	ow.styles.WriteString(">")
+	var cellProps, paraProps, textProps []string
+	if xf.FillID != nil && ow.styleSheet.Fills != nil && *xf.FillID < len(ow.styleSheet.Fills.Fill) {
+		if fill := ow.styleSheet.Fills.Fill[*xf.FillID]; fill.PatternFill != nil && fill.PatternFill.PatternType != "" && fill.PatternFill.PatternType != "none" {
+			color := ow.file.getCSSColor(fill.PatternFill.FgColor)
+			if color == "" {
+				color = ow.file.getCSSColor(fill.PatternFill.BgColor)
+			}
+			if color != "" {
+				cellProps = append(cellProps, `fo:background-color="`+color+`"`)
+			}
+		}
+	}
+	if xf.BorderID != nil && ow.styleSheet.Borders != nil && *xf.BorderID < len(ow.styleSheet.Borders.Border) {
+		cellProps = append(cellProps, ow.getBorderProps(ow.styleSheet.Borders.Border[*xf.BorderID])...)
+	}
+	if xf.Alignment != nil {
+		cell, para := getODSAlignmentProps(xf.Alignment)
+		cellProps, paraProps = append(cellProps, cell...), para
+	}
+	if xf.FontID != nil && ow.styleSheet.Fonts != nil && *xf.FontID < len(ow.styleSheet.Fonts.Font) {
+		textProps = ow.getFontProps(ow.styleSheet.Fonts.Font[*xf.FontID])
+	}
+	for _, props := range []struct {
+		name  string
+		attrs []string
+	}{
+		{"table-cell-properties", cellProps}, {"paragraph-properties", paraProps}, {"text-properties", textProps},
+	} {
+		if len(props.attrs) > 0 {
+			ow.styles.WriteString("<style:" + props.name + " " + strings.Join(props.attrs, " ") + "/>")
+		}
+	}
+	ow.styles.WriteString("</style:style>")
+	return name, dataStyle[1]
+}
+
+// getBorderProps provides a function to get the border properties of the
+// automatic cell style by given border.
+func (ow *odsWriter) getBorderProps(border *xlsxBorder) []string {
+	var props []string
+	if border == nil {
+		return props
+	}
+	for _, side := range []struct {
+		name string
+		line xlsxLine
+	}{
+		{"left", border.Left}, {"right", border.Right},
+		{"top", border.Top}, {"bottom", border.Bottom},
+	} {
+		style, ok := odsBorderStyles[side.line.Style]
+		if !ok {
+			continue
+		}
+		color := ow.file.getCSSColor(side.line.Color)
+		if color == "" {
+			color = "#000000"
+		}
+		props = append(props, fmt.Sprintf(`fo:border-%s="%s %s"`, side.name, style, color))
+	}
+	return props
+}
+
+// getODSAlignmentProps provides a function to get the cell and paragraph
+// properties of the automatic cell style by given alignment.
+func getODSAlignmentProps(alignment *xlsxAlignment) ([]string, []string) {
+	var cellProps, paraProps []string
+	switch alignment.Vertical {
+	case "top", "bottom":
+		cellProps = append(cellProps, `style:vertical-align="`+alignment.Vertical+`"`)
+	case "center", "justify", "distributed":
+		cellProps = append(cellProps, `style:vertical-align="middle"`)
+	}
+	if alignment.WrapText {
+		cellProps = append(cellProps, `fo:wrap-option="wrap"`)
+	}
+	if alignment.ShrinkToFit {
+		cellProps = append(cellProps, `style:shrink-to-fit="true"`)
+	}
+	if 0 < alignment.TextRotation && alignment.TextRotation <= 90 {
+		cellProps = append(cellProps, fmt.Sprintf(`style:rotation-angle="%d"`, alignment.TextRotation))
+	} else if 90 < alignment.TextRotation && alignment.TextRotation <= 180 {
+		cellProps = append(cellProps, fmt.Sprintf(`style:rotation-angle="%d"`, 450-alignment.TextRotation))
+	}
+	switch alignment.Horizontal {
+	case "left":
+		paraProps = append(paraProps, `fo:text-align="start"`)
+	case "right":
+		paraProps = append(paraProps, `fo:text-align="end"`)
+	case "center", "centerContinuous", "distributed":
+		paraProps = append(paraProps, `fo:text-align="center"`)
+	case "justify":
+		paraProps = append(paraProps, `fo:text-align="justify"`)
+	}
+	if alignment.Indent > 0 {
+		paraProps = append(paraProps, fmt.Sprintf(`fo:margin-left="%spt"`, strconv.FormatFloat(float64(alignment.Indent)*6.75, 'f', -1, 64)))
+	}
+	return cellProps, paraProps
+}
+
+// getFontProps provides a function to get the text properties of the
+// automatic cell style by given font.
+func (ow *odsWriter) getFontProps(font *xlsxFont) []string {
+	var props []string
+	if font == nil {
+		return props
+	}
+	var escaped bytes.Buffer
+	if font.Name != nil && font.Name.Val != nil {
+		writeODSEscaped(&escaped, *font.Name.Val)
+		props = append(props, `fo:font-family="`+escaped.String()+`"`)
+	}
+	if font.Sz != nil && font.Sz.Val != nil {
+		props = append(props, `fo:font-size="`+strconv.FormatFloat(*font.Sz.Val, 'f', -1, 64)+`pt"`)
+	}
+	if font.B != nil && (font.B.Val == nil || *font.B.Val) {
+		props = append(props, `fo:font-weight="bold"`)
+	}
+	if font.I != nil && (font.I.Val == nil || *font.I.Val) {
+		props = append(props, `fo:font-style="italic"`)
+	}
+	if font.U != nil && (font.U.Val == nil || *font.U.Val != "none") {
+		props = append(props, `style:text-underline-style="solid" style:text-underline-width="auto" style:text-underline-color="font-color"`)
+		if font.U.Val != nil && strings.HasPrefix(*font.U.Val, "double") {
+			props = append(props, `style:text-underline-type="double"`)
+		}
+	}
+	if font.Strike != nil && (font.Strike.Val == nil || *font.Strike.Val) {
+		props = append(props, `style:text-line-through-style="solid"`)
+	}
+	if color := ow.file.getCSSColor(font.Color); color != "" {
+		props = append(props, `fo:color="`+color+`"`)
+	}
+	return props
+}
+
+// getDataStyle provides a function to get the data style name and kind by
+// given number format code, the data style will be created if not exists.
+func (ow *odsWriter) getDataStyle(code string) [2]string {
+	if dataStyle, ok := ow.dataStyles[code]; ok {
+		return dataStyle
+	}
+	kind, elements, elapsed := getODSDataStyleElements(code)
+	dataStyle := [2]string{"", kind}
+	if elements != "" {
+		dataStyle[0] = fmt.Sprintf("N%d", len(ow.dataStyles)+1)
+		fmt.Fprintf(&ow.styles, `<number:%s style:name="%s"`, kind, dataStyle[0])
+		if elapsed {
+			ow.styles.WriteString(` number:truncate-on-overflow="false"`)
+		}
+		fmt.Fprintf(&ow.styles, `>%s</number:%s>`, elements, kind)
+	}
+	ow.dataStyles[code] = dataStyle
+	return dataStyle
+}
+
+// getODSDataStyleElements provides a function to convert the first section
+// of the number format code into the data style kind and elements, and
+// returns true if the elapsed time is used. It returns empty elements if the
+// number format is general or unsupported.
+func getODSDataStyleElements(code string) (string, string, bool) {
+	p := nfp.NumberFormatParser()
+	sections := p.Parse(code)
+	if len(sections) == 0 {
+		return "number-style", "", false
+	}
+	var elements, number, literal strings.Builder
+	var hasDate, hasTime, hasPercent, hasText, afterSeconds, elapsed bool
+	var exponent string
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			elements.WriteString("<number:text>")
+			var buf bytes.Buffer
+			writeODSEscaped(&buf, literal.String())
+			elements.Write(buf.Bytes())
+			elements.WriteString("</number:text>")
+			literal.Reset()
+		}
+	}
+	flushNumber := func() {
+		if number.Len() > 0 {
+			elements.WriteString(getODSNumberElement(number.String(), exponent))
+			number.Reset()
+			exponent = ""
+		}
+	}
+	items := sections[0].Items
+	for i, token := range items {
+		switch token.TType {
+		case nfp.TokenTypeZeroPlaceHolder, nfp.TokenTypeHashPlaceHolder, nfp.TokenTypeDecimalPoint, nfp.TokenTypeThousandsSeparator:
+			if afterSeconds && token.TType != nfp.TokenTypeThousandsSeparator {
+				if token.TType == nfp.TokenTypeZeroPlaceHolder {
+					s := elements.String()
+					elements.Reset()
+					elements.WriteString(strings.TrimSuffix(s, "/>") + fmt.Sprintf(` number:decimal-places="%d"/>`, len(token.TValue)))
+				}
+				continue
+			}
+			flushLiteral()
+			number.WriteString(token.TValue)
+		case nfp.TokenTypeExponential:
+			exponent = token.TValue
+			flushLiteral()
+			for _, next := range items[i+1:] {
+				if next.TType != nfp.TokenTypeZeroPlaceHolder {
+					break
+				}
+				exponent += next.TValue
+			}
+			flushNumber()
+			return getODSDataStyleKind(hasDate, hasTime, hasPercent, hasText), elements.String(), elapsed
+		case nfp.TokenTypePercent:
+			flushNumber()
+			hasPercent = true
+			literal.WriteString("%")
+		case nfp.TokenTypeLiteral:
+			flushNumber()
+			literal.WriteString(token.TValue)
+		case nfp.TokenTypeTextPlaceHolder:
+			flushNumber()
+			flushLiteral()
+			hasText = true
+			elements.WriteString("<number:text-content/>")
+		case nfp.TokenTypeDateTimes, nfp.TokenTypeElapsedDateTimes:
+			flushNumber()
+			flushLiteral()
+			element, isDate := getODSDateTimeElement(items, i)
+			if element == "" {
+				return "number-style", "", false
+			}
+			elapsed = elapsed || token.TType == nfp.TokenTypeElapsedDateTimes
+			hasDate, hasTime = hasDate || isDate, hasTime || !isDate
+			afterSeconds = strings.HasPrefix(element, "<number:seconds")
+			elements.WriteString(element)
+			continue
+		case nfp.TokenTypeGeneral:
+			if len(items) == 1 {
+				return "number-style", "", false
+			}
+			flushLiteral()
+			elements.WriteString(`<number:number number:min-integer-digits="1"/>`)
+		case nfp.TokenTypeColor, nfp.TokenTypeCondition, nfp.TokenTypeCurrencyLanguage:
+		default:
+			return "number-style", "", false
+		}
+		afterSeconds = false
+	}
+	flushNumber()
+	flushLiteral()
+	return getODSDataStyleKind(hasDate, hasTime, hasPercent, hasText), elements.String(), elapsed
+}
+
+// getODSDataStyleKind provides a function to get the kind of the data style.
+func getODSDataStyleKind(hasDate, hasTime, hasPercent, hasText bool) string {
+	switch {
+	case hasDate:
+		return "date-style"
+	case hasTime:
+		return "time-style"
+	case hasPercent:
+		return "percentage-style"
+	case hasText:
+		return "text-style"
+	}
+	return "number-style"
+}
+
+// getODSNumberElement provides a function to convert the number placeholders
+// of the number format code into the number or scientific number element.
+func getODSNumberElement(pattern, exponent string) string {
+	intPart, decPart := pattern, ""
+	if idx := strings.Index(pattern, "."); idx != -1 {
+		intPart, decPart = pattern[:idx], pattern[idx+1:]
+	}
+	attrs := fmt.Sprintf(` number:decimal-places="%d" number:min-decimal-places="%d" number:min-integer-digits="%d"`,
+		len(decPart), strings.Count(decPart, "0"), strings.Count(intPart, "0"))
+	if strings.Contains(intPart, ",") {
+		attrs += ` number:grouping="true"`
+	}
+	if exponent != "" {
+		return fmt.Sprintf(`<number:scientific-number%s number:min-exponent-digits="%d"/>`, attrs, strings.Count(exponent, "0"))
+	}
+	return "<number:number" + attrs + "/>"
+}
+
+// getODSDateTimeElement provides a function to convert the date and time
+// token of the number format code into the data style element, and returns
+// true if the element is a date part.
+func getODSDateTimeElement(items []nfp.Token, i int) (string, bool) {
+	value := strings.ToLower(items[i].TValue)
+	long := len(value) > 1
+	style := map[bool]string{true: ` number:style="long"`, false: ""}[long]
+	switch {
+	case value == "am/pm" || value == "a/p":
+		return "<number:am-pm/>", false
+	case strings.HasPrefix(value, "y"):
+		return "<number:year" + map[bool]string{true: ` number:style="long"`, false: ""}[len(value) > 2] + "/>", true
+	case strings.HasPrefix(value, "d"):
+		if len(value) > 2 {
+			return "<number:day-of-week" + map[bool]string{true: ` number:style="long"`, false: ""}[len(value) > 3] + "/>", true
+		}
+		return "<number:day" + style + "/>", true
+	case strings.Contains(value, "h"):
+		return "<number:hours" + style + "/>", false
+	case strings.Contains(value, "s"):
+		return "<number:seconds" + style + "/>", false
+	case strings.Contains(value, "m"):
+		if len(value) > 2 {
+			return `<number:month number:textual="true"` + map[bool]string{true: ` number:style="long"`, false: ""}[len(value) > 3] + "/>", true
+		}
+		if items[i].TType == nfp.TokenTypeElapsedDateTimes || isMinutesToken(items, i) {
+			return "<number:minutes" + style + "/>", false
+		}
+		return "<number:month" + style + "/>", true
+	}
+	return "", false
+}
+
+// isMinutesToken provides a function to check if the "m" or "mm" date and
+// time token means minutes, which is preceded by hours or followed by
+// seconds.
+func isMinutesToken(items []nfp.Token, i int) bool {
+	for j := i - 1; j >= 0; j-- {
+		if items[j].TType == nfp.TokenTypeDateTimes || items[j].TType == nfp.TokenTypeElapsedDateTimes {
+			if strings.Contains(strings.ToLower(items[j].TValue), "h") {
+				return true
+			}
+			break
+		}
+	}
+	for j := i + 1; j < len(items); j++ {
+		if items[j].TType == nfp.TokenTypeDateTimes || items[j].TType == nfp.TokenTypeElapsedDateTimes {
+			return strings.Contains(strings.ToLower(items[j].TValue), "s")
+		}
+	}
+	return false
+}
@@ -0,0 +1,479 @@
+package excelize
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteODS(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("My Sheet")
+	assert.NoError(t, err)
+	_, err = f.NewSheet("Hidden")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetVisible("Hidden", false))
+	for cell, value := range map[string]interface{}{
+		"A1": "  two  spaces\tand\nnew line <&>", "B1": 1.5, "C1": true,
+		"A2": 10, "B2": 20, "A3": 45306.5, "B3": 0.75, "C3": 0.5, "D3": 1234.5,
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellValue("My Sheet", "A1", 5))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "SUM(A2:B2)+'My Sheet'!A1&\"\"\"x\""))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "IF(C1,{1,2;3,4},_xlfn.CONCAT(A1,\"a\"))"))
+	for cell, numFmt := range map[string]int{"A3": 22, "B3": 21, "C3": 10, "D3": 4} {
+		styleID, err := f.NewStyle(&Style{NumFmt: numFmt})
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, styleID))
+	}
+	customNumFmt := "[h]:mm:ss.00"
+	styleID, err := f.NewStyle(&Style{CustomNumFmt: &customNumFmt})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "E3", 1.25))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "E3", "E3", styleID))
+	styleID, err = f.NewStyle(&Style{
+		Font:      &Font{Bold: true, Italic: true, Underline: "double", Strike: true, Color: "FF0000", Size: 14, Family: "Arial"},
+		Fill:      Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		Border:    []Border{{Type: "left", Style: 2, Color: "0000FF"}, {Type: "bottom", Style: 6}},
+		Alignment: &Alignment{Horizontal: "left", Indent: 2, Vertical: "center", WrapText: true, TextRotation: 135},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", styleID))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "F1", "F1", styleID))
+	assert.NoError(t, f.MergeCell("Sheet1", "A5", "B6"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "merged"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "D", false))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 3, 30))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 4, false))
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, f.WriteODS(buf))
+	assert.NoError(t, os.WriteFile(filepath.Join("test", "TestWriteODS.ods"), buf.Bytes(), 0o600))
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1", "My Sheet", "Hidden"}, f.GetSheetList())
+	for cell, expected := range map[string]string{
+		"A1": "  two  spaces\tand\nnew line <&>", "B1": "1.5", "C1": "TRUE", "C2": "",
+		"A3": "1/15/24 12:00", "B3": "18:00:00", "C3": "50.00%", "D3": "1,234.50", "A5": "merged",
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	val, err := f.GetCellValue("Sheet1", "E3", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.25", val)
+	formula, err := f.GetCellFormula("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A2:B2)+'My Sheet'!A1&\"\"\"x\"", formula)
+	formula, err = f.GetCellFormula("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.Equal(t, "IF(C1,{1,2;3,4},CONCAT(A1,\"a\"))", formula)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A5:B6", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	visible, err := f.GetColVisible("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	height, err := f.GetRowHeight("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	visible, err = f.GetRowVisible("Sheet1", 4)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	visible, err = f.GetSheetVisible("Hidden")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	// Test the round trip cell style
+	styleID, err = f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	styleSheet, err := f.stylesReader()
+	assert.NoError(t, err)
+	xf := styleSheet.CellXfs.Xf[styleID]
+	font := styleSheet.Fonts.Font[*xf.FontID]
+	assert.Equal(t, "Arial", *font.Name.Val)
+	assert.Equal(t, 14.0, *font.Sz.Val)
+	assert.Equal(t, "double", *font.U.Val)
+	assert.Equal(t, "FFFF0000", font.Color.RGB)
+	assert.NotNil(t, font.B)
+	assert.NotNil(t, font.I)
+	assert.NotNil(t, font.Strike)
+	assert.Equal(t, "FFFFFF00", styleSheet.Fills.Fill[*xf.FillID].PatternFill.FgColor.RGB)
+	border := styleSheet.Borders.Border[*xf.BorderID]
+	assert.Equal(t, "medium", border.Left.Style)
+	assert.Equal(t, "FF0000FF", border.Left.Color.RGB)
+	assert.Equal(t, "double", border.Bottom.Style)
+	assert.Equal(t, &xlsxAlignment{Horizontal: "left", Indent: 2, Vertical: "center", WrapText: true, TextRotation: 135}, xf.Alignment)
+	cellStyleID, err := f.GetCellStyle("Sheet1", "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, cellStyleID)
+
+	// Test write ODS keep the worksheets unmodified
+	file, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(file)
+	assert.NoError(t, err)
+	assert.NoError(t, f.WriteODS(buf))
+	for _, sheet := range f.GetSheetList() {
+		name, ok := f.getSheetXMLPath(sheet)
+		assert.True(t, ok)
+		assert.False(t, f.isPartModified(name), sheet)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test write ODS with unsupported charset workbook and styles
+	for _, path := range []string{defaultXMLPathStyles, defaultXMLPathWorkbook} {
+		f = NewFile()
+		f.Styles, f.WorkBook = nil, nil
+		f.Pkg.Store(path, MacintoshCyrillicCharset)
+		assert.EqualError(t, f.WriteODS(buf), "XML syntax error on line 1: invalid UTF-8")
+	}
+	// Test write ODS with unsupported charset worksheet and shared strings
+	for _, path := range []string{"xl/worksheets/sheet1.xml", defaultXMLPathSharedStrings} {
+		f = NewFile()
+		f.Sheet.Delete("xl/worksheets/sheet1.xml")
+		f.Pkg.Store(path, MacintoshCyrillicCharset)
+		assert.EqualError(t, f.WriteODS(buf), "XML syntax error on line 1: invalid UTF-8")
+	}
+}
+
+func TestOpenODS(t *testing.T) {
+	styles := `<office:document-styles xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0">
+<office:styles>
+<number:number-style style:name="N4"><number:number number:decimal-places="2" number:min-integer-digits="1" number:grouping="true"/></number:number-style>
+<style:style style:name="Default" style:family="table-cell"><style:text-properties style:font-name="Liberation Sans"/></style:style>
+<style:style style:name="Accent" style:family="table-cell" style:parent-style-name="Default"><style:text-properties fo:font-weight="bold" fo:color="#ffffff"/><style:table-cell-properties fo:background-color="#000000"/></style:style>
+</office:styles>
+</office:document-styles>`
+	content := `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" xmlns:calcext="urn:org:documentfoundation:names:experimental:calc:xmlns:calcext:1.0">
+<office:automatic-styles>
+<style:style style:name="co1" style:family="table-column"><style:table-column-properties style:column-width="2.258cm"/></style:style>
+<style:style style:name="ro1" style:family="table-row"><style:table-row-properties style:row-height="0.452cm" style:use-optimal-row-height="true"/></style:style>
+<style:style style:name="ro2" style:family="table-row"><style:table-row-properties style:row-height="0.5in" style:use-optimal-row-height="false"/></style:style>
+<style:style style:name="ta2" style:family="table"><style:table-properties table:display="false"/></style:style>
+<number:percentage-style style:name="N11"><number:number number:decimal-places="1" number:min-decimal-places="1" number:min-integer-digits="1"/><number:text>%</number:text></number:percentage-style>
+<number:date-style style:name="N37"><number:day-of-week number:style="long"/><number:text>, </number:text><number:month number:textual="true"/><number:text> </number:text><number:day number:style="long"/><number:text> "</number:text><number:year number:style="long"/></number:date-style>
+<number:time-style style:name="N46" number:truncate-on-overflow="false"><number:hours/><number:text>:</number:text><number:minutes number:style="long"/><number:text>:</number:text><number:seconds number:style="long" number:decimal-places="1"/></number:time-style>
+<number:number-style style:name="N50"><number:scientific-number number:decimal-places="2" number:min-integer-digits="1" number:min-exponent-digits="3"/></number:number-style>
+<number:number-style style:name="N51"><number:fraction number:min-integer-digits="0" number:min-numerator-digits="1" number:min-denominator-digits="2"/></number:number-style>
+<number:number-style style:name="N52"><number:number number:decimal-places="3" number:min-decimal-places="1"/><number:text>€</number:text></number:number-style>
+<number:text-style style:name="N53"><number:text-content/></number:text-style>
+<style:style style:name="ce1" style:family="table-cell" style:parent-style-name="Accent" style:data-style-name="N4"><style:table-cell-properties fo:border="0.06pt solid #ff0000" fo:border-top="2.5pt solid #00ff00" style:vertical-align="top" style:rotation-angle="300" fo:wrap-option="wrap" style:shrink-to-fit="true"/><style:paragraph-properties fo:text-align="end"/><style:text-properties fo:font-style="italic" style:text-underline-style="solid" style:text-line-through-style="solid" fo:font-size="12pt" fo:font-weight="700"/></style:style>
+<style:style style:name="ce2" style:family="table-cell" style:data-style-name="N11"/>
+<style:style style:name="ce3" style:family="table-cell" style:data-style-name="N37"/>
+<style:style style:name="ce4" style:family="table-cell" style:data-style-name="N46"/>
+<style:style style:name="ce5" style:family="table-cell" style:data-style-name="N50"><style:table-cell-properties fo:border-left="0.74pt dashed #0000ff" fo:border-right="2pt dashed" fo:border-bottom="0.74pt dotted" fo:border-top="2.49pt double"/><style:paragraph-properties fo:text-align="center"/></style:style>
+<style:style style:name="ce6" style:family="table-cell" style:data-style-name="N51"><style:paragraph-properties fo:text-align="start" fo:margin-left="13.5pt"/><style:table-cell-properties style:vertical-align="middle" style:rotation-angle="45"/></style:style>
+<style:style style:name="ce7" style:family="table-cell" style:data-style-name="N52"/>
+<style:style style:name="ce8" style:family="table-cell" style:data-style-name="N53"><style:table-cell-properties fo:background-color="transparent"/></style:style>
+<style:style style:name="ce9" style:family="table-cell" style:parent-style-name="Accent"/>
+</office:automatic-styles>
+<office:body><office:spreadsheet>
+<table:table table:name="Data">
+<table:table-column-group><table:table-column table:style-name="co1" table:number-columns-repeated="2"/></table:table-column-group>
+<table:table-column table:style-name="co1" table:visibility="collapse"/>
+<table:table-column table:style-name="co1" table:number-columns-repeated="1021"/>
+<table:table-header-rows>
+<table:table-row table:style-name="ro2">
+<table:table-cell table:style-name="ce1" office:value-type="float" office:value="1234.5"><text:p>1,234.50</text:p></table:table-cell>
+<table:table-cell office:value-type="string"><text:p>a<text:s text:c="2"/>b<text:tab/>c</text:p><text:p><text:span>d</text:span><text:line-break/>e<text:s/></text:p><office:annotation><text:p>note</text:p></office:annotation></table:table-cell>
+<table:table-cell table:formula="of:=SUM([.A1];[$'It''s'.$A$1:.B2];COM.MICROSOFT.CONCAT(&quot;[x];y&quot;);{1;2|3;4})" office:value-type="float" office:value="3"><text:p>3</text:p></table:table-cell>
+<table:table-cell table:formula="of:=1/0" office:value-type="float" office:value="0" calcext:value-type="error"><text:p>#DIV/0!</text:p></table:table-cell>
+<table:table-cell table:formula="of:=&quot;a&quot;&amp;&quot;b&quot;" office:value-type="string" office:string-value="ab"><text:p>ab</text:p></table:table-cell>
+<table:table-cell table:number-columns-repeated="1019"/>
+</table:table-row>
+</table:table-header-rows>
+<table:table-row table:style-name="ro1" table:visibility="collapse">
+<table:table-cell table:style-name="ce2" office:value-type="percentage" office:value="0.125"><text:p>12.5%</text:p></table:table-cell>
+<table:table-cell table:style-name="ce3" office:value-type="date" office:date-value="2024-01-15"><text:p>Monday</text:p></table:table-cell>
+<table:table-cell table:style-name="ce4" office:value-type="time" office:time-value="PT26H30M15.5S"><text:p>26:30:15.5</text:p></table:table-cell>
+<table:table-cell office:value-type="date" office:date-value="2024-01-15T12:00:00"><text:p>01/15/24 12:00</text:p></table:table-cell>
+<table:table-cell office:value-type="date" office:date-value="2024-01-15"><text:p>01/15/24</text:p></table:table-cell>
+<table:table-cell office:value-type="time" office:time-value="-PT12H"><text:p>-12:00:00</text:p></table:table-cell>
+<table:table-cell office:value-type="boolean" office:boolean-value="true"><text:p>TRUE</text:p></table:table-cell>
+<table:table-cell table:style-name="ce9" table:number-columns-repeated="2"/>
+<table:table-cell table:style-name="ce9" table:number-columns-repeated="1015"/>
+</table:table-row>
+<table:table-row-group>
+<table:table-row table:style-name="ro1" table:number-rows-repeated="2">
+<table:table-cell table:style-name="ce5" office:value-type="float" office:value="12345"/>
+<table:table-cell table:style-name="ce6" office:value-type="float" office:value="0.25"/>
+<table:table-cell table:style-name="ce7" office:value-type="currency" office:value="1.5"/>
+<table:table-cell table:style-name="ce8" office:value-type="string"><text:p>text</text:p></table:table-cell>
+</table:table-row>
+</table:table-row-group>
+<table:table-row table:style-name="ro1" table:number-rows-repeated="2"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>
+<table:table-row table:style-name="ro1">
+<table:table-cell table:number-columns-spanned="2" table:number-rows-spanned="2" office:value-type="string"><text:p>merged</text:p></table:table-cell>
+<table:covered-table-cell/>
+<table:table-cell table:number-columns-spanned="1" table:number-rows-spanned="1"/>
+</table:table-row>
+<table:table-row table:style-name="ro1"><table:covered-table-cell table:number-columns-repeated="2"/></table:table-row>
+<table:table-row table:style-name="ro1" table:number-rows-repeated="1048566"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>
+</table:table>
+<table:table table:name="It's" table:style-name="ta2"><table:table-row><table:table-cell office:value-type="float" office:value="1"/></table:table-row></table:table>
+<table:table table:name="Empty"/>
+</office:spreadsheet></office:body>
+</office:document-content>`
+	parts := map[string][]byte{
+		"mimetype":    []byte(ContentTypeODS),
+		"styles.xml":  []byte(styles),
+		"content.xml": []byte(content),
+	}
+	f, err := OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Data", "It's", "Empty"}, f.GetSheetList())
+	for cell, expected := range map[string]string{
+		"A1": "1,234.50", "B1": "a  b\tc\nd\ne ", "C1": "3", "D1": "#DIV/0!", "E1": "ab",
+		"A2": "12.5%", "B2": "Monday, Jan 15 \"2024", "D2": "1/15/24 12:00",
+		"E2": "01-15-24", "F2": "-0.5", "G2": "TRUE", "A3": "12345", "B3": "0.25",
+		"C3": "1.5€", "D3": "text", "A4": "12345", "A7": "merged",
+	} {
+		val, err := f.GetCellValue("Data", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	formula, err := f.GetCellFormula("Data", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A1,'It''s'!$A$1:B2,CONCAT(\"[x];y\"),{1,2;3,4})", formula)
+	formula, err = f.GetCellFormula("Data", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "\"a\"&\"b\"", formula)
+	mergeCells, err := f.GetMergeCells("Data")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A7", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "B8", mergeCells[0].GetEndAxis())
+	rows, err := f.GetRows("Data")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 7)
+	cols, err := f.GetCols("Data")
+	assert.NoError(t, err)
+	assert.Len(t, cols, 9)
+	width, err := f.GetColWidth("Data", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 11.48, width)
+	visible, err := f.GetColVisible("Data", "C")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	height, err := f.GetRowHeight("Data", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 36.0, height)
+	visible, err = f.GetRowVisible("Data", 2)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	visible, err = f.GetSheetVisible("It's")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	// Test the imported cell styles
+	styleSheet, err := f.stylesReader()
+	assert.NoError(t, err)
+	styleID, err := f.GetCellStyle("Data", "A1")
+	assert.NoError(t, err)
+	xf := styleSheet.CellXfs.Xf[styleID]
+	font := styleSheet.Fonts.Font[*xf.FontID]
+	assert.Equal(t, "Liberation Sans", *font.Name.Val)
+	assert.Equal(t, 12.0, *font.Sz.Val)
+	assert.Equal(t, "FFFFFFFF", font.Color.RGB)
+	assert.NotNil(t, font.B)
+	assert.NotNil(t, font.I)
+	assert.NotNil(t, font.U)
+	assert.NotNil(t, font.Strike)
+	assert.Equal(t, "FF000000", styleSheet.Fills.Fill[*xf.FillID].PatternFill.FgColor.RGB)
+	border := styleSheet.Borders.Border[*xf.BorderID]
+	assert.Equal(t, "thin", border.Left.Style)
+	assert.Equal(t, "thick", border.Top.Style)
+	assert.Equal(t, "FF00FF00", border.Top.Color.RGB)
+	assert.Equal(t, &xlsxAlignment{Horizontal: "right", Vertical: "top", TextRotation: 150, WrapText: true, ShrinkToFit: true}, xf.Alignment)
+	styleID, err = f.GetCellStyle("Data", "A3")
+	assert.NoError(t, err)
+	border = styleSheet.Borders.Border[*styleSheet.CellXfs.Xf[styleID].BorderID]
+	assert.Equal(t, []string{"dashed", "mediumDashed", "double", "dotted"},
+		[]string{border.Left.Style, border.Right.Style, border.Top.Style, border.Bottom.Style})
+	styleID, err = f.GetCellStyle("Data", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxAlignment{Horizontal: "left", Indent: 2, Vertical: "center", TextRotation: 45}, styleSheet.CellXfs.Xf[styleID].Alignment)
+	for cell, expected := range map[string]string{
+		"B2": "dddd\",\" mmm dd \\\"yyyy", "C2": "[h]:mm:ss.0", "A3": "0.00E+000",
+		"B3": "# ?/??", "C3": "#.0##\"€\"", "D3": "@",
+	} {
+		styleID, err = f.GetCellStyle("Data", cell)
+		assert.NoError(t, err)
+		numFmtID := *styleSheet.CellXfs.Xf[styleID].NumFmtID
+		for _, numFmt := range styleSheet.NumFmts.NumFmt {
+			if numFmt.NumFmtID == numFmtID {
+				assert.Equal(t, expected, numFmt.FormatCode, cell)
+			}
+		}
+	}
+	for _, cell := range []string{"H2", "I2"} {
+		styleID, err = f.GetCellStyle("Data", cell)
+		assert.NoError(t, err)
+		assert.NotZero(t, styleID)
+	}
+	// Test the trailing repeated cells without value collapsed into row format
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row[1].C, 9)
+	assert.True(t, ws.(*xlsxWorksheet).SheetData.Row[1].CustomFormat)
+	rowStyleID, err := f.GetCellStyle("Data", "J2")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, rowStyleID)
+
+	// Test open ODS without tables and content part
+	for _, content := range []string{
+		"<office:document-content/>", "",
+	} {
+		parts["content.xml"] = []byte(content)
+		if content == "" {
+			delete(parts, "content.xml")
+		}
+		_, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+		assert.Equal(t, ErrWorkbookFileFormat, err)
+	}
+	// Test open ODS with large repeated rows and cells without value
+	parts["content.xml"] = []byte(`<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0">
+<office:automatic-styles><style:style style:name="ce1" style:family="table-cell"><style:text-properties fo:font-weight="700"/></style:style></office:automatic-styles>
+<office:body><office:spreadsheet><table:table table:name="Sheet1">
+<table:table-row><table:table-cell table:style-name="ce1" table:number-columns-repeated="16384"/></table:table-row>
+<table:table-row table:number-rows-repeated="2"><table:table-cell office:value-type="float" office:value="1"/><table:table-cell table:style-name="ce1" table:number-columns-repeated="16383"/></table:table-row>
+<table:table-row table:number-rows-repeated="100000"><table:table-cell table:number-columns-repeated="16384"/></table:table-row>
+<table:table-row><table:table-cell office:value-type="float" office:value="2"/><table:table-cell table:number-columns-repeated="16383"/></table:table-row>
+<table:table-row table:number-rows-repeated="48573"><table:table-cell table:style-name="ce1" table:number-columns-repeated="16384"/></table:table-row>
+</table:table></office:spreadsheet></office:body></office:document-content>`)
+	f, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+	assert.NoError(t, err)
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 100004)
+	var cells int
+	for _, row := range ws.(*xlsxWorksheet).SheetData.Row {
+		cells += len(row.C)
+	}
+	assert.Equal(t, 3, cells)
+	for _, row := range []int{1, 2, 3} {
+		assert.True(t, ws.(*xlsxWorksheet).SheetData.Row[row-1].CustomFormat, row)
+	}
+	val, err := f.GetCellValue("Sheet1", "A100004")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", val)
+	// Test open ODS with invalid cell values
+	for _, cell := range []string{
+		`<table:table-cell office:value-type="float" office:value="x"/>`,
+		`<table:table-cell office:value-type="time" office:time-value="x"/>`,
+		`<table:table-cell office:value-type="time" office:time-value="PTxS"/>`,
+		`<table:table-cell office:value-type="time" office:time-value="P1Y"/>`,
+		`<table:table-cell office:value-type="time" office:time-value="PT1"/>`,
+	} {
+		parts["content.xml"] = []byte(`<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"><office:body><office:spreadsheet><table:table table:name="Sheet1"><table:table-row>` +
+			cell + `</table:table-row></table:table></office:spreadsheet></office:body></office:document-content>`)
+		_, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+		assert.Equal(t, ErrWorkbookFileFormat, err, cell)
+	}
+	// Test open ODS with the date values in different layouts
+	parts["content.xml"] = []byte(`<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"><office:body><office:spreadsheet><table:table table:name="Sheet1"><table:table-row>` +
+		`<table:table-cell office:value-type="date" office:date-value="2024-03-12T14:30:00.5"/>` +
+		`<table:table-cell office:value-type="date" office:date-value="2024-03-12T14:30:00Z"/>` +
+		`<table:table-cell office:value-type="date" office:date-value="2024-03-12T14:30:00.25+02:00"/>` +
+		`<table:table-cell office:value-type="date" office:date-value="2024-03-12-05:00"/>` +
+		`<table:table-cell office:value-type="date" office:date-value="x"><text:p>12/03/2024</text:p></table:table-cell>` +
+		`</table:table-row></table:table></office:spreadsheet></office:body></office:document-content>`)
+	f, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{
+		"A1": "45363.604172453706", "B1": "45363.604166666664", "C1": "45363.60416956018",
+		"D1": "45363", "E1": "12/03/2024",
+	} {
+		val, err := f.GetCellValue("Sheet1", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	assert.NoError(t, f.Close())
+	// Test open ODS with invalid table names
+	for _, tables := range []string{
+		`<table:table table:name="Sheet:1"/>`,
+		`<table:table table:name="Sheet1"/><table:table table:name="Sheet:2"/>`,
+	} {
+		parts["content.xml"] = []byte(`<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"><office:body><office:spreadsheet>` +
+			tables + `</office:spreadsheet></office:body></office:document-content>`)
+		_, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+		assert.Error(t, err, tables)
+	}
+	// Test open ODS with invalid XML
+	parts["content.xml"] = []byte(`<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"><office:body><office:spreadsheet><table:table table:name="Sheet1"><table:table-row>`)
+	_, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	parts["styles.xml"] = MacintoshCyrillicCharset
+	_, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test open ODS with exceeds the unzip size limit
+	_, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)), Options{UnzipSizeLimit: 1})
+	assert.EqualError(t, err, newUnzipSizeLimitError(1).Error())
+}
+
+func TestODSFormula(t *testing.T) {
+	for formula, expected := range map[string]string{
+		"of:=[.A1]+[.$B$2]":                  "A1+$B$2",
+		"of:=SUM([Sheet2.A1:.B2])":           "SUM(Sheet2!A1:B2)",
+		"of:=SUM([Sheet1.A1:Sheet3.A1])":     "SUM(Sheet1:Sheet3!A1)",
+		"of:=SUM(['My Sheet'.A:.A]~[.1:.2])": "SUM('My Sheet'!A:A,1:2)",
+		"of:=[.A1:.B2]![.B1:.C2]":            "A1:B2 B1:C2",
+		"oooc:=IF([.A1];\"a;b\";\"c\"\"\")":  "IF(A1,\"a;b\",\"c\"\"\")",
+		"of:=COM.MICROSOFT.CONCAT(\"a\";\"b": "CONCAT(\"a\",\"b",
+		"=[#REF!]":                           "#REF!",
+		"of:=[.A1":                           "A1",
+		"of:=LEGACY.NORMSDIST([.A1])+ORG.OPENOFFICE.ISLEAPYEAR([.B1])": "NORMSDIST(A1)+ORG.OPENOFFICE.ISLEAPYEAR(B1)",
+		"of:=com.microsoft.ifs(TRUE();1E+3;Legacy.tdist(1;2;3))":       "IFS(TRUE(),1E+3,TDIST(1,2,3))",
+		"of:=LEGACY.FDIST+TaxRate":                                     "LEGACY.FDIST+TaxRate",
+	} {
+		assert.Equal(t, expected, odsFormulaToExcel(formula), formula)
+	}
+	for formula, expected := range map[string]string{
+		"A1+$B$2":                        "of:=[.A1]+[.$B$2]",
+		"SUM(Sheet2!A1:B2)":              "of:=SUM([Sheet2.A1:.B2])",
+		"SUM(Sheet1:Sheet3!A1)":          "of:=SUM([Sheet1.A1:Sheet3.A1])",
+		"SUM('My.Sheet'!A:A,(1:2,C3))":   "of:=SUM(['My.Sheet'.A:.A];([.1:.2]~[.C3]))",
+		"TaxRate*Table1[Price]+TRUE":     "of:=TaxRate*Table1[Price]+TRUE()",
+		"_xlfn.XLOOKUP(A1,B:B,C:C)":      "of:=XLOOKUP([.A1];[.B:.B];[.C:.C])",
+		"Sheet1!A1:B2:C3":                "of:=Sheet1!A1:B2:C3",
+		"-A1%":                           "of:=-[.A1]%",
+		"NORMSDIST(A1)+_xlfn.CONCAT(B1)": "of:=LEGACY.NORMSDIST([.A1])+CONCAT([.B1])",
+	} {
+		assert.Equal(t, expected, excelFormulaToODS(formula), formula)
+	}
+}
+
+func TestODSDataStyle(t *testing.T) {
+	for code, expected := range map[string][2]string{
+		"General":      {"number-style", ""},
+		"":             {"number-style", ""},
+		"# ?/?":        {"number-style", ""},
+		"\"x\"General": {"number-style", `<number:text>x</number:text><number:number number:min-integer-digits="1"/>`},
+		"[Red]0.0E+00": {"number-style", `<number:scientific-number number:decimal-places="1" number:min-decimal-places="1" number:min-integer-digits="1" number:min-exponent-digits="2"/>`},
+		"0%":           {"percentage-style", `<number:number number:decimal-places="0" number:min-decimal-places="0" number:min-integer-digits="1"/><number:text>%</number:text>`},
+		"@\" <&>\"":    {"text-style", `<number:text-content/><number:text> &lt;&amp;&gt;</number:text>`},
+		"mmmm yy":      {"date-style", `<number:month number:textual="true" number:style="long"/><number:text> </number:text><number:year/>`},
+		"ddd m/d":      {"date-style", `<number:day-of-week/><number:text> </number:text><number:month/><number:text>/</number:text><number:day/>`},
+		"mm:ss A/P":    {"time-style", `<number:minutes number:style="long"/><number:text>:</number:text><number:seconds number:style="long"/><number:text> </number:text><number:am-pm/>`},
+		"[mm]:ss.000":  {"time-style", `<number:minutes number:style="long"/><number:text>:</number:text><number:seconds number:style="long" number:decimal-places="3"/>`},
+	} {
+		kind, elements, _ := getODSDataStyleElements(code)
+		assert.Equal(t, expected, [2]string{kind, elements}, code)
+	}
+	cellProps, paraProps := getODSAlignmentProps(&xlsxAlignment{Horizontal: "right", Vertical: "top", ShrinkToFit: true, TextRotation: 45})
+	assert.Equal(t, []string{`style:vertical-align="top"`, `style:shrink-to-fit="true"`, `style:rotation-angle="45"`}, cellProps)
+	assert.Equal(t, []string{`fo:text-align="end"`}, paraProps)
+	for horizontal, expected := range map[string]string{"centerContinuous": "center", "justify": "justify"} {
+		_, paraProps = getODSAlignmentProps(&xlsxAlignment{Horizontal: horizontal})
+		assert.Equal(t, []string{`fo:text-align="` + expected + `"`}, paraProps)
+	}
+}
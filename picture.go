@@ -19,6 +19,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -399,6 +400,7 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	content.mu.Lock()
 	defer content.mu.Unlock()
 	content.TwoCellAnchor = append(content.TwoCellAnchor, &twoCellAnchor)
+	f.setPartModified(drawingXML)
 	f.Drawings.Store(drawingXML, content)
 	return err
 }
@@ -457,7 +459,14 @@ func (f *File) setContentTypePartImageExtensions() error {
 	for _, file := range content.Defaults {
 		delete(imageTypes, file.Extension)
 	}
-	for extension, prefix := range imageTypes {
+	extensions := make([]string, 0, len(imageTypes))
+	for extension := range imageTypes {
+		extensions = append(extensions, extension)
+	}
+	sort.Strings(extensions)
+	for _, extension := range extensions {
+		prefix := imageTypes[extension]
+		f.setPartModified(defaultXMLPathContentTypes)
 		content.Defaults = append(content.Defaults, xlsxDefault{
 			Extension:   extension,
 			ContentType: prefix + extension,
@@ -482,6 +491,7 @@ func (f *File) setContentTypePartVMLExtensions() error {
 		}
 	}
 	if !vml {
+		f.setPartModified(defaultXMLPathContentTypes)
 		content.Defaults = append(content.Defaults, xlsxDefault{
 			Extension:   "vml",
 			ContentType: ContentTypeVML,
@@ -534,6 +544,7 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 			return err
 		}
 	}
+	f.setPartModified(defaultXMLPathContentTypes)
 	content.Overrides = append(content.Overrides, xlsxOverride{
 		PartName:    partNames[contentType],
 		ContentType: contentTypes[contentType],
@@ -550,7 +561,7 @@ func (f *File) getSheetRelationshipsTargetByID(sheet, rID string) string {
 		name = strings.ToLower(sheet) + ".xml"
 	}
 	rels := "xl/worksheets/_rels/" + strings.TrimPrefix(name, "xl/worksheets/") + ".rels"
-	sheetRels, _ := f.relsReader(rels)
+	sheetRels, _ := f.loadRels(rels)
 	if sheetRels == nil {
 		sheetRels = &xlsxRelationships{}
 	}
@@ -597,7 +608,7 @@ func (f *File) GetPictures(sheet, cell string) ([]Picture, error) {
 	col--
 	row--
 	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		f.mu.Unlock()
 		return nil, err
@@ -716,7 +727,7 @@ func (f *File) getPicturesFromWsDr(row, col int, drawingRelationships string, ws
 // from xl/drawings/_rels/drawing%s.xml.rels by given file name and
 // relationship ID.
 func (f *File) getDrawingRelationships(rels, rID string) *xlsxRelationship {
-	if drawingRels, _ := f.relsReader(rels); drawingRels != nil {
+	if drawingRels, _ := f.loadRels(rels); drawingRels != nil {
 		drawingRels.mu.Lock()
 		defer drawingRels.mu.Unlock()
 		for _, v := range drawingRels.Relationships {
@@ -732,7 +743,7 @@ func (f *File) getDrawingRelationships(rels, rID string) *xlsxRelationship {
 // serialize structure.
 func (f *File) drawingsWriter() {
 	f.Drawings.Range(func(path, d interface{}) bool {
-		if d != nil {
+		if d != nil && f.isPartModified(path.(string)) {
 			v, _ := xml.Marshal(d.(*xlsxWsDr))
 			f.saveFileList(path.(string), v)
 		}
@@ -20,7 +20,8 @@ import (
 
 // PivotTableOptions directly maps the format settings of the pivot table.
 //
-// PivotTableStyleName: The built-in pivot table style names
+// PivotTableStyleName: The built-in pivot table style names, or the name of
+// the custom table style created by the AddTableStyle function
 //
 //	PivotStyleLight1 - PivotStyleLight28
 //	PivotStyleMedium1 - PivotStyleMedium28
@@ -190,7 +191,7 @@ func (f *File) parseFormatPivotTableSet(opts *PivotTableOptions) (*xlsxWorksheet
 	if err != nil {
 		return nil, "", fmt.Errorf("parameter 'DataRange' parsing error: %s", err.Error())
 	}
-	dataSheet, err := f.workSheetReader(dataSheetName)
+	dataSheet, _, err := f.loadWorkSheet(dataSheetName)
 	if err != nil {
 		return dataSheet, "", err
 	}
@@ -267,7 +267,7 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 	if !ok {
 		return nil, ErrSheetNotExist{sheet}
 	}
-	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
+	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil && f.isPartModified(name) {
 		ws := worksheet.(*xlsxWorksheet)
 		ws.mu.Lock()
 		defer ws.mu.Unlock()
@@ -373,7 +373,7 @@ func (f *File) SetRowHeight(sheet string, row int, height float64) error {
 // getRowHeight provides a function to get row height in pixels by given sheet
 // name and row number.
 func (f *File) getRowHeight(sheet string, row int) int {
-	ws, _ := f.workSheetReader(sheet)
+	ws, _, _ := f.loadWorkSheet(sheet)
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 	for i := range ws.SheetData.Row {
@@ -398,7 +398,7 @@ func (f *File) GetRowHeight(sheet string, row int) (float64, error) {
 		return defaultRowHeight, newInvalidRowNumberError(row)
 	}
 	ht := defaultRowHeight
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return ht, err
 	}
@@ -420,9 +420,16 @@ func (f *File) GetRowHeight(sheet string, row int) (float64, error) {
 // sharedStringsReader provides a function to get the pointer to the structure
 // after deserialization of xl/sharedStrings.xml.
 func (f *File) sharedStringsReader() (*xlsxSST, error) {
-	var err error
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	return f.loadSharedStrings()
+}
+
+// loadSharedStrings provides a function to get the pointer to the structure
+// after deserialization of xl/sharedStrings.xml, the caller should hold the
+// lock of the file.
+func (f *File) loadSharedStrings() (*xlsxSST, error) {
+	var err error
 	relPath := f.getWorkbookRelsPath()
 	if f.SharedStrings == nil {
 		var sharedStrings xlsxSST
@@ -446,12 +453,12 @@ func (f *File) sharedStringsReader() (*xlsxSST, error) {
 		if err = f.addContentTypePart(0, "sharedStrings"); err != nil {
 			return f.SharedStrings, err
 		}
-		rels, err := f.relsReader(relPath)
+		rels, err := f.loadRels(relPath)
 		if err != nil {
 			return f.SharedStrings, err
 		}
 		for _, rel := range rels.Relationships {
-			if rel.Target == "/xl/sharedStrings.xml" {
+			if rel.Type == SourceRelationshipSharedStrings {
 				return f.SharedStrings, nil
 			}
 		}
@@ -490,7 +497,7 @@ func (f *File) GetRowVisible(sheet string, row int) (bool, error) {
 		return false, newInvalidRowNumberError(row)
 	}
 
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return false, err
 	}
@@ -530,7 +537,7 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 	if row < 1 {
 		return 0, newInvalidRowNumberError(row)
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return 0, err
 	}
@@ -815,7 +822,7 @@ func (f *File) SetRowStyle(sheet string, start, end, styleID int) error {
 	if end > TotalRows {
 		return ErrMaxRows
 	}
-	s, err := f.stylesReader()
+	s, err := f.loadStyles()
 	if err != nil {
 		return err
 	}
@@ -463,6 +463,7 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 		FPrintsWithSheet: *opts.Format.PrintObject,
 	}
 	content.TwoCellAnchor = append(content.TwoCellAnchor, &twoCellAnchor)
+	f.setPartModified(drawingXML)
 	f.Drawings.Store(drawingXML, content)
 	return err
 }
@@ -83,7 +83,7 @@ func (f *File) contentTypesReader() (*xlsxTypes, error) {
 // contentTypesWriter provides a function to save [Content_Types].xml after
 // serialize structure.
 func (f *File) contentTypesWriter() {
-	if f.ContentTypes != nil {
+	if f.ContentTypes != nil && f.isPartModified(defaultXMLPathContentTypes) {
 		output, _ := xml.Marshal(f.ContentTypes)
 		f.saveFileList(defaultXMLPathContentTypes, output)
 	}
@@ -136,45 +136,90 @@ func (f *File) mergeExpandedCols(ws *xlsxWorksheet) {
 // workSheetWriter provides a function to save xl/worksheets/sheet%d.xml after
 // serialize structure.
 func (f *File) workSheetWriter() {
-	var (
-		arr     []byte
-		buffer  = bytes.NewBuffer(arr)
-		encoder = xml.NewEncoder(buffer)
-	)
+	buffer := bytes.NewBuffer(nil)
 	f.Sheet.Range(func(p, ws interface{}) bool {
 		if ws != nil {
-			sheet := ws.(*xlsxWorksheet)
-			if sheet.MergeCells != nil && len(sheet.MergeCells.Cells) > 0 {
-				_ = f.mergeOverlapCells(sheet)
-			}
-			if sheet.Cols != nil && len(sheet.Cols.Col) > 0 {
-				f.mergeExpandedCols(sheet)
-			}
-			sheet.SheetData.Row = trimRow(&sheet.SheetData)
-			if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
-				f.addNameSpaces(p.(string), SourceRelationship)
-			}
-			if sheet.DecodeAlternateContent != nil {
-				sheet.AlternateContent = &xlsxAlternateContent{
-					Content: sheet.DecodeAlternateContent.Content,
-					XMLNSMC: SourceRelationshipCompatibility.Value,
-				}
-			}
-			sheet.DecodeAlternateContent = nil
-			// reusing buffer
-			_ = encoder.Encode(sheet)
-			f.saveFileList(p.(string), replaceRelationshipsBytes(f.replaceNameSpaceBytes(p.(string), buffer.Bytes())))
-			ok := f.checked[p.(string)]
-			if ok {
-				f.Sheet.Delete(p.(string))
-				f.checked[p.(string)] = false
-			}
+			f.saveWorkSheet(p.(string), ws.(*xlsxWorksheet), buffer)
 			buffer.Reset()
 		}
 		return true
 	})
 }
 
+// saveWorkSheet provides a function to serialize the worksheet and save it
+// into the package by given worksheet XML path, the buffer will be used for
+// the encoding. The original content of the unmodified worksheet will be kept.
+func (f *File) saveWorkSheet(path string, sheet *xlsxWorksheet, buffer *bytes.Buffer) {
+	if f.isPartModified(path) {
+		f.prepareWorkSheet(path, sheet)
+		_ = xml.NewEncoder(buffer).Encode(sheet)
+		f.saveFileList(path, replaceRelationshipsBytes(f.replaceNameSpaceBytes(path, buffer.Bytes())))
+	}
+	if ok := f.checked[path]; ok {
+		f.Sheet.Delete(path)
+		f.checked[path] = false
+	}
+}
+
+// writeWorkSheet provides a function to serialize the worksheet into the
+// given writer by given worksheet XML path. The output is the same as saved
+// by the saveWorkSheet function, but the rows will be encoded and written in
+// chunks without buffering the whole worksheet in memory.
+func (f *File) writeWorkSheet(w io.Writer, path string, sheet *xlsxWorksheet) error {
+	f.prepareWorkSheet(path, sheet)
+	buffer, rows := bytes.NewBuffer(nil), sheet.SheetData.Row
+	sheet.SheetData.Row = nil
+	err := xml.NewEncoder(buffer).Encode(sheet)
+	sheet.SheetData.Row = rows
+	if err != nil {
+		return err
+	}
+	content := replaceRelationshipsBytes(f.replaceNameSpaceBytes(path, buffer.Bytes()))
+	idx := bytes.Index(content, []byte(`<sheetData></sheetData>`)) + len(`<sheetData>`)
+	if _, err = io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	if _, err = w.Write(content[:idx]); err != nil {
+		return err
+	}
+	rowBuffer := bytes.NewBuffer(nil)
+	enc := xml.NewEncoder(rowBuffer)
+	for i := range rows {
+		if err = enc.EncodeElement(&rows[i], xml.StartElement{Name: xml.Name{Local: "row"}}); err != nil {
+			return err
+		}
+		if rowBuffer.Len() >= StreamChunkSize || i == len(rows)-1 {
+			if _, err = rowBuffer.WriteTo(w); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = w.Write(content[idx:])
+	return err
+}
+
+// prepareWorkSheet provides a function to prepare the worksheet for
+// serialization by given worksheet XML path.
+func (f *File) prepareWorkSheet(path string, sheet *xlsxWorksheet) {
+	if sheet.MergeCells != nil && len(sheet.MergeCells.Cells) > 0 {
+		_ = f.mergeOverlapCells(sheet)
+	}
+	if sheet.Cols != nil && len(sheet.Cols.Col) > 0 {
+		f.mergeExpandedCols(sheet)
+	}
+	sheet.SheetData.Row = trimRow(&sheet.SheetData)
+	if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
+		f.addNameSpaces(path, SourceRelationship)
+	}
+	if sheet.DecodeAlternateContent != nil {
+		sheet.AlternateContent = &xlsxAlternateContent{
+			Content: sheet.DecodeAlternateContent.Content,
+			XMLNSMC: SourceRelationshipCompatibility.Value,
+		}
+	}
+	sheet.DecodeAlternateContent = nil
+}
+
 // trimRow provides a function to trim empty rows.
 func trimRow(sheetData *xlsxSheetData) []xlsxRow {
 	var (
@@ -219,6 +264,7 @@ func (f *File) setContentTypes(partName, contentType string) error {
 	}
 	content.mu.Lock()
 	defer content.mu.Unlock()
+	f.setPartModified(defaultXMLPathContentTypes)
 	content.Overrides = append(content.Overrides, xlsxOverride{
 		PartName:    partName,
 		ContentType: contentType,
@@ -237,6 +283,7 @@ func (f *File) setSheet(index int, name string) {
 	sheetXMLPath := "xl/worksheets/sheet" + strconv.Itoa(index) + ".xml"
 	f.sheetMap[name] = sheetXMLPath
 	f.Sheet.Store(sheetXMLPath, &ws)
+	f.setPartModified(sheetXMLPath)
 	f.xmlAttr[sheetXMLPath] = []xml.Attr{NameSpaceSpreadSheet}
 }
 
@@ -244,7 +291,7 @@ func (f *File) setSheet(index int, name string) {
 // serialize structure.
 func (f *File) relsWriter() {
 	f.Relationships.Range(func(path, rel interface{}) bool {
-		if rel != nil {
+		if rel != nil && f.isPartModified(path.(string)) {
 			output, _ := xml.Marshal(rel.(*xlsxRelationships))
 			if strings.HasPrefix(path.(string), "xl/worksheets/sheet/rels/sheet") {
 				output = f.replaceNameSpaceBytes(path.(string), output)
@@ -317,7 +364,7 @@ func (f *File) SetActiveSheet(index int) {
 // spreadsheet. If not found the active sheet will be return integer 0.
 func (f *File) GetActiveSheetIndex() (index int) {
 	sheetID := f.getActiveSheetID()
-	wb, _ := f.workbookReader()
+	wb, _ := f.loadWorkbook()
 	if wb != nil {
 		for idx, sheet := range wb.Sheets.Sheet {
 			if sheet.SheetID == sheetID {
@@ -332,7 +379,7 @@ func (f *File) GetActiveSheetIndex() (index int) {
 // getActiveSheetID provides a function to get active sheet ID of the
 // spreadsheet. If not found the active sheet will be return integer 0.
 func (f *File) getActiveSheetID() int {
-	wb, _ := f.workbookReader()
+	wb, _ := f.loadWorkbook()
 	if wb != nil {
 		if wb.BookViews != nil && len(wb.BookViews.WorkBookView) > 0 {
 			activeTab := wb.BookViews.WorkBookView[0].ActiveTab
@@ -430,7 +477,7 @@ func (f *File) GetSheetIndex(sheet string) (int, error) {
 //	    fmt.Println(index, name)
 //	}
 func (f *File) GetSheetMap() map[int]string {
-	wb, _ := f.workbookReader()
+	wb, _ := f.loadWorkbook()
 	sheetMap := map[int]string{}
 	if wb != nil {
 		for _, sheet := range wb.Sheets.Sheet {
@@ -443,7 +490,7 @@ func (f *File) GetSheetMap() map[int]string {
 // GetSheetList provides a function to get worksheets, chart sheets, and
 // dialog sheets name list of the workbook.
 func (f *File) GetSheetList() (list []string) {
-	wb, _ := f.workbookReader()
+	wb, _ := f.loadWorkbook()
 	if wb != nil {
 		for _, sheet := range wb.Sheets.Sheet {
 			list = append(list, sheet.Name)
@@ -456,11 +503,11 @@ func (f *File) GetSheetList() (list []string) {
 // of the spreadsheet.
 func (f *File) getSheetMap() (map[string]string, error) {
 	maps := map[string]string{}
-	wb, err := f.workbookReader()
+	wb, err := f.loadWorkbook()
 	if err != nil {
 		return nil, err
 	}
-	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	rels, err := f.loadRels(f.getWorkbookRelsPath())
 	if err != nil {
 		return nil, err
 	}
@@ -640,6 +687,7 @@ func (f *File) deleteSheetFromContentTypes(target string) error {
 	defer content.mu.Unlock()
 	for k, v := range content.Overrides {
 		if v.PartName == target {
+			f.setPartModified(defaultXMLPathContentTypes)
 			content.Overrides = append(content.Overrides[:k], content.Overrides[k+1:]...)
 		}
 	}
@@ -668,7 +716,7 @@ func (f *File) CopySheet(from, to int) error {
 // target worksheet name.
 func (f *File) copySheet(from, to int) error {
 	fromSheet := f.GetSheetName(from)
-	sheet, err := f.workSheetReader(fromSheet)
+	sheet, _, err := f.loadWorkSheet(fromSheet)
 	if err != nil {
 		return err
 	}
@@ -682,6 +730,7 @@ func (f *File) copySheet(from, to int) error {
 	worksheet.TableParts = nil
 	worksheet.PageSetUp = nil
 	f.Sheet.Store(sheetXMLPath, worksheet)
+	f.setPartModified(sheetXMLPath)
 	toRels := "xl/worksheets/_rels/sheet" + toSheetID + ".xml.rels"
 	fromRels := "xl/worksheets/_rels/sheet" + strconv.Itoa(f.getSheetID(fromSheet)) + ".xml.rels"
 	if rels, ok := f.Pkg.Load(fromRels); ok && rels != nil {
@@ -945,7 +994,7 @@ func (ws *xlsxWorksheet) getPanes() Panes {
 // views by given worksheet name.
 func (f *File) GetPanes(sheet string) (Panes, error) {
 	var panes Panes
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return panes, err
 	}
@@ -961,7 +1010,7 @@ func (f *File) GetSheetVisible(sheet string) (bool, error) {
 	if err := checkSheetName(sheet); err != nil {
 		return visible, err
 	}
-	wb, _ := f.workbookReader()
+	wb, _ := f.loadWorkbook()
 	for k, v := range wb.Sheets.Sheet {
 		if strings.EqualFold(v.Name, sheet) {
 			if wb.Sheets.Sheet[k].State == "" || wb.Sheets.Sheet[k].State == "visible" {
@@ -1001,7 +1050,7 @@ func (f *File) SearchSheet(sheet, value string, reg ...bool) ([]string, error) {
 	if !ok {
 		return result, ErrSheetNotExist{sheet}
 	}
-	if ws, ok := f.Sheet.Load(name); ok && ws != nil {
+	if ws, ok := f.Sheet.Load(name); ok && ws != nil && f.isPartModified(name) {
 		// Flush data
 		output, _ := xml.Marshal(ws.(*xlsxWorksheet))
 		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
@@ -1553,7 +1602,7 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 		FirstPageNumber: uintPtr(1),
 		AdjustTo:        uintPtr(100),
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return opts, err
 	}
@@ -1667,7 +1716,7 @@ func (f *File) DeleteDefinedName(definedName *DefinedName) error {
 // or worksheet.
 func (f *File) GetDefinedName() []DefinedName {
 	var definedNames []DefinedName
-	wb, _ := f.workbookReader()
+	wb, _ := f.loadWorkbook()
 	if wb.DefinedNames != nil {
 		for _, dn := range wb.DefinedNames.DefinedName {
 			definedName := DefinedName{
@@ -1864,8 +1913,18 @@ func (f *File) RemovePageBreak(sheet, cell string) error {
 }
 
 // relsReader provides a function to get the pointer to the structure
-// after deserialization of xl/worksheets/_rels/sheet%d.xml.rels.
+// after deserialization of xl/worksheets/_rels/sheet%d.xml.rels, and mark the
+// relationships part as modified.
 func (f *File) relsReader(path string) (*xlsxRelationships, error) {
+	rels, err := f.loadRels(path)
+	f.setPartModified(path)
+	return rels, err
+}
+
+// loadRels provides a function to get the pointer to the structure after
+// deserialization of the relationships part by given path without marking the
+// part as modified.
+func (f *File) loadRels(path string) (*xlsxRelationships, error) {
 	rels, _ := f.Relationships.Load(path)
 	if rels == nil {
 		if _, ok := f.Pkg.Load(path); ok {
@@ -1966,7 +2025,7 @@ func (f *File) SetSheetDimension(sheet string, rangeRef string) error {
 // GetSheetDimension provides the method to get the used range of the worksheet.
 func (f *File) GetSheetDimension(sheet string) (string, error) {
 	var ref string
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return ref, err
 	}
@@ -61,7 +61,7 @@ func (f *File) GetPageMargins(sheet string) (PageLayoutMarginsOptions, error) {
 		Right:  float64Ptr(0.7),
 		Top:    float64Ptr(0.75),
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return opts, err
 	}
@@ -188,7 +188,7 @@ func (f *File) GetSheetProps(sheet string) (SheetPropsOptions, error) {
 		OutlineSummaryBelow:               boolPtr(true),
 		BaseColWidth:                      &baseColWidth,
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return opts, err
 	}
@@ -209,7 +209,7 @@ func (f *File) GetSheetProps(sheet string) (SheetPropsOptions, error) {
 			opts.OutlineSummaryRight = ws.SheetPr.OutlinePr.SummaryRight
 		}
 		if ws.SheetPr.TabColor != nil {
-			opts.TabColorIndexed = intPtr(ws.SheetPr.TabColor.Indexed)
+			opts.TabColorIndexed = ws.SheetPr.TabColor.Indexed
 			opts.TabColorRGB = stringPtr(ws.SheetPr.TabColor.RGB)
 			opts.TabColorTheme = ws.SheetPr.TabColor.Theme
 			opts.TabColorTint = float64Ptr(ws.SheetPr.TabColor.Tint)
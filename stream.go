@@ -29,13 +29,15 @@ type StreamWriter struct {
 	Sheet           string
 	SheetID         int
 	sheetWritten    bool
-	cols            strings.Builder
 	worksheet       *xlsxWorksheet
 	rawData         bufferedWriter
 	rows            int
 	mergeCellsCount int
 	mergeCells      strings.Builder
-	tableParts      string
+	existingRows    io.Reader
+	existingFile    *os.File
+	colWidths       map[int]float64
+	header          bufferedWriter
 }
 
 // NewStreamWriter returns stream writer struct by given worksheet name used for
@@ -46,8 +48,14 @@ type StreamWriter struct {
 // mode functions and stream mode functions can not be work mixed to writing
 // data on the worksheets. The stream writer will try to use temporary files on
 // disk to reduce the memory usage when in-memory chunks data over 16MB, and
-// you can't get cell value at this time. For example, set data for worksheet
-// of size 102400 rows x 50 columns with numbers and style:
+// you can't get cell value at this time. Use the NewStreamAppender function
+// to append rows to a worksheet with existing data. The NewStreamWriter, SetRow
+// and Flush functions of the stream writers for different worksheets can be
+// called concurrently in separate goroutines. The other functions of the
+// stream writer, such as AddTable, AddComment, AddPicture, AddChart,
+// SetPageLayout and AutoFilter, and the methods of a single stream writer are
+// not safe for concurrent use. For example, set data for
+// worksheet of size 102400 rows x 50 columns with numbers and style:
 //
 //	f := excelize.NewFile()
 //	defer func() {
@@ -116,6 +124,8 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 	if err := checkSheetName(sheet); err != nil {
 		return nil, err
 	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	sheetID := f.getSheetID(sheet)
 	if sheetID == -1 {
 		return nil, newNoExistSheetError(sheet)
@@ -138,10 +148,210 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 	f.streams[sheetXMLPath] = sw
 
 	_, _ = sw.rawData.WriteString(xml.Header + `<worksheet` + templateNamespaceIDMap)
-	bulkAppendFields(&sw.rawData, sw.worksheet, 2, 3)
 	return sw, err
 }
 
+// NewStreamAppender returns stream writer struct by given worksheet name used
+// for appending rows after the last row of an existing worksheet with large
+// amounts of data. The existing rows will be copied through to the stream
+// without loading them into memory, and the other elements of the worksheet,
+// such as columns, merged cells and conditional formats will be kept. Note
+// that the row number of the appended rows must be greater than the last row
+// of the worksheet, and you must call the 'Flush' method to end the streaming
+// writing process. For example, append a row after the existing rows of the
+// worksheet named Sheet1:
+//
+//	f, err := excelize.OpenFile("Book1.xlsx")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	sw, err := f.NewStreamAppender("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	cell, err := excelize.CoordinatesToCellName(1, sw.LastRow()+1)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := sw.SetRow(cell, []interface{}{"Appended", 1}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := sw.Flush(); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.Save(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) NewStreamAppender(sheet string) (*StreamWriter, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sheetID := f.getSheetID(sheet)
+	if sheetID == -1 {
+		return nil, newNoExistSheetError(sheet)
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	if !strings.HasPrefix(sheetXMLPath, "xl/worksheets/") {
+		return nil, newNotWorksheetError(sheet)
+	}
+	if ws, ok := f.Sheet.Load(sheetXMLPath); ok && ws != nil {
+		f.saveWorkSheet(sheetXMLPath, ws.(*xlsxWorksheet), bytes.NewBuffer(nil))
+		f.Sheet.Delete(sheetXMLPath)
+		delete(f.checked, sheetXMLPath)
+	}
+	sw := &StreamWriter{
+		file:    f,
+		Sheet:   sheet,
+		SheetID: sheetID,
+	}
+	if err := sw.readExistingSheet(sheetXMLPath); err != nil {
+		return nil, err
+	}
+	f.Sheet.Store(sheetXMLPath, sw.worksheet)
+	if f.streams == nil {
+		f.streams = make(map[string]*StreamWriter)
+	}
+	f.streams[sheetXMLPath] = sw
+
+	_, _ = sw.rawData.Write(f.replaceNameSpaceBytes(sheetXMLPath,
+		[]byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)))
+	return sw, nil
+}
+
+// LastRow returns the number of the last row written by the StreamWriter, or
+// the last row of the existing worksheet for the stream appender.
+func (sw *StreamWriter) LastRow() int {
+	return sw.rows
+}
+
+// readExistingSheet provides a function to read the worksheet elements except
+// the sheet data by given worksheet XML path, and prepare the reader of the
+// existing rows for the stream appender. The attributes of the root element
+// will be preserved, and the dimension will be removed because the used range
+// of the worksheet will be changed after appending rows.
+func (sw *StreamWriter) readExistingSheet(path string) (err error) {
+	var (
+		src  io.ReaderAt = bytes.NewReader(nil)
+		size int64
+	)
+	defer func() {
+		if err != nil && sw.existingFile != nil {
+			_ = sw.existingFile.Close()
+			sw.existingFile = nil
+		}
+	}()
+	if content, ok := sw.file.Pkg.Load(path); ok && content != nil {
+		src, size = bytes.NewReader(content.([]byte)), int64(len(content.([]byte)))
+	} else if tempFile, ok := sw.file.tempFiles.Load(path); ok {
+		file, err := os.Open(tempFile.(string))
+		if err != nil {
+			return err
+		}
+		fi, err := file.Stat()
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+		sw.existingFile, src, size = file, file, fi.Size()
+	}
+	offsets, lastRow, err := sw.file.getSheetDataOffsets(io.NewSectionReader(src, 0, size))
+	content := make([]byte, offsets[0]+size-offsets[3])
+	if err == nil {
+		if _, err = src.ReadAt(content[:offsets[0]], 0); err == io.EOF {
+			err = nil
+		}
+	}
+	if err == nil {
+		if _, err = src.ReadAt(content[offsets[0]:], offsets[3]); err == io.EOF {
+			err = nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+	sw.worksheet = new(xlsxWorksheet)
+	if len(content) > 0 {
+		d := sw.file.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content)))
+		sw.file.xmlAttr[path] = getRootElement(d)
+		if err = sw.file.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+			Decode(sw.worksheet); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	sw.worksheet.Dimension = nil
+	if sw.worksheet.MergeCells != nil {
+		for _, mergeCell := range sw.worksheet.MergeCells.Cells {
+			sw.mergeCellsCount++
+			_, _ = sw.mergeCells.WriteString(`<mergeCell ref="`)
+			_, _ = sw.mergeCells.WriteString(mergeCell.Ref)
+			_, _ = sw.mergeCells.WriteString(`"/>`)
+		}
+		sw.worksheet.MergeCells = nil
+	}
+	sw.rows, sw.existingRows = lastRow, io.NewSectionReader(src, offsets[1], offsets[2]-offsets[1])
+	return nil
+}
+
+// getSheetDataOffsets provides a function to get the offsets of the sheetData
+// start element, the beginning and ending of the rows, and the end of the
+// sheetData element in the worksheet XML, and the number of the last row.
+func (f *File) getSheetDataOffsets(r io.Reader) ([4]int64, int, error) {
+	var (
+		offsets [4]int64
+		lastRow int
+		depth   int
+		found   bool
+		decoder = f.xmlNewDecoder(r)
+	)
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return offsets, lastRow, err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 2 && element.Name.Local == "sheetData" {
+				offsets[0], offsets[1], found = offset, decoder.InputOffset(), true
+			}
+			if depth == 3 && element.Name.Local == "row" {
+				lastRow++
+				for _, attr := range element.Attr {
+					if attr.Name.Local == "r" {
+						if row, err := strconv.Atoi(attr.Value); err == nil {
+							lastRow = row
+						}
+					}
+				}
+				if err = decoder.Skip(); err != nil {
+					return offsets, lastRow, err
+				}
+				depth--
+			}
+		case xml.EndElement:
+			if depth == 2 && element.Name.Local == "sheetData" {
+				offsets[2], offsets[3] = offset, decoder.InputOffset()
+			}
+			if depth == 1 && !found {
+				offsets = [4]int64{offset, offset, offset, offset}
+			}
+			depth--
+		}
+	}
+	return offsets, lastRow, nil
+}
+
 // AddTable creates an Excel table for the StreamWriter using the given
 // cell range and format set. For example, create a table of A1:D5:
 //
@@ -163,8 +373,7 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 // Note that the table must be at least two lines including the header. The
 // header cells must contain strings and must be unique.
 //
-// Currently, only one table is allowed for a StreamWriter. AddTable must be
-// called after the rows are written but before Flush.
+// AddTable must be called after the rows are written but before Flush.
 //
 // See File.AddTable for details on the table format.
 func (sw *StreamWriter) AddTable(table *Table) error {
@@ -238,8 +447,13 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 	sheetPath := sw.file.sheetMap[sw.Sheet]
 	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
 	rID := sw.file.addRels(sheetRels, SourceRelationshipTable, sheetRelationshipsTableXML, "")
-
-	sw.tableParts = fmt.Sprintf(`<tableParts count="1"><tablePart r:id="rId%d"></tablePart></tableParts>`, rID)
+	if sw.worksheet.TableParts == nil {
+		sw.worksheet.TableParts = &xlsxTableParts{}
+	}
+	sw.worksheet.TableParts.Count++
+	sw.worksheet.TableParts.TableParts = append(sw.worksheet.TableParts.TableParts, &xlsxTablePart{
+		RID: "rId" + strconv.Itoa(rID),
+	})
 
 	if err = sw.file.addContentTypePart(tableID, "table"); err != nil {
 		return err
@@ -394,7 +608,9 @@ func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpt
 		return newStreamSetRowError(row)
 	}
 	sw.rows = row
-	sw.writeSheetData()
+	if err = sw.writeSheetData(); err != nil {
+		return err
+	}
 	options := parseRowOpts(opts...)
 	attrs, err := options.marshalAttrs()
 	if err != nil {
@@ -427,6 +643,10 @@ func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpt
 			_, _ = sw.rawData.WriteString(`</row>`)
 			return err
 		}
+		if err = sw.trackColWidth(col+i, &c); err != nil {
+			_, _ = sw.rawData.WriteString(`</row>`)
+			return err
+		}
 		writeCell(&sw.rawData, c)
 	}
 	_, _ = sw.rawData.WriteString(`</row>`)
@@ -453,13 +673,19 @@ func (sw *StreamWriter) SetColWidth(min, max int, width float64) error {
 		min, max = max, min
 	}
 
-	sw.cols.WriteString(`<col min="`)
-	sw.cols.WriteString(strconv.Itoa(min))
-	sw.cols.WriteString(`" max="`)
-	sw.cols.WriteString(strconv.Itoa(max))
-	sw.cols.WriteString(`" width="`)
-	sw.cols.WriteString(strconv.FormatFloat(width, 'f', -1, 64))
-	sw.cols.WriteString(`" customWidth="1"/>`)
+	col := xlsxCol{Min: min, Max: max, Width: float64Ptr(width), CustomWidth: true}
+	if sw.worksheet.Cols == nil {
+		sw.worksheet.Cols = &xlsxCols{}
+	}
+	sw.worksheet.Cols.Col = flatCols(col, sw.worksheet.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+		fc.BestFit = c.BestFit
+		fc.Collapsed = c.Collapsed
+		fc.Hidden = c.Hidden
+		fc.OutlineLevel = c.OutlineLevel
+		fc.Phonetic = c.Phonetic
+		fc.Style = c.Style
+		return fc
+	})
 	return nil
 }
 
@@ -498,6 +724,212 @@ func (sw *StreamWriter) MergeCell(hCell, vCell string) error {
 	return nil
 }
 
+// AddDataValidation provides a function to set data validation on a range of
+// the worksheet for the StreamWriter. Note that you must call the
+// 'AddDataValidation' function before the 'Flush' function. For example, set
+// a dropdown list for the cells A1:A100:
+//
+//	dv := excelize.NewDataValidation(true)
+//	dv.Sqref = "A1:A100"
+//	if err := dv.SetDropList([]string{"1", "2", "3"}); err != nil {
+//	    fmt.Println(err)
+//	}
+//	err := sw.AddDataValidation(dv)
+//
+// See File.AddDataValidation for details on the data validation.
+func (sw *StreamWriter) AddDataValidation(dv *DataValidation) error {
+	if dv == nil {
+		return ErrParameterInvalid
+	}
+	sw.worksheet.addDataValidation(dv)
+	return nil
+}
+
+// SetConditionalFormat provides a function to create conditional formatting
+// rule for cell value for the StreamWriter. Note that you must call the
+// 'SetConditionalFormat' function before the 'Flush' function. For example,
+// highlight cells in the range A1:A100 with values greater than 6:
+//
+//	format, err := f.NewConditionalStyle(
+//	    &excelize.Style{
+//	        Font: &excelize.Font{Color: "9A0511"},
+//	        Fill: excelize.Fill{
+//	            Type: "pattern", Color: []string{"FEC7CE"}, Pattern: 1,
+//	        },
+//	    },
+//	)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = sw.SetConditionalFormat("A1:A100",
+//	    []excelize.ConditionalFormatOptions{
+//	        {Type: "cell", Criteria: ">", Format: format, Value: "6"},
+//	    },
+//	)
+//
+// See File.SetConditionalFormat for details on the format options.
+func (sw *StreamWriter) SetConditionalFormat(rangeRef string, opts []ConditionalFormatOptions) error {
+	return sw.file.setConditionalFormat(sw.worksheet, sw.Sheet, rangeRef, opts)
+}
+
+// SetCellHyperLink provides a function to set cell hyperlink by given cell
+// reference and link URL for the StreamWriter, the value of the cell should
+// be written by the 'SetRow' function. Note that you must call the
+// 'SetCellHyperLink' function before the 'Flush' function. For example, add
+// an external link to the cell A1:
+//
+//	display, tooltip := "https://github.com/xuri/excelize", "Excelize on GitHub"
+//	err := sw.SetCellHyperLink("A1", "https://github.com/xuri/excelize",
+//	    "External", excelize.HyperlinkOpts{
+//	        Display: &display,
+//	        Tooltip: &tooltip,
+//	    })
+//
+// See File.SetCellHyperLink for details on the link types.
+func (sw *StreamWriter) SetCellHyperLink(cell, link, linkType string, opts ...HyperlinkOpts) error {
+	if _, _, err := SplitCellName(cell); err != nil {
+		return err
+	}
+	return sw.file.setCellHyperLink(sw.worksheet, sw.Sheet, cell, link, linkType, opts...)
+}
+
+// AddComment provides the method to add comment in the worksheet for the
+// StreamWriter, the VML drawing and comments parts will be created for the
+// worksheet. Note that you must call the 'AddComment' function before the
+// 'Flush' function. For example, add a comment in Sheet1!$A$1:
+//
+//	err := sw.AddComment(excelize.Comment{
+//	    Cell:   "A1",
+//	    Author: "Excelize",
+//	    Text:   "This is a comment.",
+//	})
+//
+// See File.AddComment for details on the comment options.
+func (sw *StreamWriter) AddComment(opts Comment) error {
+	return sw.file.AddComment(sw.Sheet, opts)
+}
+
+// AddPicture provides the method to add picture in the worksheet for the
+// StreamWriter by given cell reference, file path and format options. The
+// picture will be positioned by the column width set by the 'SetColWidth'
+// function and the default row height. Note that you must call the
+// 'AddPicture' function before the 'Flush' function. For example:
+//
+//	err := sw.AddPicture("A1", "logo.png", &excelize.GraphicOptions{
+//	    ScaleX: 0.5,
+//	    ScaleY: 0.5,
+//	})
+//
+// See File.AddPicture for details on the format options.
+func (sw *StreamWriter) AddPicture(cell, name string, opts *GraphicOptions) error {
+	return sw.file.AddPicture(sw.Sheet, cell, name, opts)
+}
+
+// AddPictureFromBytes provides the method to add picture in the worksheet
+// for the StreamWriter by given cell reference and the picture data. Note
+// that you must call the 'AddPictureFromBytes' function before the 'Flush'
+// function.
+//
+// See File.AddPictureFromBytes for details on the picture data.
+func (sw *StreamWriter) AddPictureFromBytes(cell string, pic *Picture) error {
+	return sw.file.AddPictureFromBytes(sw.Sheet, cell, pic)
+}
+
+// AddChart provides the method to add chart in the worksheet for the
+// StreamWriter by given cell reference and chart options, the data range of
+// the chart series could refer to the streamed cells. Note that you must call
+// the 'AddChart' function before the 'Flush' function. For example, add a
+// clustered column chart with the data in the range Sheet1!$A$1:$B$4:
+//
+//	err := sw.AddChart("D1", &excelize.Chart{
+//	    Type: excelize.Col,
+//	    Series: []excelize.ChartSeries{
+//	        {
+//	            Name:       "Sheet1!$B$1",
+//	            Categories: "Sheet1!$A$2:$A$4",
+//	            Values:     "Sheet1!$B$2:$B$4",
+//	        },
+//	    },
+//	})
+//
+// See File.AddChart for details on the chart options.
+func (sw *StreamWriter) AddChart(cell string, chart *Chart, combo ...*Chart) error {
+	return sw.file.AddChart(sw.Sheet, cell, chart, combo...)
+}
+
+// SetHeaderFooter provides the method to set headers and footers in the
+// worksheet for the StreamWriter by given options. Note that you must call
+// the 'SetHeaderFooter' function before the 'Flush' function. For example:
+//
+//	err := sw.SetHeaderFooter(&excelize.HeaderFooterOptions{
+//	    OddHeader: "&R&P",
+//	    OddFooter: "&CPage &P of &N",
+//	})
+//
+// See File.SetHeaderFooter for details on the options.
+func (sw *StreamWriter) SetHeaderFooter(opts *HeaderFooterOptions) error {
+	return sw.file.SetHeaderFooter(sw.Sheet, opts)
+}
+
+// ProtectSheet provides the method to prevent other users from accidentally
+// or deliberately changing, moving, or deleting data in the worksheet for the
+// StreamWriter. Note that you must call the 'ProtectSheet' function before
+// the 'Flush' function. For example, protect the worksheet with a password:
+//
+//	err := sw.ProtectSheet(&excelize.SheetProtectionOptions{
+//	    AlgorithmName:     "SHA-512",
+//	    Password:          "password",
+//	    SelectLockedCells: true,
+//	})
+//
+// See File.ProtectSheet for details on the options.
+func (sw *StreamWriter) ProtectSheet(opts *SheetProtectionOptions) error {
+	return sw.file.ProtectSheet(sw.Sheet, opts)
+}
+
+// AutoFilter provides the method to add auto filter in the worksheet for the
+// StreamWriter by given cell range reference and filter options. Note that
+// you must call the 'AutoFilter' function before the 'Flush' function, and
+// the filter mode of the worksheet properties will be set only if it was
+// called before the 'SetRow' function. For example, add an auto filter for
+// the streamed data in the range A1:D100:
+//
+//	err := sw.AutoFilter("A1:D100", []excelize.AutoFilterOptions{})
+//
+// See File.AutoFilter for details on the filter options.
+func (sw *StreamWriter) AutoFilter(rangeRef string, opts []AutoFilterOptions) error {
+	return sw.file.AutoFilter(sw.Sheet, rangeRef, opts)
+}
+
+// SetPageLayout provides the method to set the page layout of the worksheet
+// for the StreamWriter by given options. Note that you must call the
+// 'SetPageLayout' function before the 'Flush' function. For example:
+//
+//	orientation := "landscape"
+//	err := sw.SetPageLayout(&excelize.PageLayoutOptions{
+//	    Orientation: &orientation,
+//	})
+//
+// See File.SetPageLayout for details on the options.
+func (sw *StreamWriter) SetPageLayout(opts *PageLayoutOptions) error {
+	return sw.file.SetPageLayout(sw.Sheet, opts)
+}
+
+// SetPageMargins provides the method to set the page margins of the
+// worksheet for the StreamWriter by given options. Note that you must call
+// the 'SetPageMargins' function before the 'Flush' function. For example:
+//
+//	margin := 1.0
+//	err := sw.SetPageMargins(&excelize.PageLayoutMarginsOptions{
+//	    Left:  &margin,
+//	    Right: &margin,
+//	})
+//
+// See File.SetPageMargins for details on the options.
+func (sw *StreamWriter) SetPageMargins(opts *PageLayoutMarginsOptions) error {
+	return sw.file.SetPageMargins(sw.Sheet, opts)
+}
+
 // setCellFormula provides a function to set formula of a cell.
 func setCellFormula(c *xlsxC, formula string) {
 	if formula != "" {
@@ -508,7 +940,9 @@ func setCellFormula(c *xlsxC, formula string) {
 // setCellTime provides a function to set number of a cell with a time.
 func (sw *StreamWriter) setCellTime(c *xlsxC, val time.Time) error {
 	var date1904, isNum bool
-	wb, err := sw.file.workbookReader()
+	sw.file.mu.Lock()
+	wb, err := sw.file.loadWorkbook()
+	sw.file.mu.Unlock()
 	if err != nil {
 		return err
 	}
@@ -640,23 +1074,124 @@ func writeCell(buf *bufferedWriter, c xlsxC) {
 }
 
 // writeSheetData prepares the element preceding sheetData and writes the
-// sheetData XML start element to the buffer.
-func (sw *StreamWriter) writeSheetData() {
-	if !sw.sheetWritten {
-		bulkAppendFields(&sw.rawData, sw.worksheet, 4, 5)
-		if sw.cols.Len() > 0 {
-			_, _ = sw.rawData.WriteString("<cols>")
-			_, _ = sw.rawData.WriteString(sw.cols.String())
-			_, _ = sw.rawData.WriteString("</cols>")
+// sheetData XML start element to the buffer, the existing rows of the
+// worksheet will be copied after the start element for the stream appender.
+// The elements preceding the rows will be written on flush if auto fit
+// columns width was enabled.
+func (sw *StreamWriter) writeSheetData() error {
+	if sw.sheetWritten {
+		return nil
+	}
+	sw.sheetWritten = true
+	if sw.colWidths != nil {
+		sw.header, sw.rawData = sw.rawData, bufferedWriter{}
+	} else {
+		sw.writeSheetDataStart()
+	}
+	if sw.existingRows == nil {
+		return nil
+	}
+	defer func() {
+		if sw.existingFile != nil {
+			_ = sw.existingFile.Close()
+		}
+		sw.existingRows, sw.existingFile = nil, nil
+	}()
+	return sw.copyRows(sw.existingRows)
+}
+
+// writeSheetDataStart provides a function to write the worksheet elements
+// before the rows, including the sheet properties, columns and the start tag
+// of the sheet data.
+func (sw *StreamWriter) writeSheetDataStart() {
+	bulkAppendFields(&sw.rawData, sw.worksheet, 2, 5)
+	if sw.worksheet.Cols != nil && len(sw.worksheet.Cols.Col) > 0 {
+		sw.file.mergeExpandedCols(sw.worksheet)
+		bulkAppendFields(&sw.rawData, sw.worksheet, 6, 6)
+	}
+	_, _ = sw.rawData.WriteString(`<sheetData>`)
+}
+
+// copyRows provides a function to copy the rows from the given reader to the
+// stream in chunks.
+func (sw *StreamWriter) copyRows(r io.Reader) error {
+	for {
+		_, err := io.CopyN(&sw.rawData, r, StreamChunkSize)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if syncErr := sw.rawData.Sync(); syncErr != nil {
+			return syncErr
+		}
+		if err == io.EOF {
+			return nil
 		}
-		_, _ = sw.rawData.WriteString(`<sheetData>`)
-		sw.sheetWritten = true
 	}
 }
 
+// AutoFitColWidth provides a function to enable the stream writer to estimate
+// the width of the columns to fit the display text of the cells, the maximum
+// width of each column will be tracked while the rows are written, and the
+// columns width will be set when calling the 'Flush' function. The width of
+// the columns will be estimated in the same way as the AutoFitColWidth
+// function of the File, and the rows of the existing worksheet for the stream
+// appender will not be measured. Note that you must call this function before
+// the 'SetRow' function, and the rows will be copied once more on flush. For
+// example:
+//
+//	if err := sw.AutoFitColWidth(); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+func (sw *StreamWriter) AutoFitColWidth() error {
+	if sw.sheetWritten {
+		return ErrStreamAutoFitColWidth
+	}
+	sw.colWidths = make(map[int]float64)
+	return nil
+}
+
+// trackColWidth provides a function to track the maximum width of the column
+// by given column number and the written cell.
+func (sw *StreamWriter) trackColWidth(col int, c *xlsxC) error {
+	if sw.colWidths == nil {
+		return nil
+	}
+	sw.file.mu.Lock()
+	width, err := sw.file.getCellTextWidth(c, nil)
+	sw.file.mu.Unlock()
+	if width > sw.colWidths[col] {
+		sw.colWidths[col] = width
+	}
+	return err
+}
+
+// writeAutoFitCols provides a function to write the worksheet elements before
+// the rows with the estimated columns width, and copy the written rows after
+// them.
+func (sw *StreamWriter) writeAutoFitCols() error {
+	rows := sw.rawData
+	defer rows.Close()
+	sw.rawData, sw.header = sw.header, bufferedWriter{}
+	setAutoFitCols(sw.worksheet, sw.colWidths)
+	sw.writeSheetDataStart()
+	r, err := rows.Reader()
+	if err != nil {
+		return err
+	}
+	return sw.copyRows(r)
+}
+
 // Flush ending the streaming writing process.
 func (sw *StreamWriter) Flush() error {
-	sw.writeSheetData()
+	if err := sw.writeSheetData(); err != nil {
+		return err
+	}
+	if sw.colWidths != nil {
+		if err := sw.writeAutoFitCols(); err != nil {
+			return err
+		}
+	}
 	_, _ = sw.rawData.WriteString(`</sheetData>`)
 	bulkAppendFields(&sw.rawData, sw.worksheet, 8, 15)
 	mergeCells := strings.Builder{}
@@ -668,14 +1203,14 @@ func (sw *StreamWriter) Flush() error {
 		_, _ = mergeCells.WriteString(`</mergeCells>`)
 	}
 	_, _ = sw.rawData.WriteString(mergeCells.String())
-	bulkAppendFields(&sw.rawData, sw.worksheet, 17, 38)
-	_, _ = sw.rawData.WriteString(sw.tableParts)
-	bulkAppendFields(&sw.rawData, sw.worksheet, 40, 40)
+	bulkAppendFields(&sw.rawData, sw.worksheet, 17, 40)
 	_, _ = sw.rawData.WriteString(`</worksheet>`)
 	if err := sw.rawData.Flush(); err != nil {
 		return err
 	}
 
+	sw.file.mu.Lock()
+	defer sw.file.mu.Unlock()
 	sheetPath := sw.file.sheetMap[sw.Sheet]
 	sw.file.Sheet.Delete(sheetPath)
 	delete(sw.file.checked, sheetPath)
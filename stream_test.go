@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -165,6 +166,50 @@ func TestStreamSetColWidth(t *testing.T) {
 	assert.ErrorIs(t, streamWriter.SetColWidth(2, 3, 20), ErrStreamSetColWidth)
 }
 
+func TestStreamAutoFitColWidth(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetColWidth(3, 3, 20))
+	assert.NoError(t, sw.AutoFitColWidth())
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{Cell{StyleID: styleID, Value: "Name"}, "Amount"}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{"Excelize", 1234567.891}))
+	assert.NoError(t, sw.SetRow("A3", []interface{}{"A long text in the cell"}))
+	assert.Equal(t, ErrStreamAutoFitColWidth, sw.AutoFitColWidth())
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamAutoFitColWidth.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestStreamAutoFitColWidth.xlsx"))
+	assert.NoError(t, err)
+	for col, expected := range map[string]float64{
+		"A": getTextWidth("A long text in the cell", nil),
+		"B": getTextWidth("1234567.891", nil),
+		"C": 20,
+		"D": defaultColWidth,
+	} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name", "Amount"}, {"Excelize", "1234567.891"}, {"A long text in the cell"}}, rows)
+	assert.NoError(t, f.Close())
+
+	// Test auto fit column width with unsupported charset style sheet
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.AutoFitColWidth())
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, sw.SetRow("A1", []interface{}{Cell{StyleID: 1, Value: "A"}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestStreamSetPanes(t *testing.T) {
 	file, paneOpts := NewFile(), &Panes{
 		Freeze:      true,
@@ -258,6 +303,365 @@ func TestStreamInsertPageBreak(t *testing.T) {
 	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamInsertPageBreak.xlsx")))
 }
 
+func TestStreamDataValidation(t *testing.T) {
+	file := NewFile()
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A10"
+	assert.NoError(t, dv.SetDropList([]string{"1", "2", "3"}))
+	assert.NoError(t, streamWriter.AddDataValidation(dv))
+	assert.Equal(t, ErrParameterInvalid, streamWriter.AddDataValidation(nil))
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{1}))
+	assert.NoError(t, streamWriter.Flush())
+	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamDataValidation.xlsx")))
+	assert.NoError(t, file.Close())
+
+	file, err = OpenFile(filepath.Join("test", "TestStreamDataValidation.xlsx"))
+	assert.NoError(t, err)
+	dvs, err := file.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "A1:A10", dvs[0].Sqref)
+	assert.NoError(t, file.Close())
+}
+
+func TestStreamSetConditionalFormat(t *testing.T) {
+	file := NewFile()
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	format, err := file.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetConditionalFormat("A1:A10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "6"},
+	}))
+	assert.NoError(t, streamWriter.SetConditionalFormat("B1:B10", []ConditionalFormatOptions{
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarSolid: true},
+	}))
+	// Test set conditional format with invalid options
+	assert.Equal(t, ErrParameterInvalid, streamWriter.SetConditionalFormat("C1:C10", []ConditionalFormatOptions{
+		{Type: "icon_set", IconStyle: "unknown"},
+	}))
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{1, 2}))
+	assert.NoError(t, streamWriter.Flush())
+	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamSetConditionalFormat.xlsx")))
+	assert.NoError(t, file.Close())
+
+	file, err = OpenFile(filepath.Join("test", "TestStreamSetConditionalFormat.xlsx"))
+	assert.NoError(t, err)
+	condFmts, err := file.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, condFmts, 2)
+	assert.Equal(t, "cell", condFmts["A1:A10"][0].Type)
+	assert.Equal(t, "data_bar", condFmts["B1:B10"][0].Type)
+	assert.NoError(t, file.Close())
+}
+
+func TestStreamSetCellHyperLink(t *testing.T) {
+	file := NewFile()
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	display, tooltip := "Excelize", "Excelize on GitHub"
+	assert.NoError(t, streamWriter.SetCellHyperLink("A1", "https://github.com/xuri/excelize", "External", HyperlinkOpts{Display: &display, Tooltip: &tooltip}))
+	assert.NoError(t, streamWriter.SetCellHyperLink("A2", "Sheet1!A1", "Location"))
+	// Test set cell hyperlink with invalid cell reference and link type
+	assert.EqualError(t, streamWriter.SetCellHyperLink("A", "Sheet1!A1", "Location"), newInvalidCellNameError("A").Error())
+	assert.EqualError(t, streamWriter.SetCellHyperLink("A3", "Sheet1!A1", ""), `invalid link type ""`)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{display}))
+	assert.NoError(t, streamWriter.SetRow("A2", []interface{}{"A1"}))
+	assert.NoError(t, streamWriter.Flush())
+	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamSetCellHyperLink.xlsx")))
+	assert.NoError(t, file.Close())
+
+	file, err = OpenFile(filepath.Join("test", "TestStreamSetCellHyperLink.xlsx"))
+	assert.NoError(t, err)
+	ok, link, err := file.GetCellHyperLink("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "https://github.com/xuri/excelize", link)
+	ok, link, err = file.GetCellHyperLink("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "Sheet1!A1", link)
+	assert.NoError(t, file.Close())
+}
+
+func TestStreamAddCommentPictureChart(t *testing.T) {
+	file := NewFile()
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetColWidth(1, 2, 20))
+	assert.NoError(t, streamWriter.AddComment(Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	assert.NoError(t, streamWriter.AddPicture("D1", filepath.Join("test", "images", "excel.png"), nil))
+	img, err := os.ReadFile(filepath.Join("test", "images", "excel.jpg"))
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.AddPictureFromBytes("D10", &Picture{Extension: ".jpg", File: img}))
+	assert.NoError(t, streamWriter.AddChart("H1", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"},
+		},
+	}))
+	// Test add picture and chart with invalid options
+	assert.Error(t, streamWriter.AddPicture("D1", filepath.Join("test", "images", "unknown.png"), nil))
+	assert.Equal(t, ErrParameterInvalid, streamWriter.AddChart("H20", nil))
+	for r, row := range [][]interface{}{{"Name", "Value"}, {"A", 1}, {"B", 2}, {"C", 3}} {
+		cell, err := CoordinatesToCellName(1, r+1)
+		assert.NoError(t, err)
+		assert.NoError(t, streamWriter.SetRow(cell, row))
+	}
+	assert.NoError(t, streamWriter.Flush())
+	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamAddCommentPictureChart.xlsx")))
+	assert.NoError(t, file.Close())
+
+	file, err = OpenFile(filepath.Join("test", "TestStreamAddCommentPictureChart.xlsx"))
+	assert.NoError(t, err)
+	comments, err := file.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "Comment", comments[0].Text)
+	pics, err := file.GetPictures("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	pics, err = file.GetPictures("Sheet1", "D10")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	width, err := file.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	val, err := file.GetCellValue("Sheet1", "B4")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", val)
+	assert.NoError(t, file.Close())
+}
+
+func TestStreamPrintSettings(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{TabColorRGB: stringPtr("FF0000")}))
+	assert.NoError(t, sw.AutoFilter("A1:B3", []AutoFilterOptions{{Column: "B", Expression: "x > 1"}}))
+	for rowID := 1; rowID <= 3; rowID++ {
+		cell, _ := CoordinatesToCellName(1, rowID)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{"Data", rowID}))
+	}
+	assert.NoError(t, sw.SetHeaderFooter(&HeaderFooterOptions{OddHeader: "&R&P", OddFooter: "&CPage &P of &N"}))
+	assert.NoError(t, sw.ProtectSheet(&SheetProtectionOptions{Password: "password"}))
+	assert.NoError(t, sw.SetPageLayout(&PageLayoutOptions{Orientation: stringPtr("landscape")}))
+	assert.NoError(t, sw.SetPageMargins(&PageLayoutMarginsOptions{Left: float64Ptr(1.5)}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamPrintSettings.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestStreamPrintSettings.xlsx"))
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, ws.SheetPr.FilterMode)
+	assert.Equal(t, "FF0000", ws.SheetPr.TabColor.RGB)
+	assert.Equal(t, "$A$1:$B$3", ws.AutoFilter.Ref)
+	assert.Equal(t, "&R&P", ws.HeaderFooter.OddHeader)
+	assert.Equal(t, "&CPage &P of &N", ws.HeaderFooter.OddFooter)
+	assert.True(t, ws.SheetProtection.Sheet)
+	assert.Equal(t, "83AF", ws.SheetProtection.Password)
+	layout, err := f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "landscape", *layout.Orientation)
+	margins, err := f.GetPageMargins("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, *margins.Left)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Data", "1"}, {"Data", "2"}, {"Data", "3"}}, rows)
+	assert.NoError(t, f.Close())
+}
+
+func TestNewStreamAppender(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Value"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"A", 1}))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "B", 20))
+	assert.NoError(t, f.MergeCell("Sheet1", "D1", "E1"))
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B100", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "6"},
+	}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "G1:H2"}))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2", Values: "Sheet1!$B$2"}},
+	}))
+	path := filepath.Join("test", "TestNewStreamAppender.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	for _, opts := range []Options{{}, {UnzipXMLSizeLimit: 128}} {
+		f, err = OpenFile(path, opts)
+		assert.NoError(t, err)
+		sw, err := f.NewStreamAppender("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, sw.LastRow())
+		// Test append row before the last row of the worksheet
+		assert.Equal(t, newStreamSetRowError(2), sw.SetRow("A2", []interface{}{"B", 2}))
+		assert.NoError(t, sw.SetColWidth(3, 3, 30))
+		assert.NoError(t, sw.SetRow("A3", []interface{}{"B", 2}))
+		assert.NoError(t, sw.SetRow("A4", []interface{}{"C", 3}))
+		assert.NoError(t, sw.MergeCell("D4", "E4"))
+		assert.NoError(t, sw.Flush())
+		// Test append rows to the worksheet without rows
+		sw, err = f.NewStreamAppender("Sheet2")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sw.LastRow())
+		assert.NoError(t, sw.SetRow("A1", []interface{}{"Sheet2"}))
+		assert.NoError(t, sw.Flush())
+		assert.NoError(t, f.SaveAs(filepath.Join("test", "TestNewStreamAppender2.xlsx")))
+		assert.NoError(t, f.Close())
+
+		f, err = OpenFile(filepath.Join("test", "TestNewStreamAppender2.xlsx"))
+		assert.NoError(t, err)
+		rows, err := f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"Name", "Value", "", "", "", "", "Column1", "Column2"}, {"A", "1"}, {"B", "2"}, {"C", "3"}}, rows)
+		rows, err = f.GetRows("Sheet2")
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"Sheet2"}}, rows)
+		for col, expected := range map[string]float64{"A": 20, "B": 20, "C": 30} {
+			width, err := f.GetColWidth("Sheet1", col)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, width)
+		}
+		mergeCells, err := f.GetMergeCells("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, mergeCells, 2)
+		condFmts, err := f.GetConditionalFormats("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, condFmts, 1)
+		ws, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, ws.TableParts.TableParts, 1)
+		assert.NoError(t, f.Close())
+	}
+
+	// Test append rows to the worksheet which has been modified
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "A5"))
+	sw, err := f.NewStreamAppender("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, sw.LastRow())
+	assert.NoError(t, sw.SetRow("A6", []interface{}{"A6"}))
+	assert.NoError(t, sw.Flush())
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, nil, nil, nil, {"A5"}, {"A6"}}, rows)
+
+	// Test append rows to the worksheet with the root element attributes and
+	// the dimension of the existing rows
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" mc:Ignorable="x14ac" xmlns:x14ac="http://schemas.microsoft.com/office/spreadsheetml/2009/9/ac"><dimension ref="A1:B2"/><sheetData><row r="1" x14ac:dyDescent="0.25"><c r="A1"><v>1</v></c></row><row r="2" x14ac:dyDescent="0.25"><c r="B2"><v>2</v></c></row></sheetData></worksheet>`))
+	sw, err = f.NewStreamAppender("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A3", []interface{}{3, 4, 5}))
+	assert.NoError(t, sw.Flush())
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	sheetXML := string(f.readBytes("xl/worksheets/sheet1.xml"))
+	assert.Contains(t, sheetXML, `mc:Ignorable="x14ac"`)
+	assert.Contains(t, sheetXML, `xmlns:x14ac="http://schemas.microsoft.com/office/spreadsheetml/2009/9/ac"`)
+	assert.NotContains(t, sheetXML, `<dimension ref="A1:B2"/>`)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}, {"", "2"}, {"3", "4", "5"}}, rows)
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, dimension)
+	assert.NoError(t, f.Close())
+
+	// Test new stream appender with invalid sheet name
+	_, err = f.NewStreamAppender("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test new stream appender on not exists worksheet
+	_, err = f.NewStreamAppender("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+	// Test new stream appender on the chart sheet
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	_, err = f.NewStreamAppender("Chart1")
+	assert.EqualError(t, err, "sheet Chart1 is not a worksheet")
+	// Test new stream appender with unsupported charset
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	_, err = f.NewStreamAppender("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData></sheetData><dimension ref="A1"><</worksheet>`))
+	_, err = f.NewStreamAppender("Sheet1")
+	assert.Error(t, err)
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1"><c></row></sheetData></worksheet>`))
+	_, err = f.NewStreamAppender("Sheet1")
+	assert.Error(t, err)
+	// Test new stream appender with the invalid worksheet in the temporary file
+	tmp, err := os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	_, err = tmp.WriteString(`<worksheet><sheetData></sheetData><dimension ref="A1"><</worksheet>`)
+	assert.NoError(t, err)
+	assert.NoError(t, tmp.Close())
+	f.Pkg.Delete("xl/worksheets/sheet1.xml")
+	f.tempFiles.Store("xl/worksheets/sheet1.xml", tmp.Name())
+	_, err = f.NewStreamAppender("Sheet1")
+	assert.Error(t, err)
+	f.tempFiles.Delete("xl/worksheets/sheet1.xml")
+	assert.NoError(t, os.Remove(tmp.Name()))
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamWriterConcurrency(t *testing.T) {
+	f := NewFile()
+	sheets := []string{"Sheet1", "Sheet2", "Sheet3", "Sheet4"}
+	for _, sheet := range sheets[1:] {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	var wg sync.WaitGroup
+	for _, sheet := range sheets {
+		wg.Add(1)
+		go func(sheet string) {
+			defer wg.Done()
+			sw, err := f.NewStreamWriter(sheet)
+			assert.NoError(t, err)
+			for rowID := 1; rowID <= 100; rowID++ {
+				cell, _ := CoordinatesToCellName(1, rowID)
+				assert.NoError(t, sw.SetRow(cell, []interface{}{sheet, rowID, time.Date(2023, 1, rowID%28+1, 0, 0, 0, 0, time.UTC)}))
+			}
+			assert.NoError(t, sw.Flush())
+		}(sheet)
+	}
+	wg.Wait()
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamWriterConcurrency.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestStreamWriterConcurrency.xlsx"))
+	assert.NoError(t, err)
+	for _, sheet := range sheets {
+		rows, err := f.GetRows(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, rows, 100)
+		assert.Equal(t, []string{sheet, "100", "1/17/23 00:00"}, rows[99])
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestNewStreamWriter(t *testing.T) {
 	// Test error exceptions
 	file := NewFile()
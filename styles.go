@@ -21,6 +21,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/efp"
 )
 
 // validType defined the list of valid validation types.
@@ -105,8 +108,16 @@ var operatorType = map[string]string{
 }
 
 // stylesReader provides a function to get the pointer to the structure after
-// deserialization of xl/styles.xml.
+// deserialization of xl/styles.xml, and mark the styles as modified.
 func (f *File) stylesReader() (*xlsxStyleSheet, error) {
+	s, err := f.loadStyles()
+	f.setPartModified(defaultXMLPathStyles)
+	return s, err
+}
+
+// loadStyles provides a function to get the pointer to the structure after
+// deserialization of xl/styles.xml without marking the styles as modified.
+func (f *File) loadStyles() (*xlsxStyleSheet, error) {
 	if f.Styles == nil {
 		f.Styles = new(xlsxStyleSheet)
 		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathStyles)))).
@@ -120,7 +131,7 @@ func (f *File) stylesReader() (*xlsxStyleSheet, error) {
 // styleSheetWriter provides a function to save xl/styles.xml after serialize
 // structure.
 func (f *File) styleSheetWriter() {
-	if f.Styles != nil {
+	if f.Styles != nil && f.isPartModified(defaultXMLPathStyles) {
 		output, _ := xml.Marshal(f.Styles)
 		f.saveFileList(defaultXMLPathStyles, f.replaceNameSpaceBytes(defaultXMLPathStyles, output))
 	}
@@ -129,7 +140,7 @@ func (f *File) styleSheetWriter() {
 // themeWriter provides a function to save xl/theme/theme1.xml after serialize
 // structure.
 func (f *File) themeWriter() {
-	if f.Theme != nil {
+	if f.Theme != nil && f.isPartModified(defaultXMLPathTheme) {
 		output, _ := xml.Marshal(f.Theme)
 		f.saveFileList(defaultXMLPathTheme, f.replaceNameSpaceBytes(defaultXMLPathTheme, output))
 	}
@@ -138,12 +149,79 @@ func (f *File) themeWriter() {
 // sharedStringsWriter provides a function to save xl/sharedStrings.xml after
 // serialize structure.
 func (f *File) sharedStringsWriter() {
-	if f.SharedStrings != nil {
+	if f.SharedStrings != nil && f.isPartModified(defaultXMLPathSharedStrings) {
 		output, _ := xml.Marshal(f.SharedStrings)
 		f.saveFileList(defaultXMLPathSharedStrings, f.replaceNameSpaceBytes(defaultXMLPathSharedStrings, output))
 	}
 }
 
+// styleBorders list the border styles by the index of the border style
+// settings.
+var styleBorders = []string{
+	"none",
+	"thin",
+	"medium",
+	"dashed",
+	"dotted",
+	"thick",
+	"double",
+	"hair",
+	"mediumDashed",
+	"dashDot",
+	"mediumDashDot",
+	"dashDotDot",
+	"mediumDashDotDot",
+	"slantDashDot",
+}
+
+// styleFillPatterns list the fill pattern types by the index of the pattern
+// fill settings.
+var styleFillPatterns = []string{
+	"none",
+	"solid",
+	"mediumGray",
+	"darkGray",
+	"lightGray",
+	"darkHorizontal",
+	"darkVertical",
+	"darkDown",
+	"darkUp",
+	"darkGrid",
+	"darkTrellis",
+	"lightHorizontal",
+	"lightVertical",
+	"lightDown",
+	"lightUp",
+	"lightGrid",
+	"lightTrellis",
+	"gray125",
+	"gray0625",
+}
+
+// styleFillVariants returns the gradient fill variants by the index of the
+// shading settings.
+func styleFillVariants() []xlsxGradientFill {
+	return []xlsxGradientFill{
+		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 270, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 180, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
+		{Degree: 45, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 255, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 45, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
+		{Degree: 135, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 315, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 135, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path"},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Left: 1, Right: 1},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 1, Top: 1},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 1, Left: 1, Right: 1, Top: 1},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 0.5, Left: 0.5, Right: 0.5, Top: 0.5},
+	}
+}
+
 // parseFormatStyleSet provides a function to parse the format settings of the
 // cells and conditional formats.
 func parseFormatStyleSet(style *Style) (*Style, error) {
@@ -965,10 +1043,9 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 // Cell Sheet1!A6 in the Excel Application: martes, 04 de Julio de 2017
 func (f *File) NewStyle(style *Style) (int, error) {
 	var (
-		fs                                  *Style
-		font                                *xlsxFont
-		err                                 error
-		cellXfsID, fontID, borderID, fillID int
+		fs        *Style
+		err       error
+		cellXfsID int
 	)
 	if style == nil {
 		return cellXfsID, err
@@ -994,18 +1071,26 @@ func (f *File) NewStyle(style *Style) (int, error) {
 		return cellXfsID, err
 	}
 
-	numFmtID := newNumFmt(s, fs)
+	numFmtID, fontID, fillID, borderID := f.newStyleComponents(s, fs)
+	applyAlignment, alignment := fs.Alignment != nil, newAlignment(fs)
+	applyProtection, protection := fs.Protection != nil, newProtection(fs)
+	return setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
+}
 
+// newStyleComponents provides a function to get or create the number format,
+// font, fill and border by given style settings, and returns the index of
+// them in the style sheet.
+func (f *File) newStyleComponents(s *xlsxStyleSheet, fs *Style) (numFmtID, fontID, fillID, borderID int) {
+	numFmtID = newNumFmt(s, fs)
 	if fs.Font != nil {
 		fontID, _ = f.getFontID(s, fs)
 		if fontID == -1 {
 			s.Fonts.Count++
-			font, _ = f.newFont(fs)
+			font, _ := f.newFont(fs)
 			s.Fonts.Font = append(s.Fonts.Font, font)
 			fontID = s.Fonts.Count - 1
 		}
 	}
-
 	borderID = getBorderID(s, fs)
 	if borderID == -1 {
 		if len(fs.Border) == 0 {
@@ -1016,7 +1101,6 @@ func (f *File) NewStyle(style *Style) (int, error) {
 			borderID = s.Borders.Count - 1
 		}
 	}
-
 	if fillID = getFillID(s, fs); fillID == -1 {
 		if fill := newFills(fs, true); fill != nil {
 			s.Fills.Count++
@@ -1026,10 +1110,7 @@ func (f *File) NewStyle(style *Style) (int, error) {
 			fillID = 0
 		}
 	}
-
-	applyAlignment, alignment := fs.Alignment != nil, newAlignment(fs)
-	applyProtection, protection := fs.Protection != nil, newProtection(fs)
-	return setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
+	return
 }
 
 var getXfIDFuncs = map[string]func(int, xlsxXf, *Style) bool{
@@ -1144,6 +1225,240 @@ func (f *File) NewConditionalStyle(style *Style) (int, error) {
 	return s.Dxfs.Count - 1, nil
 }
 
+// GetStyle provides a function to get the style definition by given style
+// index, the returned style options could be modified and used to create a
+// new style by the NewStyle function. Note that the colors will be returned
+// in 'RRGGBB' hexadecimal notation, and the built-in number format index or
+// the custom number format code will be returned in the 'NumFmt' and
+// 'CustomNumFmt' fields. For example, get the style of the cell Sheet1!A1:
+//
+//	styleID, err := f.GetCellStyle("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	style, err := f.GetStyle(styleID)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+func (f *File) GetStyle(idx int) (*Style, error) {
+	f.mu.Lock()
+	s, err := f.loadStyles()
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if idx < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= idx {
+		return nil, newInvalidStyleID(idx)
+	}
+	return f.getXfStyle(s, s.CellXfs.Xf[idx]), err
+}
+
+// getXfStyle provides a function to get the style definition by given
+// formatting record of the style sheet.
+func (f *File) getXfStyle(s *xlsxStyleSheet, xf xlsxXf) *Style {
+	style := &Style{}
+	if xf.NumFmtID != nil {
+		extractNumFmt(s, *xf.NumFmtID, style)
+	}
+	if xf.FontID != nil && s.Fonts != nil && *xf.FontID < len(s.Fonts.Font) &&
+		(*xf.FontID != 0 || (xf.ApplyFont != nil && *xf.ApplyFont)) {
+		style.Font = extractFont(s.Fonts.Font[*xf.FontID])
+	}
+	if xf.FillID != nil && s.Fills != nil && *xf.FillID < len(s.Fills.Fill) && *xf.FillID != 0 {
+		style.Fill = f.extractFill(s.Fills.Fill[*xf.FillID])
+	}
+	if xf.BorderID != nil && s.Borders != nil && *xf.BorderID < len(s.Borders.Border) && *xf.BorderID != 0 {
+		style.Border = f.extractBorders(s.Borders.Border[*xf.BorderID])
+	}
+	if xf.Alignment != nil && *xf.Alignment != (xlsxAlignment{}) {
+		style.Alignment = extractAlignment(xf.Alignment)
+	}
+	if xf.Protection != nil {
+		style.Protection = extractProtection(xf.Protection)
+	}
+	return style
+}
+
+// getColorRRGGBB provides a function to convert the ARGB color of the style
+// to the color in 'RRGGBB' hexadecimal notation.
+func getColorRRGGBB(clr *xlsxColor) string {
+	if clr == nil {
+		return ""
+	}
+	if len(clr.RGB) == 8 {
+		return strings.ToUpper(clr.RGB[2:])
+	}
+	return strings.ToUpper(clr.RGB)
+}
+
+// getResolvedColorRRGGBB provides a function to resolve the RGB, theme or
+// indexed color of the style to the color in 'RRGGBB' hexadecimal notation,
+// it returns an empty string if the color can't be resolved.
+func (f *File) getResolvedColorRRGGBB(clr *xlsxColor) string {
+	if RGB := f.getColorRGB(clr); len(RGB) == 8 {
+		return RGB[2:]
+	}
+	return ""
+}
+
+// extractNumFmt provides a function to extract the number format by given
+// number format ID.
+func extractNumFmt(styleSheet *xlsxStyleSheet, numFmtID int, style *Style) {
+	if _, ok := builtInNumFmt[numFmtID]; ok || setLangNumFmt(&Style{NumFmt: numFmtID}) != 0 {
+		style.NumFmt = numFmtID
+		return
+	}
+	if styleSheet.NumFmts != nil {
+		for _, numFmt := range styleSheet.NumFmts.NumFmt {
+			if numFmt.NumFmtID == numFmtID {
+				style.CustomNumFmt = stringPtr(numFmt.FormatCode)
+				return
+			}
+		}
+	}
+}
+
+// extractFont provides a function to extract the font settings by given font
+// definition of the style sheet.
+func extractFont(fnt *xlsxFont) *Font {
+	font := &Font{}
+	isSet := func(v *attrValBool) bool {
+		return v != nil && (v.Val == nil || *v.Val)
+	}
+	font.Bold, font.Italic, font.Strike = isSet(fnt.B), isSet(fnt.I), isSet(fnt.Strike)
+	if fnt.U != nil {
+		font.Underline = "single"
+		if fnt.U.Val != nil {
+			font.Underline = *fnt.U.Val
+		}
+	}
+	if fnt.Name != nil && fnt.Name.Val != nil {
+		font.Family = *fnt.Name.Val
+	}
+	if fnt.Sz != nil && fnt.Sz.Val != nil {
+		font.Size = *fnt.Sz.Val
+	}
+	if fnt.Color != nil {
+		font.Color = getColorRRGGBB(fnt.Color)
+		if fnt.Color.Indexed != nil {
+			font.ColorIndexed = *fnt.Color.Indexed
+		}
+		font.ColorTheme = fnt.Color.Theme
+		font.ColorTint = fnt.Color.Tint
+	}
+	return font
+}
+
+// extractFill provides a function to extract the fill settings by given fill
+// definition of the style sheet, the theme and indexed colors will be
+// resolved to the RGB colors.
+func (f *File) extractFill(fill *xlsxFill) Fill {
+	var fl Fill
+	if fill.GradientFill != nil {
+		fl.Type = "gradient"
+		for _, stop := range fill.GradientFill.Stop {
+			if len(fl.Color) < 2 {
+				fl.Color = append(fl.Color, f.getResolvedColorRRGGBB(&stop.Color))
+			}
+		}
+		for shading, variant := range styleFillVariants() {
+			if isGradientFillVariant(fill.GradientFill, &variant) {
+				fl.Shading = shading
+				break
+			}
+		}
+		return fl
+	}
+	if fill.PatternFill != nil {
+		fl.Type = "pattern"
+		fl.Pattern = inStrSlice(styleFillPatterns, fill.PatternFill.PatternType, true)
+		if fl.Pattern == -1 {
+			fl.Pattern = 0
+		}
+		if color := fill.PatternFill.FgColor; color != nil {
+			fl.Color = []string{f.getResolvedColorRRGGBB(color)}
+		} else if color = fill.PatternFill.BgColor; color != nil {
+			fl.Color = []string{f.getResolvedColorRRGGBB(color)}
+		}
+	}
+	return fl
+}
+
+// isGradientFillVariant provides a function to check if the gradient fill
+// has the same direction and stops position with the given gradient fill
+// variant.
+func isGradientFillVariant(gradient, variant *xlsxGradientFill) bool {
+	if gradient.Type != variant.Type || gradient.Degree != variant.Degree ||
+		gradient.Bottom != variant.Bottom || gradient.Left != variant.Left ||
+		gradient.Right != variant.Right || gradient.Top != variant.Top ||
+		len(gradient.Stop) != len(variant.Stop) {
+		return false
+	}
+	for i, stop := range gradient.Stop {
+		if stop.Position != variant.Stop[i].Position {
+			return false
+		}
+	}
+	return true
+}
+
+// extractBorders provides a function to extract the borders settings by given
+// border definition of the style sheet, the theme and indexed colors will be
+// resolved to the RGB colors.
+func (f *File) extractBorders(bdr *xlsxBorder) []Border {
+	var borders []Border
+	extractBorder := func(typ string, line xlsxLine) {
+		if idx := inStrSlice(styleBorders, line.Style, true); idx > 0 {
+			borders = append(borders, Border{Type: typ, Color: f.getResolvedColorRRGGBB(line.Color), Style: idx})
+		}
+	}
+	extractBorder("left", bdr.Left)
+	extractBorder("right", bdr.Right)
+	extractBorder("top", bdr.Top)
+	extractBorder("bottom", bdr.Bottom)
+	if bdr.DiagonalUp {
+		extractBorder("diagonalUp", bdr.Diagonal)
+	}
+	if bdr.DiagonalDown {
+		extractBorder("diagonalDown", bdr.Diagonal)
+	}
+	return borders
+}
+
+// extractAlignment provides a function to extract the alignment settings by
+// given alignment of the cell format.
+func extractAlignment(alignment *xlsxAlignment) *Alignment {
+	return &Alignment{
+		Horizontal:      alignment.Horizontal,
+		Indent:          alignment.Indent,
+		JustifyLastLine: alignment.JustifyLastLine,
+		ReadingOrder:    alignment.ReadingOrder,
+		RelativeIndent:  alignment.RelativeIndent,
+		ShrinkToFit:     alignment.ShrinkToFit,
+		TextRotation:    alignment.TextRotation,
+		Vertical:        alignment.Vertical,
+		WrapText:        alignment.WrapText,
+	}
+}
+
+// extractProtection provides a function to extract the protection settings by
+// given protection of the cell format, the cell is locked by default.
+func extractProtection(protection *xlsxProtection) *Protection {
+	prot := &Protection{Locked: true}
+	if protection.Hidden != nil {
+		prot.Hidden = *protection.Hidden
+	}
+	if protection.Locked != nil {
+		prot.Locked = *protection.Locked
+	}
+	return prot
+}
+
 // GetDefaultFont provides the default font name currently set in the
 // workbook. The spreadsheet generated by excelize default font is Calibri.
 func (f *File) GetDefaultFont() (string, error) {
@@ -1174,7 +1489,7 @@ func (f *File) SetDefaultFont(fontName string) error {
 func (f *File) readDefaultFont() (*xlsxFont, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	s, err := f.stylesReader()
+	s, err := f.loadStyles()
 	if err != nil {
 		return nil, err
 	}
@@ -1217,7 +1532,9 @@ func newFontColor(font *Font) *xlsxColor {
 	}
 	if font.ColorIndexed >= 0 && font.ColorIndexed <= len(IndexedColorMapping)+1 {
 		prepareFontColor()
-		fontColor.Indexed = font.ColorIndexed
+		if font.ColorIndexed != 0 {
+			fontColor.Indexed = intPtr(font.ColorIndexed)
+		}
 	}
 	if font.ColorTheme != nil {
 		prepareFontColor()
@@ -1395,58 +1712,17 @@ func getFillID(styleSheet *xlsxStyleSheet, style *Style) (fillID int) {
 // newFills provides a function to add fill elements in the styles.xml by
 // given cell format settings.
 func newFills(style *Style, fg bool) *xlsxFill {
-	patterns := []string{
-		"none",
-		"solid",
-		"mediumGray",
-		"darkGray",
-		"lightGray",
-		"darkHorizontal",
-		"darkVertical",
-		"darkDown",
-		"darkUp",
-		"darkGrid",
-		"darkTrellis",
-		"lightHorizontal",
-		"lightVertical",
-		"lightDown",
-		"lightUp",
-		"lightGrid",
-		"lightTrellis",
-		"gray125",
-		"gray0625",
-	}
-	variants := []xlsxGradientFill{
-		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 270, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 180, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
-		{Degree: 45, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 255, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 45, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
-		{Degree: 135, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 315, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 135, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path"},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Left: 1, Right: 1},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 1, Top: 1},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 1, Left: 1, Right: 1, Top: 1},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 0.5, Left: 0.5, Right: 0.5, Top: 0.5},
-	}
-
 	var fill xlsxFill
 	switch style.Fill.Type {
 	case "gradient":
 		if len(style.Fill.Color) != 2 || style.Fill.Shading < 0 || style.Fill.Shading > 16 {
 			break
 		}
-		gradient := variants[style.Fill.Shading]
-		gradient.Stop[0].Color.RGB = getPaletteColor(style.Fill.Color[0])
-		gradient.Stop[1].Color.RGB = getPaletteColor(style.Fill.Color[1])
-		if len(gradient.Stop) == 3 {
-			gradient.Stop[2].Color.RGB = getPaletteColor(style.Fill.Color[0])
+		gradient := styleFillVariants()[style.Fill.Shading]
+		for i := range gradient.Stop {
+			if color := style.Fill.Color[i%2]; color != "" {
+				gradient.Stop[i].Color.RGB = getPaletteColor(color)
+			}
 		}
 		fill.GradientFill = &gradient
 	case "pattern":
@@ -1457,7 +1733,11 @@ func newFills(style *Style, fg bool) *xlsxFill {
 			break
 		}
 		var pattern xlsxPatternFill
-		pattern.PatternType = patterns[style.Fill.Pattern]
+		pattern.PatternType = styleFillPatterns[style.Fill.Pattern]
+		if style.Fill.Color[0] == "" {
+			fill.PatternFill = &pattern
+			break
+		}
 		if fg {
 			if pattern.FgColor == nil {
 				pattern.FgColor = new(xlsxColor)
@@ -1526,48 +1806,33 @@ func getBorderID(styleSheet *xlsxStyleSheet, style *Style) (borderID int) {
 // newBorders provides a function to add border elements in the styles.xml by
 // given borders format settings.
 func newBorders(style *Style) *xlsxBorder {
-	styles := []string{
-		"none",
-		"thin",
-		"medium",
-		"dashed",
-		"dotted",
-		"thick",
-		"double",
-		"hair",
-		"mediumDashed",
-		"dashDot",
-		"mediumDashDot",
-		"dashDotDot",
-		"mediumDashDotDot",
-		"slantDashDot",
-	}
-
 	var border xlsxBorder
 	for _, v := range style.Border {
 		if 0 <= v.Style && v.Style < 14 {
-			var color xlsxColor
-			color.RGB = getPaletteColor(v.Color)
+			var color *xlsxColor
+			if v.Color != "" {
+				color = &xlsxColor{RGB: getPaletteColor(v.Color)}
+			}
 			switch v.Type {
 			case "left":
-				border.Left.Style = styles[v.Style]
-				border.Left.Color = &color
+				border.Left.Style = styleBorders[v.Style]
+				border.Left.Color = color
 			case "right":
-				border.Right.Style = styles[v.Style]
-				border.Right.Color = &color
+				border.Right.Style = styleBorders[v.Style]
+				border.Right.Color = color
 			case "top":
-				border.Top.Style = styles[v.Style]
-				border.Top.Color = &color
+				border.Top.Style = styleBorders[v.Style]
+				border.Top.Color = color
 			case "bottom":
-				border.Bottom.Style = styles[v.Style]
-				border.Bottom.Color = &color
+				border.Bottom.Style = styleBorders[v.Style]
+				border.Bottom.Color = color
 			case "diagonalUp":
-				border.Diagonal.Style = styles[v.Style]
-				border.Diagonal.Color = &color
+				border.Diagonal.Style = styleBorders[v.Style]
+				border.Diagonal.Color = color
 				border.DiagonalUp = true
 			case "diagonalDown":
-				border.Diagonal.Style = styles[v.Style]
-				border.Diagonal.Color = &color
+				border.Diagonal.Style = styleBorders[v.Style]
+				border.Diagonal.Color = color
 				border.DiagonalDown = true
 			}
 		}
@@ -1578,6 +1843,21 @@ func newBorders(style *Style) *xlsxBorder {
 // setCellXfs provides a function to set describes all the formatting for a
 // cell.
 func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, applyAlignment, applyProtection bool, alignment *xlsxAlignment, protection *xlsxProtection) (int, error) {
+	xf := newXf(fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
+	if len(style.CellXfs.Xf) == MaxCellStyles {
+		return 0, ErrCellStyles
+	}
+	style.CellXfs.Count = len(style.CellXfs.Xf) + 1
+	xfID := 0
+	xf.XfID = &xfID
+	style.CellXfs.Xf = append(style.CellXfs.Xf, xf)
+	return style.CellXfs.Count - 1, nil
+}
+
+// newXf provides a function to create the formatting record by given index
+// of the font, number format, fill, border and the alignment and protection
+// settings.
+func newXf(fontID, numFmtID, fillID, borderID int, applyAlignment, applyProtection bool, alignment *xlsxAlignment, protection *xlsxProtection) xlsxXf {
 	var xf xlsxXf
 	xf.FontID = intPtr(fontID)
 	if fontID != 0 {
@@ -1595,10 +1875,6 @@ func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, a
 	if borderID != 0 {
 		xf.ApplyBorder = boolPtr(true)
 	}
-	if len(style.CellXfs.Xf) == MaxCellStyles {
-		return 0, ErrCellStyles
-	}
-	style.CellXfs.Count = len(style.CellXfs.Xf) + 1
 	xf.Alignment = alignment
 	if alignment != nil {
 		xf.ApplyAlignment = boolPtr(applyAlignment)
@@ -1607,16 +1883,13 @@ func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, a
 		xf.ApplyProtection = boolPtr(applyProtection)
 		xf.Protection = protection
 	}
-	xfID := 0
-	xf.XfID = &xfID
-	style.CellXfs.Xf = append(style.CellXfs.Xf, xf)
-	return style.CellXfs.Count - 1, nil
+	return xf
 }
 
 // GetCellStyle provides a function to get cell style index by given worksheet
 // name and cell reference.
 func (f *File) GetCellStyle(sheet, cell string) (int, error) {
-	ws, err := f.workSheetReader(sheet)
+	ws, _, err := f.loadWorkSheet(sheet)
 	if err != nil {
 		return 0, err
 	}
@@ -1764,7 +2037,7 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 		f.mu.Unlock()
 		return err
 	}
-	s, err := f.stylesReader()
+	s, err := f.loadStyles()
 	if err != nil {
 		f.mu.Unlock()
 		return err
@@ -1789,95 +2062,512 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 	return err
 }
 
-// SetConditionalFormat provides a function to create conditional formatting
-// rule for cell value. Conditional formatting is a feature of Excel which
-// allows you to apply a format to a cell or a range of cells based on certain
-// criteria.
-//
-// The type option is a required parameter and it has no default value.
-// Allowable type values and their associated parameters are:
-//
-//	 Type          | Parameters
-//	---------------+------------------------------------
-//	 cell          | Criteria
-//	               | Value
-//	               | MinValue
-//	               | MaxValue
-//	 date          | Criteria
-//	               | Value
-//	               | MinValue
-//	               | MaxValue
-//	 time_period   | Criteria
-//	 text          | Criteria
-//	               | Value
-//	 average       | Criteria
-//	 duplicate     | (none)
-//	 unique        | (none)
-//	 top           | Criteria
-//	               | Value
-//	 bottom        | Criteria
-//	               | Value
-//	 blanks        | (none)
-//	 no_blanks     | (none)
-//	 errors        | (none)
-//	 no_errors     | (none)
-//	 2_color_scale | MinType
-//	               | MaxType
-//	               | MinValue
-//	               | MaxValue
-//	               | MinColor
-//	               | MaxColor
-//	 3_color_scale | MinType
-//	               | MidType
-//	               | MaxType
-//	               | MinValue
-//	               | MidValue
-//	               | MaxValue
-//	               | MinColor
-//	               | MidColor
-//	               | MaxColor
-//	 data_bar      | MinType
-//	               | MaxType
-//	               | MinValue
-//	               | MaxValue
-//	               | BarBorderColor
-//	               | BarColor
-//	               | BarDirection
-//	               | BarOnly
-//	               | BarSolid
-//	 icon_set      | IconStyle
-//	               | ReverseIcons
-//	               | IconsOnly
-//	 formula       | Criteria
-//
-// The 'Criteria' parameter is used to set the criteria by which the cell data
-// will be evaluated. It has no default value. The most common criteria as
-// applied to {"type"："cell"} are:
-//
-//	between                  |
-//	not between              |
-//	equal to                 | ==
-//	not equal to             | !=
-//	greater than             | >
-//	less than                | <
-//	greater than or equal to | >=
-//	less than or equal to    | <=
-//
-// You can either use Excel's textual description strings, in the first column
-// above, or the more common symbolic alternatives.
-//
-// Additional criteria which are specific to other conditional format types are
-// shown in the relevant sections below.
-//
-// value: The value is generally used along with the criteria parameter to set
-// the rule by which the cell data will be evaluated:
+// UpdateCellStyle provides a function to update the style of the cells by
+// given worksheet name, range reference and the style patch. The patch only
+// specifies the style attributes to change, and the other attributes of each
+// distinct existing style of the cells will be kept, the updated styles will
+// be reused if the same style already exists. The fields with the zero value
+// in the patch will be ignored, so the boolean attributes such as the bold
+// font can't be cleared by the patch. The borders of the patch will replace
+// the borders with the same type, the fill and protection of the patch will
+// replace the existing settings, and the fields of the font and alignment will
+// be merged into the existing settings. This function is concurrency safe.
+// For example, set the font bold and add the bottom border for the cells
+// Sheet1!A1:D5 with keeping the existing fill and number formats:
 //
-//	err := f.SetConditionalFormat("Sheet1", "D1:D10",
-//	    []excelize.ConditionalFormatOptions{
-//	        {
-//	            Type:     "cell",
-//	            Criteria: ">",
-//	            Format:   format,
+//	err := f.UpdateCellStyle("Sheet1", "A1", "D5", &excelize.Style{
+//	    Font:   &excelize.Font{Bold: true},
+//	    Border: []excelize.Border{{Type: "bottom", Color: "000000", Style: 1}},
+//	})
+func (f *File) UpdateCellStyle(sheet, hCell, vCell string, patch *Style) error {
+	hCol, hRow, err := CellNameToCoordinates(hCell)
+	if err != nil {
+		return err
+	}
+	vCol, vRow, err := CellNameToCoordinates(vCell)
+	if err != nil {
+		return err
+	}
+	if vCol < hCol {
+		vCol, hCol = hCol, vCol
+	}
+	if vRow < hRow {
+		vRow, hRow = hRow, vRow
+	}
+	if patch == nil {
+		return ErrParameterInvalid
+	}
+	if _, err = parseFormatStyleSet(patch); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.prepareSheetXML(vCol, vRow)
+	ws.makeContiguousColumns(hRow, vRow, vCol)
+	styleIDs := make(map[int]int)
+	for r := hRow; r <= vRow; r++ {
+		for c := hCol; c <= vCol; c++ {
+			cell := &ws.SheetData.Row[r-1].C[c-1]
+			styleID := ws.prepareCellStyle(c, r, cell.S)
+			newStyleID, ok := styleIDs[styleID]
+			if !ok {
+				if newStyleID, err = f.updateStyle(styleID, patch); err != nil {
+					return err
+				}
+				styleIDs[styleID] = newStyleID
+			}
+			cell.S = newStyleID
+		}
+	}
+	return err
+}
+
+// updateStyle provides a function to merge the style patch into the style by
+// given style index, and returns the index of the updated style. The fill and
+// border of the style will be kept by the original index if the patch doesn't
+// change them, so that the theme and indexed colors won't be resolved, and
+// the parent cell style of the formatting record will be kept.
+func (f *File) updateStyle(styleID int, patch *Style) (int, error) {
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return styleID, err
+	}
+	keepFill, keepBorder := patch.Fill.Type == "", len(patch.Border) == 0
+	if keepFill {
+		style.Fill = Fill{}
+	}
+	if keepBorder {
+		style.Border = nil
+	}
+	if patch.Font != nil && style.Font == nil {
+		font, err := f.readDefaultFont()
+		if err != nil {
+			return styleID, err
+		}
+		style.Font = extractFont(font)
+	}
+	mergeStyle(style, patch)
+	fs, err := parseFormatStyleSet(style)
+	if err != nil {
+		return styleID, err
+	}
+	if fs.DecimalPlaces != nil && (*fs.DecimalPlaces < 0 || *fs.DecimalPlaces > 30) {
+		fs.DecimalPlaces = intPtr(2)
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return styleID, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	numFmtID, fontID, fillID, borderID := f.newStyleComponents(s, fs)
+	orig := s.CellXfs.Xf[styleID]
+	if keepFill && orig.FillID != nil {
+		fillID = *orig.FillID
+	}
+	if keepBorder && orig.BorderID != nil {
+		borderID = *orig.BorderID
+	}
+	applyAlignment, alignment := fs.Alignment != nil, newAlignment(fs)
+	applyProtection, protection := fs.Protection != nil, newProtection(fs)
+	xf := newXf(fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
+	xf.XfID = intPtr(0)
+	if orig.XfID != nil {
+		xf.XfID = intPtr(*orig.XfID)
+	}
+	for idx := range s.CellXfs.Xf {
+		if reflect.DeepEqual(s.CellXfs.Xf[idx], xf) {
+			return idx, err
+		}
+	}
+	if len(s.CellXfs.Xf) == MaxCellStyles {
+		return styleID, ErrCellStyles
+	}
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	return s.CellXfs.Count - 1, err
+}
+
+// mergeStyle provides a function to merge the non-zero fields of the style
+// patch into the style.
+func mergeStyle(style, patch *Style) {
+	for _, border := range patch.Border {
+		idx := -1
+		for i := range style.Border {
+			if style.Border[i].Type == border.Type {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			style.Border = append(style.Border, border)
+			continue
+		}
+		style.Border[idx] = border
+	}
+	if patch.Fill.Type != "" {
+		style.Fill = patch.Fill
+	}
+	if patch.Font != nil {
+		mergeFont(style.Font, patch.Font)
+	}
+	if patch.Alignment != nil {
+		if style.Alignment == nil {
+			style.Alignment = &Alignment{}
+		}
+		mergeAlignment(style.Alignment, patch.Alignment)
+	}
+	if patch.Protection != nil {
+		style.Protection = patch.Protection
+	}
+	if patch.CustomNumFmt != nil {
+		style.NumFmt, style.CustomNumFmt = 0, patch.CustomNumFmt
+	} else if patch.NumFmt != 0 {
+		style.NumFmt, style.CustomNumFmt = patch.NumFmt, nil
+	}
+	if patch.DecimalPlaces != nil {
+		style.DecimalPlaces = patch.DecimalPlaces
+	}
+	style.NegRed = style.NegRed || patch.NegRed
+}
+
+// mergeFont provides a function to merge the non-zero fields of the font
+// patch into the font settings.
+func mergeFont(font, patch *Font) {
+	font.Bold = font.Bold || patch.Bold
+	font.Italic = font.Italic || patch.Italic
+	font.Strike = font.Strike || patch.Strike
+	if patch.Underline != "" {
+		font.Underline = patch.Underline
+	}
+	if patch.Family != "" {
+		font.Family = patch.Family
+	}
+	if patch.Size != 0 {
+		font.Size = patch.Size
+	}
+	if patch.Color != "" {
+		font.Color, font.ColorIndexed, font.ColorTheme = patch.Color, 0, nil
+	}
+	if patch.ColorIndexed != 0 {
+		font.Color, font.ColorIndexed, font.ColorTheme = "", patch.ColorIndexed, nil
+	}
+	if patch.ColorTheme != nil {
+		font.Color, font.ColorIndexed, font.ColorTheme = "", 0, patch.ColorTheme
+	}
+	if patch.ColorTint != 0 {
+		font.ColorTint = patch.ColorTint
+	}
+}
+
+// mergeAlignment provides a function to merge the non-zero fields of the
+// alignment patch into the alignment settings.
+func mergeAlignment(alignment, patch *Alignment) {
+	if patch.Horizontal != "" {
+		alignment.Horizontal = patch.Horizontal
+	}
+	if patch.Vertical != "" {
+		alignment.Vertical = patch.Vertical
+	}
+	if patch.Indent != 0 {
+		alignment.Indent = patch.Indent
+	}
+	if patch.RelativeIndent != 0 {
+		alignment.RelativeIndent = patch.RelativeIndent
+	}
+	if patch.ReadingOrder != 0 {
+		alignment.ReadingOrder = patch.ReadingOrder
+	}
+	if patch.TextRotation != 0 {
+		alignment.TextRotation = patch.TextRotation
+	}
+	alignment.JustifyLastLine = alignment.JustifyLastLine || patch.JustifyLastLine
+	alignment.ShrinkToFit = alignment.ShrinkToFit || patch.ShrinkToFit
+	alignment.WrapText = alignment.WrapText || patch.WrapText
+}
+
+// builtInCellStyles defined the built-in named cell styles by the name, and
+// the built-in ID of these styles.
+var builtInCellStyles = map[string]int{
+	"Normal": 0, "Comma": 3, "Currency": 4, "Percent": 5, "Comma [0]": 6,
+	"Currency [0]": 7, "Hyperlink": 8, "Followed Hyperlink": 9, "Note": 10,
+	"Warning Text": 11, "Title": 15, "Heading 1": 16, "Heading 2": 17,
+	"Heading 3": 18, "Heading 4": 19, "Input": 20, "Output": 21,
+	"Calculation": 22, "Check Cell": 23, "Linked Cell": 24, "Total": 25,
+	"Good": 26, "Bad": 27, "Neutral": 28, "Accent1": 29,
+	"20% - Accent1": 30, "40% - Accent1": 31, "60% - Accent1": 32,
+	"Accent2": 33, "20% - Accent2": 34, "40% - Accent2": 35,
+	"60% - Accent2": 36, "Accent3": 37, "20% - Accent3": 38,
+	"40% - Accent3": 39, "60% - Accent3": 40, "Accent4": 41,
+	"20% - Accent4": 42, "40% - Accent4": 43, "60% - Accent4": 44,
+	"Accent5": 45, "20% - Accent5": 46, "40% - Accent5": 47,
+	"60% - Accent5": 48, "Accent6": 49, "20% - Accent6": 50,
+	"40% - Accent6": 51, "60% - Accent6": 52, "Explanatory Text": 53,
+}
+
+// NewNamedStyle provides a function to create the named cell style by given
+// style name and style settings, the named cell style will be shown in the
+// cell styles gallery of the spreadsheet application. The style settings are
+// the same with the NewStyle function. This function returns the cell style
+// index which applies the named cell style, it could be used to set the style
+// of the cells by the SetCellStyle function or the stream writer. When the
+// given name is the same as the name of a built-in cell style, such as
+// "Heading 1" or "Input", the named cell style will be created as the
+// customized built-in cell style. This function is concurrency safe. For
+// example, create a named cell style "Input" and apply it to the cells
+// Sheet1!A1:B2:
+//
+//	_, err := f.NewNamedStyle("Input", &excelize.Style{
+//	    Font: &excelize.Font{Color: "3F3F76"},
+//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"FFCC99"}, Pattern: 1},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetCellNamedStyle("Sheet1", "A1", "B2", "Input")
+func (f *File) NewNamedStyle(name string, style *Style) (int, error) {
+	if name == "" {
+		return 0, ErrParameterRequired
+	}
+	if utf8.RuneCountInString(name) > MaxFieldLength {
+		return 0, ErrNameLength
+	}
+	if style == nil {
+		return 0, ErrParameterInvalid
+	}
+	fs, err := parseFormatStyleSet(style)
+	if err != nil {
+		return 0, err
+	}
+	if fs.DecimalPlaces != nil && (*fs.DecimalPlaces < 0 || *fs.DecimalPlaces > 30) {
+		fs.DecimalPlaces = intPtr(2)
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return 0, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.getCellStyle(name) != nil {
+		return 0, ErrExistsNamedStyle
+	}
+	if s.CellStyleXfs == nil {
+		s.CellStyleXfs = &xlsxCellStyleXfs{}
+	}
+	if len(s.CellStyleXfs.Xf) == MaxCellStyles {
+		return 0, ErrCellStyles
+	}
+	numFmtID, fontID, fillID, borderID := f.newStyleComponents(s, fs)
+	s.CellStyleXfs.Xf = append(s.CellStyleXfs.Xf, newXf(fontID, numFmtID, fillID, borderID,
+		fs.Alignment != nil, fs.Protection != nil, newAlignment(fs), newProtection(fs)))
+	s.CellStyleXfs.Count = len(s.CellStyleXfs.Xf)
+	cellStyle := &xlsxCellStyle{Name: name, XfID: s.CellStyleXfs.Count - 1}
+	if builtInID, ok := builtInCellStyles[name]; ok {
+		cellStyle.BuiltInID, cellStyle.CustomBuiltIn = intPtr(builtInID), boolPtr(true)
+	}
+	if s.CellStyles == nil {
+		s.CellStyles = &xlsxCellStyles{}
+	}
+	s.CellStyles.CellStyle = append(s.CellStyles.CellStyle, cellStyle)
+	s.CellStyles.Count = len(s.CellStyles.CellStyle)
+	return s.getNamedStyleID(cellStyle.XfID)
+}
+
+// GetNamedStyles provides a function to get all named cell styles of the
+// workbook, including the built-in cell styles used in the workbook.
+func (f *File) GetNamedStyles() ([]NamedStyle, error) {
+	var namedStyles []NamedStyle
+	f.mu.Lock()
+	s, err := f.loadStyles()
+	if err != nil {
+		f.mu.Unlock()
+		return namedStyles, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CellStyles == nil {
+		return namedStyles, err
+	}
+	for _, cellStyle := range s.CellStyles.CellStyle {
+		namedStyle := NamedStyle{Name: cellStyle.Name, Style: &Style{}}
+		if s.CellStyleXfs != nil && cellStyle.XfID >= 0 && cellStyle.XfID < len(s.CellStyleXfs.Xf) {
+			namedStyle.Style = f.getXfStyle(s, s.CellStyleXfs.Xf[cellStyle.XfID])
+		}
+		namedStyles = append(namedStyles, namedStyle)
+	}
+	return namedStyles, err
+}
+
+// SetCellNamedStyle provides a function to apply the named cell style to the
+// cells by given worksheet name, range reference and the name of the named
+// cell style. The name of the named cell style is case-insensitive. This
+// function is concurrency safe.
+func (f *File) SetCellNamedStyle(sheet, hCell, vCell, name string) error {
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	cellStyle := s.getCellStyle(name)
+	if cellStyle == nil || s.CellStyleXfs == nil || cellStyle.XfID < 0 || cellStyle.XfID >= len(s.CellStyleXfs.Xf) {
+		s.mu.Unlock()
+		return newNoExistNamedStyleError(name)
+	}
+	styleID, err := s.getNamedStyleID(cellStyle.XfID)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return f.SetCellStyle(sheet, hCell, vCell, styleID)
+}
+
+// getCellStyle provides a function to get the named cell style by given
+// case-insensitive style name, it will return nil if the named cell style
+// doesn't exist.
+func (s *xlsxStyleSheet) getCellStyle(name string) *xlsxCellStyle {
+	if s.CellStyles == nil {
+		return nil
+	}
+	for _, cellStyle := range s.CellStyles.CellStyle {
+		if strings.EqualFold(cellStyle.Name, name) {
+			return cellStyle
+		}
+	}
+	return nil
+}
+
+// getNamedStyleID provides a function to get the cell style index which
+// applies the named cell style by given index of the master formatting
+// record in the cellStyleXfs, the cell formatting record linked to the
+// master formatting record will be created if it doesn't exist.
+func (s *xlsxStyleSheet) getNamedStyleID(xfID int) (int, error) {
+	parent := s.CellStyleXfs.Xf[xfID]
+	if s.CellXfs == nil {
+		s.CellXfs = &xlsxCellXfs{}
+	}
+	for idx, xf := range s.CellXfs.Xf {
+		if xf.XfID != nil && *xf.XfID == xfID {
+			if xf.XfID = nil; reflect.DeepEqual(xf, parent) {
+				return idx, nil
+			}
+		}
+	}
+	if len(s.CellXfs.Xf) == MaxCellStyles {
+		return 0, ErrCellStyles
+	}
+	parent.XfID = intPtr(xfID)
+	s.CellXfs.Xf = append(s.CellXfs.Xf, parent)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	return s.CellXfs.Count - 1, nil
+}
+
+// SetConditionalFormat provides a function to create conditional formatting
+// rule for cell value. Conditional formatting is a feature of Excel which
+// allows you to apply a format to a cell or a range of cells based on certain
+// criteria.
+//
+// The type option is a required parameter and it has no default value.
+// Allowable type values and their associated parameters are:
+//
+//	 Type          | Parameters
+//	---------------+------------------------------------
+//	 cell          | Criteria
+//	               | Value
+//	               | MinValue
+//	               | MaxValue
+//	 date          | Criteria
+//	               | Value
+//	               | MinValue
+//	               | MaxValue
+//	 time_period   | Criteria
+//	 text          | Criteria
+//	               | Value
+//	 average       | Criteria
+//	 duplicate     | (none)
+//	 unique        | (none)
+//	 top           | Criteria
+//	               | Value
+//	 bottom        | Criteria
+//	               | Value
+//	 blanks        | (none)
+//	 no_blanks     | (none)
+//	 errors        | (none)
+//	 no_errors     | (none)
+//	 2_color_scale | MinType
+//	               | MaxType
+//	               | MinValue
+//	               | MaxValue
+//	               | MinColor
+//	               | MaxColor
+//	 3_color_scale | MinType
+//	               | MidType
+//	               | MaxType
+//	               | MinValue
+//	               | MidValue
+//	               | MaxValue
+//	               | MinColor
+//	               | MidColor
+//	               | MaxColor
+//	 data_bar      | MinType
+//	               | MaxType
+//	               | MinValue
+//	               | MaxValue
+//	               | BarBorderColor
+//	               | BarColor
+//	               | BarDirection
+//	               | BarOnly
+//	               | BarSolid
+//	 icon_set      | IconStyle
+//	               | ReverseIcons
+//	               | IconsOnly
+//	 formula       | Criteria
+//
+// The 'Criteria' parameter is used to set the criteria by which the cell data
+// will be evaluated. It has no default value. The most common criteria as
+// applied to {"type"："cell"} are:
+//
+//	between                  |
+//	not between              |
+//	equal to                 | ==
+//	not equal to             | !=
+//	greater than             | >
+//	less than                | <
+//	greater than or equal to | >=
+//	less than or equal to    | <=
+//
+// You can either use Excel's textual description strings, in the first column
+// above, or the more common symbolic alternatives.
+//
+// Additional criteria which are specific to other conditional format types are
+// shown in the relevant sections below.
+//
+// value: The value is generally used along with the criteria parameter to set
+// the rule by which the cell data will be evaluated:
+//
+//	err := f.SetConditionalFormat("Sheet1", "D1:D10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:     "cell",
+//	            Criteria: ">",
+//	            Format:   format,
 //	            Value:    "6",
 //	        },
 //	    },
@@ -2206,6 +2896,17 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 // cells. When this parameter is set then subsequent rules are not evaluated
 // if the current rule is true.
 func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFormatOptions) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	return f.setConditionalFormat(ws, sheet, rangeRef, opts)
+}
+
+// setConditionalFormat provides a function to append the conditional
+// formatting rules to the worksheet by given worksheet name, range reference
+// and format options.
+func (f *File) setConditionalFormat(ws *xlsxWorksheet, sheet, rangeRef string, opts []ConditionalFormatOptions) error {
 	drawContFmtFunc := map[string]func(p int, ct, GUID string, fmtCond *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule){
 		"cellIs":          drawCondFmtCellIs,
 		"top10":           drawCondFmtTop10,
@@ -2219,318 +2920,1026 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 		"iconSet":         drawCondFmtIconSet,
 	}
 
+	// Create a pseudo GUID for each unique rule.
+	var rules int
+	for _, cf := range ws.ConditionalFormatting {
+		rules += len(cf.CfRule)
+	}
+	GUID := fmt.Sprintf("{00000000-0000-0000-%04X-%012X}", f.getSheetID(sheet), rules)
+	var cfRule []*xlsxCfRule
+	for p, v := range opts {
+		var vt, ct string
+		var ok bool
+		// "type" is a required parameter, check for valid validation types.
+		vt, ok = validType[v.Type]
+		if ok {
+			// Check for valid criteria types.
+			ct, ok = criteriaType[v.Criteria]
+			if ok || vt == "expression" || vt == "iconSet" {
+				drawFunc, ok := drawContFmtFunc[vt]
+				if ok {
+					rule, x14rule := drawFunc(p, ct, GUID, &v)
+					if rule == nil {
+						return ErrParameterInvalid
+					}
+					if x14rule != nil {
+						if err := f.appendCfRule(ws, x14rule); err != nil {
+							return err
+						}
+						f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
+					}
+					cfRule = append(cfRule, rule)
+				}
+			}
+		}
+	}
+
+	ws.ConditionalFormatting = append(ws.ConditionalFormatting, &xlsxConditionalFormatting{
+		SQRef:  rangeRef,
+		CfRule: cfRule,
+	})
+	return nil
+}
+
+// appendCfRule provides a function to append rules to conditional formatting.
+func (f *File) appendCfRule(ws *xlsxWorksheet, rule *xlsxX14CfRule) error {
+	var (
+		err                                      error
+		idx                                      int
+		appendMode                               bool
+		decodeExtLst                             = new(decodeWorksheetExt)
+		condFmts                                 *xlsxX14ConditionalFormattings
+		decodeCondFmts                           *decodeX14ConditionalFormattings
+		ext                                      *xlsxWorksheetExt
+		condFmtBytes, condFmtsBytes, extLstBytes []byte
+	)
+	condFmtBytes, _ = xml.Marshal([]*xlsxX14ConditionalFormatting{
+		{XMLNSXM: NameSpaceSpreadSheetExcel2006Main.Value, CfRule: []*xlsxX14CfRule{rule}},
+	})
+	if ws.ExtLst != nil { // append mode ext
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+		for idx, ext = range decodeExtLst.Ext {
+			if ext.URI == ExtURIConditionalFormattings {
+				decodeCondFmts = new(decodeX14ConditionalFormattings)
+				_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decodeCondFmts)
+				if condFmts == nil {
+					condFmts = &xlsxX14ConditionalFormattings{}
+				}
+				condFmts.Content = decodeCondFmts.Content + string(condFmtBytes)
+				condFmtsBytes, _ = xml.Marshal(condFmts)
+				decodeExtLst.Ext[idx].Content = string(condFmtsBytes)
+				appendMode = true
+			}
+		}
+	}
+	if !appendMode {
+		condFmtsBytes, _ = xml.Marshal(&xlsxX14ConditionalFormattings{Content: string(condFmtBytes)})
+		decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxWorksheetExt{
+			URI: ExtURIConditionalFormattings, Content: string(condFmtsBytes),
+		})
+	}
+	sort.Slice(decodeExtLst.Ext, func(i, j int) bool {
+		return inStrSlice(extensionURIPriority, decodeExtLst.Ext[i].URI, false) <
+			inStrSlice(extensionURIPriority, decodeExtLst.Ext[j].URI, false)
+	})
+	extLstBytes, err = xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
+
+// extractCondFmtCellIs provides a function to extract conditional format
+// settings for cell value (include between, not between, equal, not equal,
+// greater than and less than) by given conditional formatting rule.
+func extractCondFmtCellIs(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "cell", Criteria: operatorType[c.Operator], Format: *c.DxfID}
+	if len(c.Formula) == 2 {
+		format.MinValue, format.MaxValue = c.Formula[0], c.Formula[1]
+		return format
+	}
+	format.Value = c.Formula[0]
+	return format
+}
+
+// extractCondFmtTop10 provides a function to extract conditional format
+// settings for top N (default is top 10) by given conditional formatting
+// rule.
+func extractCondFmtTop10(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	format := ConditionalFormatOptions{
+		StopIfTrue: c.StopIfTrue,
+		Type:       "top",
+		Criteria:   "=",
+		Format:     *c.DxfID,
+		Percent:    c.Percent,
+		Value:      strconv.Itoa(c.Rank),
+	}
+	if c.Bottom {
+		format.Type = "bottom"
+	}
+	return format
+}
+
+// extractCondFmtAboveAverage provides a function to extract conditional format
+// settings for above average and below average by given conditional formatting
+// rule.
+func extractCondFmtAboveAverage(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	return ConditionalFormatOptions{
+		StopIfTrue:   c.StopIfTrue,
+		Type:         "average",
+		Criteria:     "=",
+		Format:       *c.DxfID,
+		AboveAverage: *c.AboveAverage,
+	}
+}
+
+// extractCondFmtDuplicateUniqueValues provides a function to extract
+// conditional format settings for duplicate and unique values by given
+// conditional formatting rule.
+func extractCondFmtDuplicateUniqueValues(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	return ConditionalFormatOptions{
+		StopIfTrue: c.StopIfTrue,
+		Type: map[string]string{
+			"duplicateValues": "duplicate",
+			"uniqueValues":    "unique",
+		}[c.Type],
+		Criteria: "=",
+		Format:   *c.DxfID,
+	}
+}
+
+// extractCondFmtColorScale provides a function to extract conditional format
+// settings for color scale (include 2 color scale and 3 color scale) by given
+// conditional formatting rule.
+func extractCondFmtColorScale(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue}
+	format.Type, format.Criteria = "2_color_scale", "="
+	values := len(c.ColorScale.Cfvo)
+	colors := len(c.ColorScale.Color)
+	if colors > 1 && values > 1 {
+		format.MinType = c.ColorScale.Cfvo[0].Type
+		if c.ColorScale.Cfvo[0].Val != "0" {
+			format.MinValue = c.ColorScale.Cfvo[0].Val
+		}
+		format.MinColor = "#" + strings.TrimPrefix(strings.ToUpper(c.ColorScale.Color[0].RGB), "FF")
+		format.MaxType = c.ColorScale.Cfvo[1].Type
+		if c.ColorScale.Cfvo[1].Val != "0" {
+			format.MaxValue = c.ColorScale.Cfvo[1].Val
+		}
+		format.MaxColor = "#" + strings.TrimPrefix(strings.ToUpper(c.ColorScale.Color[1].RGB), "FF")
+	}
+	if colors == 3 {
+		format.Type = "3_color_scale"
+		format.MidType = c.ColorScale.Cfvo[1].Type
+		if c.ColorScale.Cfvo[1].Val != "0" {
+			format.MidValue = c.ColorScale.Cfvo[1].Val
+		}
+		format.MidColor = "#" + strings.TrimPrefix(strings.ToUpper(c.ColorScale.Color[1].RGB), "FF")
+		format.MaxType = c.ColorScale.Cfvo[2].Type
+		if c.ColorScale.Cfvo[2].Val != "0" {
+			format.MaxValue = c.ColorScale.Cfvo[2].Val
+		}
+		format.MaxColor = "#" + strings.TrimPrefix(strings.ToUpper(c.ColorScale.Color[2].RGB), "FF")
+	}
+	return format
+}
+
+// extractCondFmtDataBar provides a function to extract conditional format
+// settings for data bar by given conditional formatting rule.
+func extractCondFmtDataBar(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	format := ConditionalFormatOptions{Type: "data_bar", Criteria: "="}
+	if c.DataBar != nil {
+		format.StopIfTrue = c.StopIfTrue
+		format.MinType = c.DataBar.Cfvo[0].Type
+		format.MinValue = c.DataBar.Cfvo[0].Val
+		format.MaxType = c.DataBar.Cfvo[1].Type
+		format.MaxValue = c.DataBar.Cfvo[1].Val
+		format.BarColor = "#" + strings.TrimPrefix(strings.ToUpper(c.DataBar.Color[0].RGB), "FF")
+		if c.DataBar.ShowValue != nil {
+			format.BarOnly = !*c.DataBar.ShowValue
+		}
+	}
+	extractDataBarRule := func(condFmts []decodeX14ConditionalFormatting) {
+		for _, condFmt := range condFmts {
+			for _, rule := range condFmt.CfRule {
+				if rule.DataBar != nil {
+					format.BarSolid = !rule.DataBar.Gradient
+					format.BarDirection = rule.DataBar.Direction
+					if rule.DataBar.BorderColor != nil {
+						format.BarBorderColor = "#" + strings.TrimPrefix(strings.ToUpper(rule.DataBar.BorderColor.RGB), "FF")
+					}
+				}
+			}
+		}
+	}
+	extractExtLst := func(extLst *decodeWorksheetExt) {
+		for _, ext := range extLst.Ext {
+			if ext.URI == ExtURIConditionalFormattings {
+				decodeCondFmts := new(decodeX14ConditionalFormattings)
+				if err := xml.Unmarshal([]byte(ext.Content), &decodeCondFmts); err == nil {
+					var condFmts []decodeX14ConditionalFormatting
+					if err = xml.Unmarshal([]byte(decodeCondFmts.Content), &condFmts); err == nil {
+						extractDataBarRule(condFmts)
+					}
+				}
+			}
+		}
+	}
+	if c.ExtLst != nil {
+		ext := decodeX14ConditionalFormattingExt{}
+		if err := xml.Unmarshal([]byte(c.ExtLst.Ext), &ext); err == nil && extLst != nil {
+			decodeExtLst := new(decodeWorksheetExt)
+			if err = xml.Unmarshal([]byte("<extLst>"+extLst.Ext+"</extLst>"), decodeExtLst); err == nil {
+				extractExtLst(decodeExtLst)
+			}
+		}
+	}
+	return format
+}
+
+// extractCondFmtExp provides a function to extract conditional format settings
+// for expression by given conditional formatting rule.
+func extractCondFmtExp(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "formula", Format: *c.DxfID}
+	if len(c.Formula) > 0 {
+		format.Criteria = c.Formula[0]
+	}
+	return format
+}
+
+// extractCondFmtIconSet provides a function to extract conditional format
+// settings for icon sets by given conditional formatting rule.
+func extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	format := ConditionalFormatOptions{Type: "icon_set"}
+	if c.IconSet != nil {
+		if c.IconSet.ShowValue != nil {
+			format.IconsOnly = !*c.IconSet.ShowValue
+		}
+		format.IconStyle = c.IconSet.IconSet
+		format.ReverseIcons = c.IconSet.Reverse
+	}
+	return format
+}
+
+// GetConditionalFormats returns conditional format settings by given worksheet
+// name.
+func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalFormatOptions, error) {
+	extractContFmtFunc := map[string]func(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions{
+		"cellIs":          extractCondFmtCellIs,
+		"top10":           extractCondFmtTop10,
+		"aboveAverage":    extractCondFmtAboveAverage,
+		"duplicateValues": extractCondFmtDuplicateUniqueValues,
+		"uniqueValues":    extractCondFmtDuplicateUniqueValues,
+		"colorScale":      extractCondFmtColorScale,
+		"dataBar":         extractCondFmtDataBar,
+		"expression":      extractCondFmtExp,
+		"iconSet":         extractCondFmtIconSet,
+	}
+
+	conditionalFormats := make(map[string][]ConditionalFormatOptions)
+	ws, _, err := f.loadWorkSheet(sheet)
+	if err != nil {
+		return conditionalFormats, err
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		var opts []ConditionalFormatOptions
+		for _, cr := range cf.CfRule {
+			if extractFunc, ok := extractContFmtFunc[cr.Type]; ok {
+				opts = append(opts, extractFunc(cr, ws.ExtLst))
+			}
+		}
+		conditionalFormats[cf.SQRef] = opts
+	}
+	return conditionalFormats, err
+}
+
+// UnsetConditionalFormat provides a function to unset the conditional format
+// by given worksheet name and range reference.
+func (f *File) UnsetConditionalFormat(sheet, rangeRef string) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
-	// Create a pseudo GUID for each unique rule.
-	var rules int
-	for _, cf := range ws.ConditionalFormatting {
-		rules += len(cf.CfRule)
+	for i, cf := range ws.ConditionalFormatting {
+		if cf.SQRef == rangeRef {
+			ws.ConditionalFormatting = append(ws.ConditionalFormatting[:i], ws.ConditionalFormatting[i+1:]...)
+			return nil
+		}
 	}
-	GUID := fmt.Sprintf("{00000000-0000-0000-%04X-%012X}", f.getSheetID(sheet), rules)
-	var cfRule []*xlsxCfRule
-	for p, v := range opts {
-		var vt, ct string
-		var ok bool
-		// "type" is a required parameter, check for valid validation types.
-		vt, ok = validType[v.Type]
-		if ok {
-			// Check for valid criteria types.
-			ct, ok = criteriaType[v.Criteria]
-			if ok || vt == "expression" || vt == "iconSet" {
-				drawFunc, ok := drawContFmtFunc[vt]
-				if ok {
-					rule, x14rule := drawFunc(p, ct, GUID, &v)
-					if rule == nil {
-						return ErrParameterInvalid
-					}
-					if x14rule != nil {
-						if err = f.appendCfRule(ws, x14rule); err != nil {
-							return err
-						}
-						f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
-					}
-					cfRule = append(cfRule, rule)
+	return nil
+}
+
+// GetCellEffectiveStyle provides a function to get the effective style of the
+// cell by given worksheet name and cell reference, which is the style seen in
+// the spreadsheet application. The effective style combines the cell style,
+// or the row and column default style when the cell has no style, the custom
+// table style of the table containing the cell with the banding settings of
+// the table, and the formats of the matching conditional formatting rules. The
+// conditional formatting rules are evaluated in the priority order with the
+// formula calculation engine, and the color scales will be resolved to the
+// solid fill of the cell. Note that the built-in table styles are not defined
+// in the workbook and will be ignored, and the data bars and icon sets are
+// drawn over the cell without changing the cell format. For example, get the
+// effective fill color of the cell Sheet1!A1:
+//
+//	style, err := f.GetCellEffectiveStyle("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(style.Fill.Color)
+func (f *File) GetCellEffectiveStyle(sheet, cell string) (*Style, error) {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return nil, err
+	}
+	tableStyle, err := f.getCellTableStyle(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	if tableStyle != nil {
+		if err = f.mergeDxfStyle(tableStyle, style); err != nil {
+			return nil, err
+		}
+		style = tableStyle
+	}
+	condFmtStyles, err := f.getCellCondFmtStyles(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	for _, condFmtStyle := range condFmtStyles {
+		if err = f.mergeDxfStyle(style, condFmtStyle); err != nil {
+			return nil, err
+		}
+	}
+	return style, err
+}
+
+// mergeDxfStyle provides a function to merge the differential formatting
+// style into the style, the default font will be used if the style has no
+// font settings.
+func (f *File) mergeDxfStyle(style, patch *Style) error {
+	if patch.Font != nil && style.Font == nil {
+		font, err := f.readDefaultFont()
+		if err != nil {
+			return err
+		}
+		style.Font = extractFont(font)
+	}
+	mergeStyle(style, patch)
+	return nil
+}
+
+// getDxfStyle provides a function to get the style definition by given
+// differential formatting record index of the style sheet. The theme and
+// indexed colors of the fill will be resolved to the RGB colors.
+func (f *File) getDxfStyle(dxfID int) (*Style, error) {
+	f.mu.Lock()
+	s, err := f.loadStyles()
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Dxfs == nil || dxfID < 0 || dxfID >= len(s.Dxfs.Dxfs) {
+		return nil, err
+	}
+	var d dxf
+	if err = f.xmlNewDecoder(strings.NewReader("<dxf>" + s.Dxfs.Dxfs[dxfID].Dxf + "</dxf>")).
+		Decode(&d); err != nil && err != io.EOF {
+		return nil, err
+	}
+	style := &Style{}
+	if d.NumFmt != nil {
+		if _, ok := builtInNumFmt[d.NumFmt.NumFmtID]; ok || d.NumFmt.FormatCode == "" {
+			style.NumFmt = d.NumFmt.NumFmtID
+		} else {
+			style.CustomNumFmt = stringPtr(d.NumFmt.FormatCode)
+		}
+	}
+	if d.Font != nil {
+		style.Font = extractFont(d.Font)
+	}
+	if d.Fill != nil {
+		style.Fill = f.extractDxfFill(d.Fill)
+	}
+	if d.Border != nil {
+		style.Border = f.extractBorders(d.Border)
+	}
+	if d.Alignment != nil && *d.Alignment != (xlsxAlignment{}) {
+		style.Alignment = extractAlignment(d.Alignment)
+	}
+	if d.Protection != nil {
+		style.Protection = extractProtection(d.Protection)
+	}
+	return style, err
+}
+
+// extractDxfFill provides a function to extract the fill settings by given
+// fill definition of the differential formatting record. The solid fill color
+// of the differential formatting record is specified by the background color.
+func (f *File) extractDxfFill(fill *xlsxFill) Fill {
+	fl := f.extractFill(fill)
+	if fill.PatternFill == nil {
+		return fl
+	}
+	color := fill.PatternFill.FgColor
+	if fill.PatternFill.PatternType == "" || fill.PatternFill.PatternType == "solid" {
+		fl.Pattern = 1
+		if fill.PatternFill.BgColor != nil {
+			color = fill.PatternFill.BgColor
+		}
+	}
+	if color == nil {
+		color = fill.PatternFill.BgColor
+	}
+	if rgb := f.getColorRGB(color); len(rgb) == 8 {
+		fl.Color = []string{rgb[2:]}
+	}
+	return fl
+}
+
+// getCellTableStyle provides a function to get the style of the custom table
+// style by given worksheet name and cell reference, the table style elements
+// are applied by the banding settings of the table containing the cell. It
+// returns nil if the cell is not in a table with the custom table style.
+func (f *File) getCellTableStyle(sheet, cell string) (*Style, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	ws, _, err := f.loadWorkSheet(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	var rIDs []string
+	ws.mu.Lock()
+	if ws.TableParts != nil {
+		for _, tbl := range ws.TableParts.TableParts {
+			rIDs = append(rIDs, tbl.RID)
+		}
+	}
+	ws.mu.Unlock()
+	var tables []*xlsxTable
+	f.mu.Lock()
+	for _, rID := range rIDs {
+		target := f.getSheetRelationshipsTargetByID(sheet, rID)
+		t, err := f.loadTable(strings.ReplaceAll(target, "..", "xl"))
+		if err != nil {
+			f.mu.Unlock()
+			return nil, err
+		}
+		if t != nil {
+			tables = append(tables, t)
+		}
+	}
+	f.mu.Unlock()
+	for _, t := range tables {
+		coordinates, err := rangeRefToCoordinates(t.Ref)
+		if err != nil {
+			continue
+		}
+		_ = sortCoordinates(coordinates)
+		if col < coordinates[0] || col > coordinates[2] || row < coordinates[1] || row > coordinates[3] {
+			continue
+		}
+		if t.TableStyleInfo == nil || t.TableStyleInfo.Name == "" {
+			return nil, nil
+		}
+		return f.getTableStyleElementsStyle(t, coordinates, col, row)
+	}
+	return nil, nil
+}
+
+// getTableStyleElementsStyle provides a function to merge the formats of the
+// custom table style elements which applied to the cell by given table, table
+// coordinates and the cell coordinates.
+func (f *File) getTableStyleElementsStyle(t *xlsxTable, coordinates []int, col, row int) (*Style, error) {
+	f.mu.Lock()
+	s, err := f.loadStyles()
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	elements := map[string]xlsxTableStyleElement{}
+	s.mu.Lock()
+	if s.TableStyles != nil {
+		for _, tableStyle := range s.TableStyles.TableStyles {
+			if strings.EqualFold(tableStyle.Name, t.TableStyleInfo.Name) {
+				for _, element := range tableStyle.TableStyleElement {
+					elements[element.Type] = *element
 				}
+				break
+			}
+		}
+	}
+	s.mu.Unlock()
+	if len(elements) == 0 {
+		return nil, err
+	}
+	headerRows, totalsRows := 1, t.TotalsRowCount
+	if t.HeaderRowCount != nil {
+		headerRows = *t.HeaderRowCount
+	}
+	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	isHeader, isTotal := row < y1+headerRows, row > y2-totalsRows
+	isFirstCol, isLastCol := col == x1 && t.TableStyleInfo.ShowFirstColumn, col == x2 && t.TableStyleInfo.ShowLastColumn
+	isStripe := func(first, second string, offset int) bool {
+		size := func(typ string) int {
+			if element, ok := elements[typ]; ok && element.Size > 0 {
+				return element.Size
+			}
+			return 1
+		}
+		return offset%(size(first)+size(second)) < size(first)
+	}
+	applied := []string{"wholeTable"}
+	if !isHeader && !isTotal {
+		if t.TableStyleInfo.ShowColumnStripes {
+			applied = append(applied, map[bool]string{true: "firstColumnStripe", false: "secondColumnStripe"}[isStripe("firstColumnStripe", "secondColumnStripe", col-x1)])
+		}
+		if t.TableStyleInfo.ShowRowStripes {
+			applied = append(applied, map[bool]string{true: "firstRowStripe", false: "secondRowStripe"}[isStripe("firstRowStripe", "secondRowStripe", row-y1-headerRows)])
+		}
+	}
+	if isLastCol {
+		applied = append(applied, "lastColumn")
+	}
+	if isFirstCol {
+		applied = append(applied, "firstColumn")
+	}
+	if isHeader {
+		applied = append(applied, "headerRow")
+		if isFirstCol {
+			applied = append(applied, "firstHeaderCell")
+		}
+		if isLastCol {
+			applied = append(applied, "lastHeaderCell")
+		}
+	}
+	if isTotal {
+		applied = append(applied, "totalRow")
+		if isFirstCol {
+			applied = append(applied, "firstTotalCell")
+		}
+		if isLastCol {
+			applied = append(applied, "lastTotalCell")
+		}
+	}
+	style := &Style{}
+	for _, typ := range applied {
+		element, ok := elements[typ]
+		if !ok || element.DxfID == nil {
+			continue
+		}
+		dxfStyle, err := f.getDxfStyle(*element.DxfID)
+		if err != nil {
+			return nil, err
+		}
+		if dxfStyle != nil {
+			if err = f.mergeDxfStyle(style, dxfStyle); err != nil {
+				return nil, err
 			}
 		}
 	}
+	return style, err
+}
 
-	ws.ConditionalFormatting = append(ws.ConditionalFormatting, &xlsxConditionalFormatting{
-		SQRef:  rangeRef,
-		CfRule: cfRule,
-	})
-	return err
+// condFmtRule directly maps the conditional formatting rule with the range
+// coordinates of the conditional formatting.
+type condFmtRule struct {
+	rule   *xlsxCfRule
+	ranges [][]int
 }
 
-// appendCfRule provides a function to append rules to conditional formatting.
-func (f *File) appendCfRule(ws *xlsxWorksheet, rule *xlsxX14CfRule) error {
-	var (
-		err                                      error
-		idx                                      int
-		appendMode                               bool
-		decodeExtLst                             = new(decodeWorksheetExt)
-		condFmts                                 *xlsxX14ConditionalFormattings
-		decodeCondFmts                           *decodeX14ConditionalFormattings
-		ext                                      *xlsxWorksheetExt
-		condFmtBytes, condFmtsBytes, extLstBytes []byte
-	)
-	condFmtBytes, _ = xml.Marshal([]*xlsxX14ConditionalFormatting{
-		{XMLNSXM: NameSpaceSpreadSheetExcel2006Main.Value, CfRule: []*xlsxX14CfRule{rule}},
-	})
-	if ws.ExtLst != nil { // append mode ext
-		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
-			Decode(decodeExtLst); err != nil && err != io.EOF {
-			return err
-		}
-		for idx, ext = range decodeExtLst.Ext {
-			if ext.URI == ExtURIConditionalFormattings {
-				decodeCondFmts = new(decodeX14ConditionalFormattings)
-				_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decodeCondFmts)
-				if condFmts == nil {
-					condFmts = &xlsxX14ConditionalFormattings{}
-				}
-				condFmts.Content = decodeCondFmts.Content + string(condFmtBytes)
-				condFmtsBytes, _ = xml.Marshal(condFmts)
-				decodeExtLst.Ext[idx].Content = string(condFmtsBytes)
-				appendMode = true
+// getCellCondFmtStyles provides a function to get the formats of the matching
+// conditional formatting rules by given worksheet name and cell reference. The
+// rules are evaluated by the priority order, and the formats are returned in
+// the order of merging, the format of the rule with higher priority will be
+// merged later.
+func (f *File) getCellCondFmtStyles(sheet, cell string) ([]*Style, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	ws, _, err := f.loadWorkSheet(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	var rules []condFmtRule
+	ws.mu.Lock()
+	for _, cf := range ws.ConditionalFormatting {
+		var ranges [][]int
+		for _, ref := range strings.Fields(cf.SQRef) {
+			if !strings.Contains(ref, ":") {
+				ref += ":" + ref
 			}
+			coordinates, err := rangeRefToCoordinates(ref)
+			if err != nil {
+				continue
+			}
+			_ = sortCoordinates(coordinates)
+			ranges = append(ranges, coordinates)
+		}
+		if !isInCoordinatesRanges(ranges, col, row) {
+			continue
+		}
+		for _, rule := range cf.CfRule {
+			r := *rule
+			rules = append(rules, condFmtRule{rule: &r, ranges: ranges})
 		}
 	}
-	if !appendMode {
-		condFmtsBytes, _ = xml.Marshal(&xlsxX14ConditionalFormattings{Content: string(condFmtBytes)})
-		decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxWorksheetExt{
-			URI: ExtURIConditionalFormattings, Content: string(condFmtsBytes),
-		})
-	}
-	sort.Slice(decodeExtLst.Ext, func(i, j int) bool {
-		return inStrSlice(extensionURIPriority, decodeExtLst.Ext[i].URI, false) <
-			inStrSlice(extensionURIPriority, decodeExtLst.Ext[j].URI, false)
+	ws.mu.Unlock()
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].rule.Priority < rules[j].rule.Priority
 	})
-	extLstBytes, err = xml.Marshal(decodeExtLst)
-	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
-	return err
+	ctx := &calcContext{
+		entry:             fmt.Sprintf("%s!%s", sheet, cell),
+		maxCalcIterations: f.options.MaxCalcIterations,
+		iterations:        make(map[string]uint),
+		iterationsCache:   make(map[string]formulaArg),
+	}
+	var styles []*Style
+	for _, rule := range rules {
+		ok, style, err := f.evalCondFmtRule(ctx, sheet, cell, rule)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if style != nil {
+			styles = append([]*Style{style}, styles...)
+		}
+		if rule.rule.StopIfTrue {
+			break
+		}
+	}
+	return styles, err
 }
 
-// extractCondFmtCellIs provides a function to extract conditional format
-// settings for cell value (include between, not between, equal, not equal,
-// greater than and less than) by given conditional formatting rule.
-func extractCondFmtCellIs(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "cell", Criteria: operatorType[c.Operator], Format: *c.DxfID}
-	if len(c.Formula) == 2 {
-		format.MinValue, format.MaxValue = c.Formula[0], c.Formula[1]
-		return format
+// isInCoordinatesRanges provides a function to check if the cell coordinates
+// in the given range coordinates list.
+func isInCoordinatesRanges(ranges [][]int, col, row int) bool {
+	for _, coordinates := range ranges {
+		if col >= coordinates[0] && col <= coordinates[2] && row >= coordinates[1] && row <= coordinates[3] {
+			return true
+		}
 	}
-	format.Value = c.Formula[0]
-	return format
+	return false
 }
 
-// extractCondFmtTop10 provides a function to extract conditional format
-// settings for top N (default is top 10) by given conditional formatting
+// evalCondFmtRule provides a function to evaluate the conditional formatting
+// rule for the cell, and returns if the rule matched and the format of the
 // rule.
-func extractCondFmtTop10(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	format := ConditionalFormatOptions{
-		StopIfTrue: c.StopIfTrue,
-		Type:       "top",
-		Criteria:   "=",
-		Format:     *c.DxfID,
-		Percent:    c.Percent,
-		Value:      strconv.Itoa(c.Rank),
+func (f *File) evalCondFmtRule(ctx *calcContext, sheet, cell string, rule condFmtRule) (bool, *Style, error) {
+	value, err := f.cellResolver(ctx, sheet, cell)
+	if err != nil {
+		return false, nil, err
 	}
-	if c.Bottom {
-		format.Type = "bottom"
+	var ok bool
+	switch rule.rule.Type {
+	case "cellIs":
+		ok, err = f.evalCondFmtCellIs(ctx, sheet, cell, rule, value)
+	case "top10", "aboveAverage", "duplicateValues", "uniqueValues":
+		var values []formulaArg
+		if values, err = f.getCondFmtRangeValues(ctx, sheet, rule.ranges); err == nil {
+			ok = evalCondFmtRangeRule(rule.rule, value, values)
+		}
+	case "colorScale":
+		return f.evalCondFmtColorScale(ctx, sheet, cell, rule, value)
+	case "dataBar", "iconSet":
+		return isCondFmtNumber(value), nil, err
+	default:
+		if len(rule.rule.Formula) > 0 {
+			var arg formulaArg
+			if arg, err = f.evalCondFmtFormula(ctx, sheet, cell, rule, rule.rule.Formula[0]); err == nil {
+				ok = isCondFmtTrue(arg)
+			}
+		}
 	}
-	return format
-}
-
-// extractCondFmtAboveAverage provides a function to extract conditional format
-// settings for above average and below average by given conditional formatting
-// rule.
-func extractCondFmtAboveAverage(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	return ConditionalFormatOptions{
-		StopIfTrue:   c.StopIfTrue,
-		Type:         "average",
-		Criteria:     "=",
-		Format:       *c.DxfID,
-		AboveAverage: *c.AboveAverage,
+	if err != nil || !ok || rule.rule.DxfID == nil {
+		return ok, nil, err
 	}
+	style, err := f.getDxfStyle(*rule.rule.DxfID)
+	return ok, style, err
 }
 
-// extractCondFmtDuplicateUniqueValues provides a function to extract
-// conditional format settings for duplicate and unique values by given
-// conditional formatting rule.
-func extractCondFmtDuplicateUniqueValues(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	return ConditionalFormatOptions{
-		StopIfTrue: c.StopIfTrue,
-		Type: map[string]string{
-			"duplicateValues": "duplicate",
-			"uniqueValues":    "unique",
-		}[c.Type],
-		Criteria: "=",
-		Format:   *c.DxfID,
+// evalCondFmtFormula provides a function to evaluate the formula of the
+// conditional formatting rule for the cell, the relative references in the
+// formula are relative to the top-left cell of the conditional formatting
+// range, and will be shifted to the cell.
+func (f *File) evalCondFmtFormula(ctx *calcContext, sheet, cell string, rule condFmtRule, formula string) (formulaArg, error) {
+	col, row, _ := CellNameToCoordinates(cell)
+	orig := []byte(strings.TrimPrefix(formula, "="))
+	res, start := parseSharedFormula(col-rule.ranges[0][0], row-rule.ranges[0][1], orig)
+	if start < len(orig) {
+		res += string(orig[start:])
 	}
+	ps := efp.ExcelParser()
+	tokens := ps.Parse(res)
+	if tokens == nil {
+		return newEmptyFormulaArg(), nil
+	}
+	arg, err := f.evalInfixExp(ctx, sheet, cell, tokens)
+	if list := arg.ToList(); len(list) > 0 {
+		arg = list[0]
+	}
+	return arg, err
 }
 
-// extractCondFmtColorScale provides a function to extract conditional format
-// settings for color scale (include 2 color scale and 3 color scale) by given
-// conditional formatting rule.
-func extractCondFmtColorScale(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue}
-	format.Type, format.Criteria = "2_color_scale", "="
-	values := len(c.ColorScale.Cfvo)
-	colors := len(c.ColorScale.Color)
-	if colors > 1 && values > 1 {
-		format.MinType = c.ColorScale.Cfvo[0].Type
-		if c.ColorScale.Cfvo[0].Val != "0" {
-			format.MinValue = c.ColorScale.Cfvo[0].Val
-		}
-		format.MinColor = "#" + strings.TrimPrefix(strings.ToUpper(c.ColorScale.Color[0].RGB), "FF")
-		format.MaxType = c.ColorScale.Cfvo[1].Type
-		if c.ColorScale.Cfvo[1].Val != "0" {
-			format.MaxValue = c.ColorScale.Cfvo[1].Val
+// evalCondFmtCellIs provides a function to evaluate the cell value
+// conditional formatting rule by given cell value.
+func (f *File) evalCondFmtCellIs(ctx *calcContext, sheet, cell string, rule condFmtRule, value formulaArg) (bool, error) {
+	var operands []int
+	for _, formula := range rule.rule.Formula {
+		arg, err := f.evalCondFmtFormula(ctx, sheet, cell, rule, formula)
+		if err != nil {
+			return false, err
 		}
-		format.MaxColor = "#" + strings.TrimPrefix(strings.ToUpper(c.ColorScale.Color[1].RGB), "FF")
+		operands = append(operands, compareCondFmtValue(value, arg))
 	}
-	if colors == 3 {
-		format.Type = "3_color_scale"
-		format.MidType = c.ColorScale.Cfvo[1].Type
-		if c.ColorScale.Cfvo[1].Val != "0" {
-			format.MidValue = c.ColorScale.Cfvo[1].Val
-		}
-		format.MidColor = "#" + strings.TrimPrefix(strings.ToUpper(c.ColorScale.Color[1].RGB), "FF")
-		format.MaxType = c.ColorScale.Cfvo[2].Type
-		if c.ColorScale.Cfvo[2].Val != "0" {
-			format.MaxValue = c.ColorScale.Cfvo[2].Val
+	if len(operands) == 0 {
+		return false, nil
+	}
+	switch rule.rule.Operator {
+	case "between", "notBetween":
+		if len(operands) < 2 {
+			return false, nil
 		}
-		format.MaxColor = "#" + strings.TrimPrefix(strings.ToUpper(c.ColorScale.Color[2].RGB), "FF")
+		between := operands[0] >= 0 && operands[1] <= 0 || operands[0] <= 0 && operands[1] >= 0
+		return between == (rule.rule.Operator == "between"), nil
+	case "equal":
+		return operands[0] == 0, nil
+	case "notEqual":
+		return operands[0] != 0, nil
+	case "greaterThan":
+		return operands[0] > 0, nil
+	case "greaterThanOrEqual":
+		return operands[0] >= 0, nil
+	case "lessThan":
+		return operands[0] < 0, nil
+	case "lessThanOrEqual":
+		return operands[0] <= 0, nil
 	}
-	return format
+	return false, nil
 }
 
-// extractCondFmtDataBar provides a function to extract conditional format
-// settings for data bar by given conditional formatting rule.
-func extractCondFmtDataBar(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	format := ConditionalFormatOptions{Type: "data_bar", Criteria: "="}
-	if c.DataBar != nil {
-		format.StopIfTrue = c.StopIfTrue
-		format.MinType = c.DataBar.Cfvo[0].Type
-		format.MinValue = c.DataBar.Cfvo[0].Val
-		format.MaxType = c.DataBar.Cfvo[1].Type
-		format.MaxValue = c.DataBar.Cfvo[1].Val
-		format.BarColor = "#" + strings.TrimPrefix(strings.ToUpper(c.DataBar.Color[0].RGB), "FF")
-		if c.DataBar.ShowValue != nil {
-			format.BarOnly = !*c.DataBar.ShowValue
-		}
-	}
-	extractDataBarRule := func(condFmts []decodeX14ConditionalFormatting) {
-		for _, condFmt := range condFmts {
-			for _, rule := range condFmt.CfRule {
-				if rule.DataBar != nil {
-					format.BarSolid = !rule.DataBar.Gradient
-					format.BarDirection = rule.DataBar.Direction
-					if rule.DataBar.BorderColor != nil {
-						format.BarBorderColor = "#" + strings.TrimPrefix(strings.ToUpper(rule.DataBar.BorderColor.RGB), "FF")
-					}
-				}
+// compareCondFmtValue provides a function to compare the cell value with the
+// operand of the conditional formatting rule. It returns -1 if the value is
+// less than the operand, 0 if they are equal, or 1 if the value is greater
+// than the operand. The numbers are less than the text, and the text is less
+// than the logical values, the text is compared case-insensitively, and the
+// empty value is treated as 0 or the empty text.
+func compareCondFmtValue(lhs, rhs formulaArg) int {
+	rank := func(arg, other formulaArg) (int, formulaArg) {
+		switch arg.Type {
+		case ArgNumber:
+			if arg.Boolean {
+				return 2, arg
 			}
+			return 0, arg
+		case ArgString:
+			return 1, arg
 		}
-	}
-	extractExtLst := func(extLst *decodeWorksheetExt) {
-		for _, ext := range extLst.Ext {
-			if ext.URI == ExtURIConditionalFormattings {
-				decodeCondFmts := new(decodeX14ConditionalFormattings)
-				if err := xml.Unmarshal([]byte(ext.Content), &decodeCondFmts); err == nil {
-					var condFmts []decodeX14ConditionalFormatting
-					if err = xml.Unmarshal([]byte(decodeCondFmts.Content), &condFmts); err == nil {
-						extractDataBarRule(condFmts)
-					}
-				}
-			}
+		if other.Type == ArgString {
+			return 1, newStringFormulaArg("")
 		}
+		return 0, newNumberFormulaArg(0)
 	}
-	if c.ExtLst != nil {
-		ext := decodeX14ConditionalFormattingExt{}
-		if err := xml.Unmarshal([]byte(c.ExtLst.Ext), &ext); err == nil && extLst != nil {
-			decodeExtLst := new(decodeWorksheetExt)
-			if err = xml.Unmarshal([]byte("<extLst>"+extLst.Ext+"</extLst>"), decodeExtLst); err == nil {
-				extractExtLst(decodeExtLst)
-			}
-		}
+	lRank, l := rank(lhs, rhs)
+	rRank, r := rank(rhs, lhs)
+	if lRank != rRank {
+		return map[bool]int{true: -1, false: 1}[lRank < rRank]
 	}
-	return format
+	if lRank == 1 {
+		return strings.Compare(strings.ToLower(l.String), strings.ToLower(r.String))
+	}
+	if l.Number < r.Number {
+		return -1
+	}
+	if l.Number > r.Number {
+		return 1
+	}
+	return 0
 }
 
-// extractCondFmtExp provides a function to extract conditional format settings
-// for expression by given conditional formatting rule.
-func extractCondFmtExp(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "formula", Format: *c.DxfID}
-	if len(c.Formula) > 0 {
-		format.Criteria = c.Formula[0]
+// isCondFmtNumber provides a function to check if the given value is a
+// number, the logical value is not a number in the conditional formatting.
+func isCondFmtNumber(arg formulaArg) bool {
+	return arg.Type == ArgNumber && !arg.Boolean
+}
+
+// isCondFmtTrue provides a function to check if the result of the formula in
+// the conditional formatting rule is true.
+func isCondFmtTrue(arg formulaArg) bool {
+	switch arg.Type {
+	case ArgNumber:
+		return arg.Number != 0
+	case ArgString:
+		b, err := strconv.ParseBool(arg.String)
+		return err == nil && b
+	}
+	return false
+}
+
+// getCondFmtRangeValues provides a function to get the values of the cells in
+// the conditional formatting range, the cells out of the used range of the
+// worksheet will be ignored.
+func (f *File) getCondFmtRangeValues(ctx *calcContext, sheet string, ranges [][]int) ([]formulaArg, error) {
+	f.mu.Lock()
+	ws, _, err := f.loadWorkSheet(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	ws.mu.Lock()
+	maxRow, maxCol := len(ws.SheetData.Row), 0
+	for _, r := range ws.SheetData.Row {
+		if len(r.C) > maxCol {
+			maxCol = len(r.C)
+		}
+	}
+	ws.mu.Unlock()
+	var values []formulaArg
+	for _, coordinates := range ranges {
+		for row := coordinates[1]; row <= coordinates[3] && row <= maxRow; row++ {
+			for col := coordinates[0]; col <= coordinates[2] && col <= maxCol; col++ {
+				cell, _ := CoordinatesToCellName(col, row)
+				value, err := f.cellResolver(ctx, sheet, cell)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+			}
+		}
 	}
-	return format
+	return values, err
 }
 
-// extractCondFmtIconSet provides a function to extract conditional format
-// settings for icon sets by given conditional formatting rule.
-func extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	format := ConditionalFormatOptions{Type: "icon_set"}
-	if c.IconSet != nil {
-		if c.IconSet.ShowValue != nil {
-			format.IconsOnly = !*c.IconSet.ShowValue
+// getCondFmtNumbers provides a function to get the numbers from the given
+// values of the conditional formatting range.
+func getCondFmtNumbers(values []formulaArg) []float64 {
+	var numbers []float64
+	for _, value := range values {
+		if isCondFmtNumber(value) {
+			numbers = append(numbers, value.Number)
 		}
-		format.IconStyle = c.IconSet.IconSet
-		format.ReverseIcons = c.IconSet.Reverse
 	}
-	return format
+	return numbers
 }
 
-// GetConditionalFormats returns conditional format settings by given worksheet
-// name.
-func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalFormatOptions, error) {
-	extractContFmtFunc := map[string]func(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions{
-		"cellIs":          extractCondFmtCellIs,
-		"top10":           extractCondFmtTop10,
-		"aboveAverage":    extractCondFmtAboveAverage,
-		"duplicateValues": extractCondFmtDuplicateUniqueValues,
-		"uniqueValues":    extractCondFmtDuplicateUniqueValues,
-		"colorScale":      extractCondFmtColorScale,
-		"dataBar":         extractCondFmtDataBar,
-		"expression":      extractCondFmtExp,
-		"iconSet":         extractCondFmtIconSet,
+// evalCondFmtRangeRule provides a function to evaluate the top N, above
+// average, duplicate values and unique values conditional formatting rules by
+// given cell value and the values of the conditional formatting range.
+func evalCondFmtRangeRule(rule *xlsxCfRule, value formulaArg, values []formulaArg) bool {
+	if rule.Type == "duplicateValues" || rule.Type == "uniqueValues" {
+		if value.Type == ArgEmpty || value.Value() == "" {
+			return false
+		}
+		var count int
+		for _, v := range values {
+			if strings.EqualFold(v.Value(), value.Value()) && isCondFmtNumber(v) == isCondFmtNumber(value) {
+				count++
+			}
+		}
+		return (count > 1) == (rule.Type == "duplicateValues")
+	}
+	numbers := getCondFmtNumbers(values)
+	if !isCondFmtNumber(value) || len(numbers) == 0 {
+		return false
+	}
+	if rule.Type == "top10" {
+		rank := rule.Rank
+		if rule.Percent {
+			rank = len(numbers) * rule.Rank / 100
+		}
+		if rank < 1 {
+			rank = 1
+		}
+		if rank > len(numbers) {
+			rank = len(numbers)
+		}
+		sort.Float64s(numbers)
+		if rule.Bottom {
+			return value.Number <= numbers[rank-1]
+		}
+		return value.Number >= numbers[len(numbers)-rank]
+	}
+	var sum, squares float64
+	for _, number := range numbers {
+		sum += number
+	}
+	average := sum / float64(len(numbers))
+	for _, number := range numbers {
+		squares += (number - average) * (number - average)
+	}
+	var threshold float64
+	if rule.StdDev > 0 && len(numbers) > 1 {
+		threshold = float64(rule.StdDev) * math.Sqrt(squares/float64(len(numbers)-1))
+	}
+	if rule.AboveAverage == nil || *rule.AboveAverage {
+		return value.Number > average+threshold || rule.EqualAverage && value.Number == average+threshold
 	}
+	return value.Number < average-threshold || rule.EqualAverage && value.Number == average-threshold
+}
 
-	conditionalFormats := make(map[string][]ConditionalFormatOptions)
-	ws, err := f.workSheetReader(sheet)
+// evalCondFmtColorScale provides a function to evaluate the color scale
+// conditional formatting rule by given cell value, and returns the solid fill
+// format with the interpolated color of the cell.
+func (f *File) evalCondFmtColorScale(ctx *calcContext, sheet, cell string, rule condFmtRule, value formulaArg) (bool, *Style, error) {
+	colorScale := rule.rule.ColorScale
+	if !isCondFmtNumber(value) || colorScale == nil || len(colorScale.Cfvo) < 2 || len(colorScale.Cfvo) != len(colorScale.Color) {
+		return false, nil, nil
+	}
+	values, err := f.getCondFmtRangeValues(ctx, sheet, rule.ranges)
 	if err != nil {
-		return conditionalFormats, err
+		return false, nil, err
 	}
-	for _, cf := range ws.ConditionalFormatting {
-		var opts []ConditionalFormatOptions
-		for _, cr := range cf.CfRule {
-			if extractFunc, ok := extractContFmtFunc[cr.Type]; ok {
-				opts = append(opts, extractFunc(cr, ws.ExtLst))
+	numbers := getCondFmtNumbers(values)
+	sort.Float64s(numbers)
+	thresholds := make([]float64, len(colorScale.Cfvo))
+	for idx, cfvo := range colorScale.Cfvo {
+		if thresholds[idx], err = f.getCondFmtCfvoValue(ctx, sheet, cell, rule, cfvo, numbers); err != nil {
+			return false, nil, err
+		}
+	}
+	colors := make([][3]float64, len(colorScale.Color))
+	for idx, clr := range colorScale.Color {
+		rgb := f.getColorRGB(clr)
+		if len(rgb) != 8 {
+			return false, nil, err
+		}
+		for i := 0; i < 3; i++ {
+			c, _ := strconv.ParseUint(rgb[2+i*2:4+i*2], 16, 8)
+			colors[idx][i] = float64(c)
+		}
+	}
+	color := colors[len(colors)-1]
+	if value.Number <= thresholds[0] {
+		color = colors[0]
+	}
+	for idx := 1; idx < len(thresholds); idx++ {
+		if value.Number > thresholds[idx-1] && value.Number <= thresholds[idx] {
+			ratio := (value.Number - thresholds[idx-1]) / (thresholds[idx] - thresholds[idx-1])
+			for i := 0; i < 3; i++ {
+				color[i] = colors[idx-1][i] + (colors[idx][i]-colors[idx-1][i])*ratio
 			}
+			break
 		}
-		conditionalFormats[cf.SQRef] = opts
 	}
-	return conditionalFormats, err
+	return true, &Style{Fill: Fill{
+		Type: "pattern", Pattern: 1,
+		Color: []string{fmt.Sprintf("%02X%02X%02X", int(math.Round(color[0])), int(math.Round(color[1])), int(math.Round(color[2])))},
+	}}, err
 }
 
-// UnsetConditionalFormat provides a function to unset the conditional format
-// by given worksheet name and range reference.
-func (f *File) UnsetConditionalFormat(sheet, rangeRef string) error {
-	ws, err := f.workSheetReader(sheet)
+// getCondFmtCfvoValue provides a function to get the threshold value of the
+// conditional format value object by given sorted numbers of the conditional
+// formatting range.
+func (f *File) getCondFmtCfvoValue(ctx *calcContext, sheet, cell string, rule condFmtRule, cfvo *xlsxCfvo, numbers []float64) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, nil
+	}
+	minimum, maximum := numbers[0], numbers[len(numbers)-1]
+	switch cfvo.Type {
+	case "min":
+		return minimum, nil
+	case "max":
+		return maximum, nil
+	}
+	arg, err := f.evalCondFmtFormula(ctx, sheet, cell, rule, cfvo.Val)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	for i, cf := range ws.ConditionalFormatting {
-		if cf.SQRef == rangeRef {
-			ws.ConditionalFormatting = append(ws.ConditionalFormatting[:i], ws.ConditionalFormatting[i+1:]...)
-			return nil
+	val := arg.ToNumber().Number
+	switch cfvo.Type {
+	case "percent":
+		return minimum + (maximum-minimum)*val/100, err
+	case "percentile":
+		rank := val / 100 * float64(len(numbers)-1)
+		if rank <= 0 {
+			return minimum, err
+		}
+		if idx := int(rank); idx < len(numbers)-1 {
+			return numbers[idx] + (numbers[idx+1]-numbers[idx])*(rank-float64(idx)), err
 		}
+		return maximum, err
 	}
-	return nil
+	return val, err
 }
 
 // drawCondFmtCellIs provides a function to create conditional formatting rule
@@ -2778,3 +4187,532 @@ func ThemeColor(baseColor string, tint float64) string {
 	br, bg, bb := HSLToRGB(h, s, l)
 	return fmt.Sprintf("FF%02X%02X%02X", br, bg, bb)
 }
+
+// getThemeColorList provides a function to get the base colors of the theme
+// color scheme, the colors are ordered by the theme color index used in the
+// spreadsheet, the light and dark colors are swapped from the order of the
+// color scheme.
+func (f *File) getThemeColorList() []string {
+	theme := f.Theme
+	if theme == nil {
+		if theme, _ = f.themeReader(); theme == nil {
+			return nil
+		}
+	}
+	clrScheme := theme.ThemeElements.ClrScheme
+	var colors []string
+	for _, clr := range []xlsxCTColor{
+		clrScheme.Lt1, clrScheme.Dk1, clrScheme.Lt2, clrScheme.Dk2,
+		clrScheme.Accent1, clrScheme.Accent2, clrScheme.Accent3,
+		clrScheme.Accent4, clrScheme.Accent5, clrScheme.Accent6,
+		clrScheme.Hlink, clrScheme.FolHlink,
+	} {
+		colors = append(colors, getThemeColor(clr))
+	}
+	return colors
+}
+
+// GetColorRGB provides a function to resolve the given color settings to the
+// concrete ARGB hex color, for example "FF4472C4". The theme color will be
+// resolved by the color scheme of the workbook theme, the indexed color will
+// be resolved by the legacy color palette, including the modified palette of
+// the workbook, and the automatic color will be resolved to the system
+// foreground color black. The tint will be applied to the resolved color. An
+// empty string will be returned if the color can't be resolved, for example
+// the theme color index is out of range. For example, get the ARGB hex color
+// of the font in the cell style:
+//
+//	style, err := f.GetStyle(styleID)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if style.Font != nil {
+//	    color, err := f.GetColorRGB(&excelize.Color{
+//	        RGB:   style.Font.Color,
+//	        Theme: style.Font.ColorTheme,
+//	        Tint:  style.Font.ColorTint,
+//	    })
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	    fmt.Println(color)
+//	}
+func (f *File) GetColorRGB(clr *Color) (string, error) {
+	if clr == nil {
+		return "", ErrParameterInvalid
+	}
+	f.mu.Lock()
+	s, err := f.loadStyles()
+	if err != nil {
+		f.mu.Unlock()
+		return "", err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if clr.Auto {
+		return "FF" + IndexedColorMapping[64], err
+	}
+	return f.getColorRGB(&xlsxColor{
+		RGB:     strings.TrimPrefix(clr.RGB, "#"),
+		Indexed: clr.Indexed,
+		Theme:   clr.Theme,
+		Tint:    clr.Tint,
+	}), err
+}
+
+// getIndexedColorList provides a function to get the legacy indexed color
+// palette in RRGGBB hex format, the modified palette in the style sheet will
+// override the colors of the indexes 0 to 63 in the default palette.
+func (f *File) getIndexedColorList() []string {
+	if f.Styles == nil || f.Styles.Colors == nil || f.Styles.Colors.IndexedColors == nil {
+		return IndexedColorMapping
+	}
+	colors := append([]string{}, IndexedColorMapping...)
+	for idx, clr := range f.Styles.Colors.IndexedColors.RgbColor {
+		if idx >= 64 {
+			break
+		}
+		RGB := strings.ToUpper(clr.RGB)
+		if len(RGB) == 8 {
+			RGB = RGB[2:]
+		}
+		if len(RGB) == 6 {
+			colors[idx] = RGB
+		}
+	}
+	return colors
+}
+
+// getColorRGB provides a function to get the ARGB hex color by given color
+// settings, the theme color with tint, indexed color and RGB color will be
+// resolved. The empty string will be returned for the automatic color or the
+// color can not be resolved.
+func (f *File) getColorRGB(clr *xlsxColor) string {
+	if clr == nil || clr.Auto {
+		return ""
+	}
+	if clr.RGB != "" {
+		RGB := strings.ToUpper(clr.RGB)
+		if len(RGB) == 6 {
+			RGB = "FF" + RGB
+		}
+		if len(RGB) == 8 && clr.Tint != 0 {
+			return RGB[:2] + ThemeColor(RGB[2:], clr.Tint)[2:]
+		}
+		return RGB
+	}
+	if clr.Theme != nil {
+		if colors := f.getThemeColorList(); *clr.Theme >= 0 && *clr.Theme < len(colors) && len(colors[*clr.Theme]) == 6 {
+			return ThemeColor(colors[*clr.Theme], clr.Tint)
+		}
+		return ""
+	}
+	if clr.Indexed != nil {
+		if colors := f.getIndexedColorList(); *clr.Indexed >= 0 && *clr.Indexed < len(colors) {
+			return ThemeColor(colors[*clr.Indexed], clr.Tint)
+		}
+	}
+	return ""
+}
+
+// CompactStyles provides a function to remove the unused cell formats, fonts,
+// fills, borders, custom number formats and differential formats from the
+// workbook, and update the style indexes referenced by the cells, rows,
+// columns, conditional formats and tables of all worksheets. The named cell
+// styles and the custom table styles will be kept with their formats. The
+// differential formats will be kept when they are referenced by the pivot
+// tables or the slicer and timeline styles. The style indexes in the
+// worksheets written by the stream writer can't be updated, so this function
+// will return an error if the workbook has any stream writers. For example,
+// remove the unused styles before saving the workbook:
+//
+//	if err := f.CompactStyles(); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.SaveAs("Book1.xlsx"); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) CompactStyles() error {
+	var (
+		worksheets []*xlsxWorksheet
+		paths      []string
+		keepDxfs   bool
+		xfs, dxfs  = map[int]bool{0: true}, map[int]bool{}
+		mark       = func(refs map[int]bool) func(id *int) {
+			return func(id *int) { refs[*id] = true }
+		}
+	)
+	f.mu.Lock()
+	streams := len(f.streams)
+	f.mu.Unlock()
+	if streams > 0 {
+		return ErrStreamCompactStyles
+	}
+	for _, sheet := range f.GetSheetList() {
+		f.mu.Lock()
+		if name, ok := f.getSheetXMLPath(sheet); !ok || !isWorksheetPath(name) {
+			f.mu.Unlock()
+			continue
+		}
+		ws, name, err := f.loadWorkSheet(sheet)
+		f.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		ws.mu.Lock()
+		ws.rangeStyleRefs(mark(xfs), mark(dxfs))
+		if ws.ExtLst != nil && strings.Contains(ws.ExtLst.Ext, "dxfId") {
+			keepDxfs = true
+		}
+		ws.mu.Unlock()
+		worksheets, paths = append(worksheets, ws), append(paths, name)
+	}
+	tables, err := f.getTableParts()
+	if err != nil {
+		return err
+	}
+	for _, t := range tables {
+		t.rangeDxfRefs(mark(dxfs))
+	}
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/pivotTables/pivotTable") && bytes.Contains(v.([]byte), []byte("dxfId")) {
+			keepDxfs = true
+		}
+		return !keepDxfs
+	})
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	if s.ExtLst != nil && strings.Contains(s.ExtLst.Ext, "dxfId") {
+		keepDxfs = true
+	}
+	xfIndexes := s.compactCellXfs(xfs)
+	dxfIndexes, numFmts := f.compactDxfs(s, dxfs, keepDxfs)
+	s.compactFormats(numFmts)
+	s.mu.Unlock()
+	for idx, ws := range worksheets {
+		var changed bool
+		remap := func(indexes []int) func(id *int) {
+			return func(id *int) {
+				styleID := remapStyleIndex(indexes, *id)
+				changed, *id = changed || styleID != *id, styleID
+			}
+		}
+		ws.mu.Lock()
+		ws.rangeStyleRefs(remap(xfIndexes), remap(dxfIndexes))
+		ws.mu.Unlock()
+		if changed {
+			f.setPartModified(paths[idx])
+		}
+	}
+	for name, t := range tables {
+		var changed bool
+		t.rangeDxfRefs(func(id *int) {
+			dxfID := remapStyleIndex(dxfIndexes, *id)
+			changed, *id = changed || dxfID != *id, dxfID
+		})
+		if changed {
+			table, _ := xml.Marshal(t)
+			f.saveFileList(name, table)
+		}
+	}
+	return err
+}
+
+// rangeStyleRefs provides a function to call the given functions with the
+// cell format indexes referenced by the cells, rows and columns, and the
+// differential format indexes referenced by the conditional formats and the
+// auto filter of the worksheet.
+func (ws *xlsxWorksheet) rangeStyleRefs(xfFn, dxfFn func(id *int)) {
+	if ws.Cols != nil {
+		for idx := range ws.Cols.Col {
+			xfFn(&ws.Cols.Col[idx].Style)
+		}
+	}
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		xfFn(&row.S)
+		for colIdx := range row.C {
+			xfFn(&row.C[colIdx].S)
+		}
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			if rule.DxfID != nil {
+				dxfFn(rule.DxfID)
+			}
+		}
+	}
+	ws.AutoFilter.rangeDxfRefs(dxfFn)
+}
+
+// rangeDxfRefs provides a function to call the given function with the
+// differential format indexes referenced by the color filters of the auto
+// filter.
+func (af *xlsxAutoFilter) rangeDxfRefs(fn func(id *int)) {
+	if af == nil {
+		return
+	}
+	for _, filterColumn := range af.FilterColumn {
+		if filterColumn.ColorFilter != nil {
+			fn(&filterColumn.ColorFilter.DxfID)
+		}
+	}
+}
+
+// rangeDxfRefs provides a function to call the given function with the
+// differential format indexes referenced by the table, the table columns and
+// the auto filter of the table.
+func (t *xlsxTable) rangeDxfRefs(fn func(id *int)) {
+	for _, dxfID := range []*int{
+		&t.DataDxfID, &t.HeaderRowBorderDxfID, &t.HeaderRowDxfID,
+		&t.TableBorderDxfID, &t.TotalsRowBorderDxfID, &t.TotalsRowDxfID,
+	} {
+		if *dxfID != 0 {
+			fn(dxfID)
+		}
+	}
+	if t.TableColumns != nil {
+		for _, column := range t.TableColumns.TableColumn {
+			for _, dxfID := range []*int{&column.DataDxfID, &column.HeaderRowDxfID, &column.TotalsRowDxfID} {
+				if *dxfID != 0 {
+					fn(dxfID)
+				}
+			}
+		}
+	}
+	t.AutoFilter.rangeDxfRefs(fn)
+}
+
+// getTableParts provides a function to get all tables of the workbook, the
+// key of the returned map is the path of the table part.
+func (f *File) getTableParts() (map[string]*xlsxTable, error) {
+	var (
+		err    error
+		tables = map[string]*xlsxTable{}
+	)
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/tables/table") {
+			var t xlsxTable
+			if decodeErr := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
+				Decode(&t); decodeErr != nil && decodeErr != io.EOF {
+				err = decodeErr
+				return false
+			}
+			tables[k.(string)] = &t
+		}
+		return true
+	})
+	return tables, err
+}
+
+// getCompactIndexes provides a function to get the new index of each element
+// after removing the unreferenced elements by given count of the elements and
+// the referenced element indexes, the new index of the removed element is -1.
+func getCompactIndexes(count int, refs map[int]bool) []int {
+	indexes, next := make([]int, count), 0
+	for idx := range indexes {
+		if indexes[idx] = -1; refs[idx] {
+			indexes[idx], next = next, next+1
+		}
+	}
+	return indexes
+}
+
+// remapStyleIndex provides a function to get the new index of the element by
+// given new indexes and the original index, the invalid index will be mapped
+// to the first element.
+func remapStyleIndex(indexes []int, idx int) int {
+	if idx < 0 || idx >= len(indexes) || indexes[idx] == -1 {
+		return 0
+	}
+	return indexes[idx]
+}
+
+// compactXfs provides a function to remove the removed formatting records by
+// given formatting records and the new indexes.
+func compactXfs(xfs []xlsxXf, indexes []int) []xlsxXf {
+	var list []xlsxXf
+	for idx, xf := range xfs {
+		if indexes[idx] != -1 {
+			list = append(list, xf)
+		}
+	}
+	return list
+}
+
+// compactCellXfs provides a function to remove the unreferenced cell formats
+// and the master formatting records which are neither used by the named cell
+// styles nor the cell formats, and returns the new indexes of the cell
+// formats.
+func (s *xlsxStyleSheet) compactCellXfs(xfs map[int]bool) []int {
+	if s.CellXfs == nil {
+		return nil
+	}
+	xfIndexes := getCompactIndexes(len(s.CellXfs.Xf), xfs)
+	s.CellXfs.Xf = compactXfs(s.CellXfs.Xf, xfIndexes)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	if s.CellStyleXfs == nil {
+		return xfIndexes
+	}
+	styleXfs := map[int]bool{0: true}
+	if s.CellStyles != nil {
+		for _, cellStyle := range s.CellStyles.CellStyle {
+			styleXfs[cellStyle.XfID] = true
+		}
+	}
+	for _, xf := range s.CellXfs.Xf {
+		if xf.XfID != nil {
+			styleXfs[*xf.XfID] = true
+		}
+	}
+	styleXfIndexes := getCompactIndexes(len(s.CellStyleXfs.Xf), styleXfs)
+	s.CellStyleXfs.Xf = compactXfs(s.CellStyleXfs.Xf, styleXfIndexes)
+	s.CellStyleXfs.Count = len(s.CellStyleXfs.Xf)
+	if s.CellStyles != nil {
+		for _, cellStyle := range s.CellStyles.CellStyle {
+			cellStyle.XfID = remapStyleIndex(styleXfIndexes, cellStyle.XfID)
+		}
+	}
+	for idx, xf := range s.CellXfs.Xf {
+		if xf.XfID != nil {
+			s.CellXfs.Xf[idx].XfID = intPtr(remapStyleIndex(styleXfIndexes, *xf.XfID))
+		}
+	}
+	return xfIndexes
+}
+
+// compactDxfs provides a function to remove the differential formats which
+// are neither referenced by the worksheets nor the custom table styles, and
+// returns the new indexes of the differential formats and the number format
+// IDs used by the kept differential formats.
+func (f *File) compactDxfs(s *xlsxStyleSheet, dxfs map[int]bool, keepDxfs bool) ([]int, map[int]bool) {
+	numFmts := map[int]bool{}
+	if s.Dxfs == nil {
+		return nil, numFmts
+	}
+	if s.TableStyles != nil {
+		for _, tableStyle := range s.TableStyles.TableStyles {
+			for _, element := range tableStyle.TableStyleElement {
+				if element.DxfID != nil {
+					dxfs[*element.DxfID] = true
+				}
+			}
+		}
+	}
+	var list []*xlsxDxf
+	dxfIndexes := getCompactIndexes(len(s.Dxfs.Dxfs), dxfs)
+	for idx, x := range s.Dxfs.Dxfs {
+		if keepDxfs {
+			dxfIndexes[idx] = idx
+		}
+		if dxfIndexes[idx] == -1 {
+			continue
+		}
+		list = append(list, x)
+		var d dxf
+		if err := f.xmlNewDecoder(strings.NewReader("<dxf>" + x.Dxf + "</dxf>")).
+			Decode(&d); err == nil && d.NumFmt != nil {
+			numFmts[d.NumFmt.NumFmtID] = true
+		}
+	}
+	s.Dxfs.Dxfs, s.Dxfs.Count = list, len(list)
+	if s.TableStyles != nil {
+		for _, tableStyle := range s.TableStyles.TableStyles {
+			for _, element := range tableStyle.TableStyleElement {
+				if element.DxfID != nil {
+					element.DxfID = intPtr(remapStyleIndex(dxfIndexes, *element.DxfID))
+				}
+			}
+		}
+	}
+	return dxfIndexes, numFmts
+}
+
+// compactFormats provides a function to remove the fonts, fills, borders and
+// custom number formats which are not referenced by the cell formats, the
+// master formatting records and the given number format IDs, and update the
+// indexes referenced by the formatting records.
+func (s *xlsxStyleSheet) compactFormats(numFmts map[int]bool) {
+	fonts, fills, borders := map[int]bool{0: true}, map[int]bool{0: true, 1: true}, map[int]bool{0: true}
+	var xfLists [][]xlsxXf
+	if s.CellXfs != nil {
+		xfLists = append(xfLists, s.CellXfs.Xf)
+	}
+	if s.CellStyleXfs != nil {
+		xfLists = append(xfLists, s.CellStyleXfs.Xf)
+	}
+	for _, xfs := range xfLists {
+		for _, xf := range xfs {
+			for _, ref := range []struct {
+				id   *int
+				refs map[int]bool
+			}{{xf.NumFmtID, numFmts}, {xf.FontID, fonts}, {xf.FillID, fills}, {xf.BorderID, borders}} {
+				if ref.id != nil {
+					ref.refs[*ref.id] = true
+				}
+			}
+		}
+	}
+	var fontIndexes, fillIndexes, borderIndexes []int
+	if s.Fonts != nil {
+		var list []*xlsxFont
+		fontIndexes = getCompactIndexes(len(s.Fonts.Font), fonts)
+		for idx, font := range s.Fonts.Font {
+			if fontIndexes[idx] != -1 {
+				list = append(list, font)
+			}
+		}
+		s.Fonts.Font, s.Fonts.Count = list, len(list)
+	}
+	if s.Fills != nil {
+		var list []*xlsxFill
+		fillIndexes = getCompactIndexes(len(s.Fills.Fill), fills)
+		for idx, fill := range s.Fills.Fill {
+			if fillIndexes[idx] != -1 {
+				list = append(list, fill)
+			}
+		}
+		s.Fills.Fill, s.Fills.Count = list, len(list)
+	}
+	if s.Borders != nil {
+		var list []*xlsxBorder
+		borderIndexes = getCompactIndexes(len(s.Borders.Border), borders)
+		for idx, border := range s.Borders.Border {
+			if borderIndexes[idx] != -1 {
+				list = append(list, border)
+			}
+		}
+		s.Borders.Border, s.Borders.Count = list, len(list)
+	}
+	if s.NumFmts != nil {
+		var list []*xlsxNumFmt
+		for _, numFmt := range s.NumFmts.NumFmt {
+			if numFmts[numFmt.NumFmtID] {
+				list = append(list, numFmt)
+			}
+		}
+		s.NumFmts.NumFmt, s.NumFmts.Count = list, len(list)
+	}
+	for _, xfs := range xfLists {
+		for idx, xf := range xfs {
+			if xf.FontID != nil {
+				xfs[idx].FontID = intPtr(remapStyleIndex(fontIndexes, *xf.FontID))
+			}
+			if xf.FillID != nil {
+				xfs[idx].FillID = intPtr(remapStyleIndex(fillIndexes, *xf.FillID))
+			}
+			if xf.BorderID != nil {
+				xfs[idx].BorderID = intPtr(remapStyleIndex(borderIndexes, *xf.BorderID))
+			}
+		}
+	}
+}
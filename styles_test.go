@@ -1,9 +1,12 @@
 package excelize
 
 import (
+	"bytes"
+	"fmt"
 	"math"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -250,11 +253,20 @@ func TestNewStyle(t *testing.T) {
 		})
 		assert.NoError(t, err)
 	}
+	// Test create style without fill and border colors
 	f = NewFile()
-	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true, Italic: true, Family: "Times New Roman", Size: 36, Color: "777777"}})
+	_, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{""}, Pattern: 1}, Border: []Border{{Type: "left", Style: 1}}})
 	assert.NoError(t, err)
 	styles, err := f.stylesReader()
 	assert.NoError(t, err)
+	assert.Nil(t, styles.Fills.Fill[len(styles.Fills.Fill)-1].PatternFill.FgColor)
+	assert.Nil(t, styles.Borders.Border[len(styles.Borders.Border)-1].Left.Color)
+
+	f = NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true, Italic: true, Family: "Times New Roman", Size: 36, Color: "777777"}})
+	assert.NoError(t, err)
+	styles, err = f.stylesReader()
+	assert.NoError(t, err)
 	fontID := styles.CellXfs.Xf[styleID].FontID
 	font := styles.Fonts.Font[*fontID]
 	assert.Contains(t, *font.Name.Val, "Times New Roman", "Stored font should contain font name")
@@ -362,6 +374,357 @@ func TestNewConditionalStyle(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetStyle(t *testing.T) {
+	f := NewFile()
+	style, err := f.GetStyle(0)
+	assert.NoError(t, err)
+	assert.Equal(t, &Style{}, style)
+	for _, expected := range []*Style{
+		{
+			Border: []Border{
+				{Type: "left", Color: "0000FF", Style: 3},
+				{Type: "right", Color: "FF0000", Style: 6},
+				{Type: "top", Color: "00FF00", Style: 4},
+				{Type: "bottom", Color: "FFFF00", Style: 5},
+				{Type: "diagonalUp", Color: "A020F0", Style: 7},
+			},
+			Fill:       Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1},
+			Font:       &Font{Bold: true, Italic: true, Underline: "double", Family: "Times New Roman", Size: 36, Strike: true, Color: "777777"},
+			Alignment:  &Alignment{Horizontal: "center", Vertical: "top", Indent: 1, WrapText: true, TextRotation: 45},
+			Protection: &Protection{Hidden: true, Locked: false},
+			NumFmt:     14,
+		},
+		{
+			Fill:         Fill{Type: "gradient", Color: []string{"FFFFFF", "E0EBF5"}, Shading: 16},
+			Font:         &Font{Family: "Calibri", Size: 11, ColorTheme: intPtr(1), ColorTint: 0.5},
+			CustomNumFmt: stringPtr("[$-380A]dddd\\,\\ dd\" de \"mmmm\" de \"yyyy;@"),
+		},
+		{Border: []Border{{Type: "diagonalDown", Color: "000000", Style: 1}}, NumFmt: 31},
+	} {
+		styleID, err := f.NewStyle(expected)
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, style)
+		// Test create style by the style definition
+		newStyleID, err := f.NewStyle(style)
+		assert.NoError(t, err)
+		assert.Equal(t, styleID, newStyleID)
+	}
+	// Test get style with the workbook created by the spreadsheet application
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	styleID, err := f.GetCellStyle("Sheet1", "A22")
+	assert.NoError(t, err)
+	_, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	// Test get style with invalid style index
+	for _, styleID := range []int{-1, 1} {
+		_, err = NewFile().GetStyle(styleID)
+		assert.Equal(t, newInvalidStyleID(styleID), err)
+	}
+	// Test get style with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetStyle(0)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test extract the styles definition with unsupported and default values
+	assert.Equal(t, "", getColorRRGGBB(nil))
+	assert.Equal(t, "FF0000", getColorRRGGBB(&xlsxColor{RGB: "ff0000"}))
+	f = NewFile()
+	assert.Equal(t, Fill{Type: "pattern"}, f.extractFill(&xlsxFill{PatternFill: &xlsxPatternFill{PatternType: "unknown"}}))
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}}, f.extractFill(&xlsxFill{PatternFill: &xlsxPatternFill{PatternType: "solid", BgColor: &xlsxColor{RGB: "FFFF0000"}}}))
+	assert.Equal(t, Fill{}, f.extractFill(&xlsxFill{}))
+	// Test extract the fill and borders with theme and indexed colors
+	theme := 4
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"5B9BD5"}}, f.extractFill(&xlsxFill{PatternFill: &xlsxPatternFill{PatternType: "solid", FgColor: &xlsxColor{Theme: &theme}}}))
+	assert.Equal(t, []Border{{Type: "left", Color: "FF0000", Style: 1}, {Type: "right", Style: 1}},
+		f.extractBorders(&xlsxBorder{Left: xlsxLine{Style: "thin", Color: &xlsxColor{Indexed: intPtr(10)}}, Right: xlsxLine{Style: "thin"}}))
+	assert.False(t, isGradientFillVariant(&xlsxGradientFill{Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}}}, &xlsxGradientFill{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}}))
+	assert.Equal(t, &Font{Underline: "single"}, extractFont(&xlsxFont{U: &attrValString{}}))
+	assert.Equal(t, &Protection{Locked: true}, extractProtection(&xlsxProtection{}))
+	style = &Style{}
+	extractNumFmt(&xlsxStyleSheet{}, 164, style)
+	assert.Equal(t, &Style{}, style)
+}
+
+func TestUpdateCellStyle(t *testing.T) {
+	f := NewFile()
+	style1, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}, NumFmt: 14,
+		Border: []Border{{Type: "bottom", Color: "FF0000", Style: 2}, {Type: "left", Color: "000000", Style: 1}}})
+	assert.NoError(t, err)
+	style2, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"0000FF"}, Pattern: 1}, CustomNumFmt: stringPtr("0.000"),
+		Font: &Font{Family: "Arial", Size: 9, Italic: true, ColorTheme: intPtr(1)}, Alignment: &Alignment{Horizontal: "left"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "B2", style1))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C1", "C2", style2))
+
+	patch := &Style{
+		Font:      &Font{Bold: true, Color: "00FF00"},
+		Border:    []Border{{Type: "bottom", Color: "000000", Style: 1}},
+		Alignment: &Alignment{Vertical: "center", WrapText: true},
+	}
+	assert.NoError(t, f.UpdateCellStyle("Sheet1", "D2", "A1", patch))
+	styleIDs := map[string]int{}
+	for _, cell := range []string{"A1", "B2", "C1", "C2", "D1", "D2"} {
+		styleIDs[cell], err = f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, styleIDs["A1"], styleIDs["B2"])
+	assert.Equal(t, styleIDs["C1"], styleIDs["C2"])
+	assert.Equal(t, styleIDs["D1"], styleIDs["D2"])
+	assert.NotEqual(t, styleIDs["A1"], styleIDs["C1"])
+	assert.NotEqual(t, styleIDs["A1"], styleIDs["D1"])
+
+	style, err := f.GetStyle(styleIDs["A1"])
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}, style.Fill)
+	assert.Equal(t, 14, style.NumFmt)
+	assert.Equal(t, []Border{{Type: "left", Color: "000000", Style: 1}, {Type: "bottom", Color: "000000", Style: 1}}, style.Border)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, "Calibri", style.Font.Family)
+	assert.Equal(t, &Alignment{Vertical: "center", WrapText: true}, style.Alignment)
+
+	style, err = f.GetStyle(styleIDs["C1"])
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "pattern", Color: []string{"0000FF"}, Pattern: 1}, style.Fill)
+	assert.Equal(t, "0.000", *style.CustomNumFmt)
+	assert.Equal(t, &Font{Bold: true, Italic: true, Family: "Arial", Size: 9, Color: "00FF00"}, style.Font)
+	assert.Equal(t, &Alignment{Horizontal: "left", Vertical: "center", WrapText: true}, style.Alignment)
+
+	// Test update the cells style with the same patch again
+	assert.NoError(t, f.UpdateCellStyle("Sheet1", "A1", "D2", patch))
+	for cell, styleID := range styleIDs {
+		newStyleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, styleID, newStyleID, cell)
+	}
+	// Test update the cells style with the row style
+	assert.NoError(t, f.SetRowStyle("Sheet1", 5, 5, style1))
+	assert.NoError(t, f.UpdateCellStyle("Sheet1", "A5", "A5", &Style{NumFmt: 22, Protection: &Protection{Hidden: true}}))
+	styleID, err := f.GetCellStyle("Sheet1", "A5")
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 22, style.NumFmt)
+	assert.Equal(t, Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}, style.Fill)
+	assert.Equal(t, &Protection{Hidden: true}, style.Protection)
+
+	// Test update the cells style with invalid parameters
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.UpdateCellStyle("Sheet1", "A", "B1", patch))
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), f.UpdateCellStyle("Sheet1", "A1", "B", patch))
+	assert.Equal(t, ErrParameterInvalid, f.UpdateCellStyle("Sheet1", "A1", "B1", nil))
+	assert.Equal(t, ErrFontLength, f.UpdateCellStyle("Sheet1", "A1", "B1", &Style{Font: &Font{Family: strings.Repeat("s", MaxFontFamilyLength+1)}}))
+	assert.EqualError(t, f.UpdateCellStyle("SheetN", "A1", "B1", patch), "sheet SheetN does not exist")
+	assert.Equal(t, ErrSheetNameInvalid, f.UpdateCellStyle("Sheet:1", "A1", "B1", patch))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 100
+	assert.Equal(t, newInvalidStyleID(100), f.UpdateCellStyle("Sheet1", "A1", "B1", patch))
+
+	// Test update the cells style with theme colored fill and border
+	f = NewFile()
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	theme, tint := 4, 0.3999
+	styles.Fills.Fill = append(styles.Fills.Fill, &xlsxFill{PatternFill: &xlsxPatternFill{
+		PatternType: "solid", FgColor: &xlsxColor{Theme: &theme, Tint: tint}, BgColor: &xlsxColor{Indexed: intPtr(64)},
+	}})
+	styles.Fills.Count = len(styles.Fills.Fill)
+	styles.Borders.Border = append(styles.Borders.Border, &xlsxBorder{
+		Left:   xlsxLine{Style: "thin", Color: &xlsxColor{Theme: intPtr(1)}},
+		Bottom: xlsxLine{Style: "thin", Color: &xlsxColor{Auto: true}},
+	})
+	styles.Borders.Count = len(styles.Borders.Border)
+	fillID, borderID := styles.Fills.Count-1, styles.Borders.Count-1
+	styles.CellXfs.Xf = append(styles.CellXfs.Xf, xlsxXf{FontID: intPtr(0), FillID: &fillID, BorderID: &borderID, ApplyFill: boolPtr(true), ApplyBorder: boolPtr(true)})
+	styles.CellXfs.Count = len(styles.CellXfs.Xf)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styles.CellXfs.Count-1))
+	assert.NoError(t, f.UpdateCellStyle("Sheet1", "A1", "A1", &Style{Font: &Font{Bold: true}}))
+	styleID, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, Fill{Type: "pattern", Color: []string{"9DC3E6"}, Pattern: 1}, style.Fill)
+	assert.Equal(t, []Border{{Type: "left", Color: "000000", Style: 1}, {Type: "bottom", Style: 1}}, style.Border)
+	// Test update the cells style keep the theme colored fill and border
+	assert.Equal(t, fillID, *styles.CellXfs.Xf[styleID].FillID)
+	assert.Equal(t, borderID, *styles.CellXfs.Xf[styleID].BorderID)
+	assert.Len(t, styles.Fills.Fill, fillID+1)
+	assert.Len(t, styles.Borders.Border, borderID+1)
+	assert.NoError(t, f.UpdateCellStyle("Sheet1", "A1", "A1", &Style{Font: &Font{Bold: true}}))
+	updatedStyleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, updatedStyleID)
+	assert.NoError(t, f.UpdateCellStyle("Sheet1", "A1", "A1", &Style{Border: []Border{{Type: "top", Color: "FF0000", Style: 1}}}))
+	styleID, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, fillID, *styles.CellXfs.Xf[styleID].FillID)
+	assert.NotEqual(t, borderID, *styles.CellXfs.Xf[styleID].BorderID)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NotContains(t, buf.String(), `rgb="FF"`)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	styles, err = f.stylesReader()
+	assert.NoError(t, err)
+	for _, fill := range styles.Fills.Fill {
+		if fill.PatternFill != nil && fill.PatternFill.FgColor != nil {
+			assert.NotEqual(t, "FF", fill.PatternFill.FgColor.RGB)
+		}
+	}
+	for _, border := range styles.Borders.Border {
+		for _, line := range []xlsxLine{border.Left, border.Right, border.Top, border.Bottom, border.Diagonal} {
+			if line.Color != nil {
+				assert.NotEqual(t, "FF", line.Color.RGB)
+			}
+		}
+	}
+	assert.NoError(t, f.Close())
+
+	// Test update the cells style keep the named cell style
+	f = NewFile()
+	namedStyleID, err := f.NewNamedStyle("Input", &Style{Font: &Font{Color: "3F3F76"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellNamedStyle("Sheet1", "A1", "B1", "Input"))
+	assert.NoError(t, f.UpdateCellStyle("Sheet1", "A1", "A1", &Style{Font: &Font{Bold: true}}))
+	assert.NoError(t, f.UpdateCellStyle("Sheet1", "B1", "B1", &Style{
+		Fill:   Fill{Type: "pattern", Color: []string{"FFCC99"}, Pattern: 1},
+		Border: []Border{{Type: "top", Color: "FF0000", Style: 1}},
+	}))
+	styles, err = f.stylesReader()
+	assert.NoError(t, err)
+	for _, cell := range []string{"A1", "B1"} {
+		styleID, err = f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.NotEqual(t, namedStyleID, styleID, cell)
+		assert.Equal(t, *styles.CellXfs.Xf[namedStyleID].XfID, *styles.CellXfs.Xf[styleID].XfID, cell)
+	}
+	// Test update the cells style with exceeds the cell styles limit
+	for i := len(styles.CellXfs.Xf); i < MaxCellStyles; i++ {
+		styles.CellXfs.Xf = append(styles.CellXfs.Xf, xlsxXf{})
+	}
+	assert.Equal(t, ErrCellStyles, f.UpdateCellStyle("Sheet1", "A1", "A1", &Style{Font: &Font{Italic: true}}))
+	assert.NoError(t, f.Close())
+
+	// Test merge the font and alignment with unset fields
+	font := &Font{Color: "FF0000"}
+	mergeFont(font, &Font{ColorIndexed: 2, ColorTint: 0.5, Underline: "single", Strike: true})
+	assert.Equal(t, &Font{ColorIndexed: 2, ColorTint: 0.5, Underline: "single", Strike: true}, font)
+	mergeFont(font, &Font{ColorTheme: intPtr(4)})
+	assert.Equal(t, &Font{ColorTheme: intPtr(4), ColorTint: 0.5, Underline: "single", Strike: true}, font)
+	alignment := &Alignment{}
+	mergeAlignment(alignment, &Alignment{Indent: 1, RelativeIndent: 2, ReadingOrder: 1, TextRotation: 90, JustifyLastLine: true, ShrinkToFit: true})
+	assert.Equal(t, &Alignment{Indent: 1, RelativeIndent: 2, ReadingOrder: 1, TextRotation: 90, JustifyLastLine: true, ShrinkToFit: true}, alignment)
+	style = &Style{NumFmt: 14}
+	mergeStyle(style, &Style{CustomNumFmt: stringPtr("0.0"), DecimalPlaces: intPtr(1), NegRed: true})
+	assert.Equal(t, &Style{CustomNumFmt: stringPtr("0.0"), DecimalPlaces: intPtr(1), NegRed: true}, style)
+}
+
+func TestNamedStyle(t *testing.T) {
+	f := NewFile()
+	input := &Style{
+		Font:   &Font{Color: "3F3F76", Family: "Calibri", Size: 11},
+		Fill:   Fill{Type: "pattern", Color: []string{"FFCC99"}, Pattern: 1},
+		Border: []Border{{Type: "bottom", Color: "7F7F7F", Style: 1}},
+	}
+	styleID, err := f.NewNamedStyle("Input", input)
+	assert.NoError(t, err)
+	corporate := &Style{Font: &Font{Bold: true, Family: "Arial", Size: 12}, Alignment: &Alignment{Horizontal: "center"}, NumFmt: 4}
+	_, err = f.NewNamedStyle("Corporate", corporate)
+	assert.NoError(t, err)
+	// Test create named style with the existing name
+	_, err = f.NewNamedStyle("input", input)
+	assert.Equal(t, ErrExistsNamedStyle, err)
+
+	namedStyles, err := f.GetNamedStyles()
+	assert.NoError(t, err)
+	assert.Equal(t, []NamedStyle{{Name: "Normal", Style: &Style{}}, {Name: "Input", Style: input}, {Name: "Corporate", Style: corporate}}, namedStyles)
+
+	// Test apply named style to the cells
+	assert.NoError(t, f.SetCellNamedStyle("Sheet1", "A1", "B2", "INPUT"))
+	assert.NoError(t, f.SetCellNamedStyle("Sheet1", "C1", "C1", "Corporate"))
+	assert.NoError(t, f.SetCellNamedStyle("Sheet1", "D1", "D1", "Normal"))
+	for cell, expected := range map[string]int{"A1": styleID, "B2": styleID, "D1": 0} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID, cell)
+	}
+	styleID, err = f.GetCellStyle("Sheet1", "C1")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, corporate, style)
+
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, styles.CellStyleXfs.Count)
+	assert.Equal(t, 2, *styles.CellXfs.Xf[styleID].XfID)
+	assert.Equal(t, 20, *styles.CellStyles.CellStyle[1].BuiltInID)
+	assert.True(t, *styles.CellStyles.CellStyle[1].CustomBuiltIn)
+	assert.Nil(t, styles.CellStyles.CellStyle[2].BuiltInID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestNamedStyle.xlsx")))
+
+	// Test create named style with invalid parameters
+	_, err = f.NewNamedStyle("", input)
+	assert.Equal(t, ErrParameterRequired, err)
+	_, err = f.NewNamedStyle(strings.Repeat("s", MaxFieldLength+1), input)
+	assert.Equal(t, ErrNameLength, err)
+	_, err = f.NewNamedStyle("Style", nil)
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.NewNamedStyle("Style", &Style{Font: &Font{Size: MaxFontSize + 1}})
+	assert.Equal(t, ErrFontSize, err)
+	_, err = f.NewNamedStyle("Style", &Style{NumFmt: 170, DecimalPlaces: intPtr(-1)})
+	assert.NoError(t, err)
+	// Test apply named style with invalid parameters
+	assert.Equal(t, newNoExistNamedStyleError("Unknown"), f.SetCellNamedStyle("Sheet1", "A1", "B2", "Unknown"))
+	assert.EqualError(t, f.SetCellNamedStyle("SheetN", "A1", "B2", "Input"), "sheet SheetN does not exist")
+	styles.CellStyles.CellStyle[1].XfID = 10
+	assert.Equal(t, newNoExistNamedStyleError("Input"), f.SetCellNamedStyle("Sheet1", "A1", "B2", "Input"))
+	namedStyles, err = f.GetNamedStyles()
+	assert.NoError(t, err)
+	assert.Equal(t, &Style{}, namedStyles[1].Style)
+
+	// Test create and apply named styles without the cell styles
+	f = NewFile()
+	styles, err = f.stylesReader()
+	assert.NoError(t, err)
+	styles.CellStyleXfs, styles.CellStyles, styles.CellXfs = nil, nil, nil
+	namedStyles, err = f.GetNamedStyles()
+	assert.NoError(t, err)
+	assert.Nil(t, namedStyles)
+	assert.Equal(t, newNoExistNamedStyleError("Input"), f.SetCellNamedStyle("Sheet1", "A1", "B2", "Input"))
+	styleID, err = f.NewNamedStyle("Input", input)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, styleID)
+	assert.NoError(t, f.SetCellNamedStyle("Sheet1", "A1", "B2", "Input"))
+
+	// Test create and apply named styles exceeds the limit
+	styles.CellStyleXfs.Xf = make([]xlsxXf, MaxCellStyles)
+	_, err = f.NewNamedStyle("Style", input)
+	assert.Equal(t, ErrCellStyles, err)
+	styles.CellStyleXfs.Xf = styles.CellStyleXfs.Xf[:1]
+	styles.CellXfs.Xf = make([]xlsxXf, MaxCellStyles)
+	_, err = f.NewNamedStyle("Style", input)
+	assert.Equal(t, ErrCellStyles, err)
+	assert.Equal(t, ErrCellStyles, f.SetCellNamedStyle("Sheet1", "A1", "B2", "Style"))
+
+	// Test named style with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.NewNamedStyle("Style", input)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	_, err = f.GetNamedStyles()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	assert.EqualError(t, f.SetCellNamedStyle("Sheet1", "A1", "B2", "Normal"), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetDefaultFont(t *testing.T) {
 	f := NewFile()
 	s, err := f.GetDefaultFont()
@@ -472,3 +835,490 @@ func TestGetNumFmtID(t *testing.T) {
 	assert.NotEqual(t, id1, id2)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStyleNumFmt.xlsx")))
 }
+
+func TestGetCellEffectiveStyle(t *testing.T) {
+	f := NewFile()
+	for r := 1; r <= 6; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{
+			r, []string{"x", "y", "X", "z", "", "w"}[r-1], r * 10,
+		}))
+	}
+	for r, v := range []int{0, 50, 100} {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("D%d", r+1), v))
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("E%d", r+1), v))
+	}
+	bold, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", bold))
+	red, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}})
+	assert.NoError(t, err)
+	italic, err := f.NewConditionalStyle(&Style{Font: &Font{Italic: true, Color: "9C0006"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A6", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Value: "4", Format: red},
+		{Type: "formula", Criteria: "MOD(A1,2)=0", Format: italic},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B6", []ConditionalFormatOptions{
+		{Type: "duplicate", Criteria: "=", Format: red},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:C6", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: "between", MinValue: "20", MaxValue: "$A$3*10", Format: italic, StopIfTrue: true},
+		{Type: "top", Criteria: "=", Value: "2", Format: red},
+		{Type: "average", Criteria: "=", AboveAverage: true, Format: italic},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "D1:D3", []ConditionalFormatOptions{
+		{Type: "2_color_scale", Criteria: "=", MinType: "min", MaxType: "max", MinColor: "#FFFFFF", MaxColor: "#000000"},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "E1:E3", []ConditionalFormatOptions{
+		{Type: "3_color_scale", Criteria: "=", MinType: "num", MidType: "percentile", MaxType: "percent", MinValue: "0", MidValue: "50", MaxValue: "100", MinColor: "#F8696B", MidColor: "#FFEB84", MaxColor: "#63BE7B"},
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6"},
+	}))
+	defaultFont := func(font Font) *Font {
+		font.Family, font.Size = "Calibri", 11
+		return &font
+	}
+	for _, c := range []struct {
+		cell     string
+		expected *Style
+	}{
+		{"A1", &Style{Font: &Font{Bold: true, Family: "Calibri", Size: 11}}},
+		{"A2", &Style{Font: defaultFont(Font{Italic: true, Color: "9C0006"})}},
+		{"A5", &Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}}},
+		{"A6", &Style{Font: defaultFont(Font{Italic: true, Color: "9C0006"}), Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}}},
+		{"B1", &Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}}},
+		{"B2", &Style{}},
+		{"B5", &Style{}},
+		{"C1", &Style{}},
+		{"C2", &Style{Font: defaultFont(Font{Italic: true, Color: "9C0006"})}},
+		{"C4", &Style{Font: defaultFont(Font{Italic: true, Color: "9C0006"})}},
+		{"C5", &Style{Font: defaultFont(Font{Italic: true, Color: "9C0006"}), Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}}},
+		{"D1", &Style{Fill: Fill{Type: "pattern", Color: []string{"FFFFFF"}, Pattern: 1}}},
+		{"D2", &Style{Fill: Fill{Type: "pattern", Color: []string{"808080"}, Pattern: 1}}},
+		{"D3", &Style{Fill: Fill{Type: "pattern", Color: []string{"000000"}, Pattern: 1}}},
+		{"E1", &Style{Fill: Fill{Type: "pattern", Color: []string{"F8696B"}, Pattern: 1}}},
+		{"E2", &Style{Fill: Fill{Type: "pattern", Color: []string{"FFEB84"}, Pattern: 1}}},
+		{"E3", &Style{Fill: Fill{Type: "pattern", Color: []string{"63BE7B"}, Pattern: 1}}},
+		{"F1", &Style{}},
+	} {
+		style, err := f.GetCellEffectiveStyle("Sheet1", c.cell)
+		assert.NoError(t, err, c.cell)
+		assert.Equal(t, c.expected, style, c.cell)
+	}
+	// Test get effective style keep the worksheet unmodified
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f2, err := OpenReader(buf)
+	assert.NoError(t, err)
+	for _, cell := range []string{"A6", "B1", "C5", "D2"} {
+		_, err = f2.GetCellEffectiveStyle("Sheet1", cell)
+		assert.NoError(t, err, cell)
+	}
+	assert.False(t, f2.isPartModified("xl/worksheets/sheet1.xml"))
+	assert.NoError(t, f2.Close())
+
+	// Test get effective style with custom table style
+	header, err := f.NewConditionalStyle(&Style{
+		Font: &Font{Bold: true, Color: "FFFFFF"},
+		Fill: Fill{Type: "pattern", Color: []string{"1F4E78"}, Pattern: 1},
+	})
+	assert.NoError(t, err)
+	stripe, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1}})
+	assert.NoError(t, err)
+	border, err := f.NewConditionalStyle(&Style{Border: []Border{{Type: "left", Color: "000000", Style: 1}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddTableStyle(&TableStyle{
+		Name: "CorporateTable",
+		Elements: []TableStyleElement{
+			{Type: "headerRow", Format: header},
+			{Type: "firstRowStripe", Format: stripe, Size: 2},
+			{Type: "firstColumn", Format: border},
+		},
+	}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "H1", &[]interface{}{"Name", "Value"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "H1:I6", StyleName: "CorporateTable", ShowFirstColumn: true}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "K1:L6", StyleName: "TableStyleMedium2"}))
+	italicStyle, err := f.NewStyle(&Style{Font: &Font{Italic: true}, Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "I3", "I3", italicStyle))
+	for _, c := range []struct {
+		cell     string
+		expected *Style
+	}{
+		{"H1", &Style{Font: defaultFont(Font{Bold: true, Color: "FFFFFF"}), Fill: Fill{Type: "pattern", Color: []string{"1F4E78"}, Pattern: 1}, Border: []Border{{Type: "left", Color: "000000", Style: 1}}}},
+		{"I1", &Style{Font: defaultFont(Font{Bold: true, Color: "FFFFFF"}), Fill: Fill{Type: "pattern", Color: []string{"1F4E78"}, Pattern: 1}}},
+		{"I2", &Style{Fill: Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1}}},
+		{"I3", &Style{Font: &Font{Italic: true, Family: "Calibri", Size: 11, ColorTheme: intPtr(1)}, Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}}},
+		{"I4", &Style{}},
+		{"I6", &Style{Fill: Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1}}},
+		{"K2", &Style{}},
+	} {
+		style, err := f.GetCellEffectiveStyle("Sheet1", c.cell)
+		assert.NoError(t, err, c.cell)
+		assert.Equal(t, c.expected, style, c.cell)
+	}
+	// Test get effective style reuse the decoded table
+	cached, ok := f.tables.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	_, err = f.GetCellEffectiveStyle("Sheet1", "I2")
+	assert.NoError(t, err)
+	table, ok := f.tables.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	assert.True(t, cached.(*decodedTable).table == table.(*decodedTable).table)
+	// Test get effective style with the changed table
+	assert.NoError(t, f.SetSheetRow("Sheet1", "H7", &[]interface{}{"Total"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "N1:O6", StyleName: "CorporateTable"}))
+	content, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	f.Pkg.Store("xl/tables/table1.xml", bytes.ReplaceAll(content.([]byte), []byte(`ref="H1:I6"`), []byte(`ref="H1:I7"`)))
+	style, err := f.GetCellEffectiveStyle("Sheet1", "H7")
+	assert.NoError(t, err)
+	assert.Equal(t, &Style{Border: []Border{{Type: "left", Color: "000000", Style: 1}}}, style)
+	// Test get effective style concurrently with setting cell values
+	var wg sync.WaitGroup
+	for i := 1; i <= 5; i++ {
+		wg.Add(1)
+		go func(row int) {
+			defer wg.Done()
+			assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row+10), row))
+			assert.NoError(t, f.SetCellStr("Sheet1", fmt.Sprintf("I%d", row+10), strings.Repeat("I", row)))
+			_, err := f.GetCellEffectiveStyle("Sheet1", fmt.Sprintf("I%d", row))
+			assert.NoError(t, err)
+			_, err = f.GetCellEffectiveStyle("Sheet1", fmt.Sprintf("A%d", row))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+	// Test get effective style with unsupported charset table
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCellEffectiveStyle("Sheet1", "H1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get effective style with invalid cell reference
+	_, err = f.GetCellEffectiveStyle("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get effective style on not exists worksheet
+	_, err = f.GetCellEffectiveStyle("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get effective style with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetCellEffectiveStyle("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestEvalCondFmtRule(t *testing.T) {
+	f := NewFile()
+	ctx := &calcContext{iterations: make(map[string]uint), iterationsCache: make(map[string]formulaArg)}
+	for _, c := range []struct {
+		operator string
+		formula  []string
+		value    formulaArg
+		expected bool
+	}{
+		{"equal", []string{"5"}, newNumberFormulaArg(5), true},
+		{"notEqual", []string{"5"}, newNumberFormulaArg(5), false},
+		{"greaterThanOrEqual", []string{"5"}, newNumberFormulaArg(5), true},
+		{"lessThan", []string{"5"}, newNumberFormulaArg(4), true},
+		{"lessThanOrEqual", []string{"5"}, newNumberFormulaArg(6), false},
+		{"between", []string{"10", "1"}, newNumberFormulaArg(5), true},
+		{"between", []string{"10"}, newNumberFormulaArg(5), false},
+		{"notBetween", []string{"1", "10"}, newNumberFormulaArg(11), true},
+		{"equal", []string{"\"abc\""}, newStringFormulaArg("ABC"), true},
+		{"greaterThan", []string{"5"}, newStringFormulaArg("a"), true},
+		{"equal", []string{"0"}, newEmptyFormulaArg(), true},
+		{"unknown", []string{"5"}, newNumberFormulaArg(5), false},
+		{"equal", nil, newNumberFormulaArg(5), false},
+	} {
+		ok, err := f.evalCondFmtCellIs(ctx, "Sheet1", "A1", condFmtRule{
+			rule: &xlsxCfRule{Operator: c.operator, Formula: c.formula}, ranges: [][]int{{1, 1, 1, 1}},
+		}, c.value)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, ok, c.operator, c.formula)
+	}
+	assert.Equal(t, 1, compareCondFmtValue(newBoolFormulaArg(false), newStringFormulaArg("a")))
+	assert.Equal(t, 0, compareCondFmtValue(newEmptyFormulaArg(), newStringFormulaArg("")))
+	assert.True(t, isCondFmtTrue(newStringFormulaArg("TRUE")))
+	assert.False(t, isCondFmtTrue(newStringFormulaArg("a")))
+	assert.False(t, isCondFmtTrue(newEmptyFormulaArg()))
+
+	var values []formulaArg
+	for i := 1; i <= 10; i++ {
+		values = append(values, newNumberFormulaArg(float64(i)))
+	}
+	values = append(values, newStringFormulaArg("a"), newStringFormulaArg("b"), newStringFormulaArg("A"))
+	below := false
+	for _, c := range []struct {
+		rule     *xlsxCfRule
+		value    formulaArg
+		expected bool
+	}{
+		{&xlsxCfRule{Type: "top10", Rank: 2, Bottom: true}, newNumberFormulaArg(2), true},
+		{&xlsxCfRule{Type: "top10", Rank: 2, Bottom: true}, newNumberFormulaArg(3), false},
+		{&xlsxCfRule{Type: "top10", Rank: 20, Percent: true}, newNumberFormulaArg(9), true},
+		{&xlsxCfRule{Type: "top10", Rank: 20, Percent: true}, newNumberFormulaArg(8), false},
+		{&xlsxCfRule{Type: "top10", Rank: 100}, newNumberFormulaArg(1), true},
+		{&xlsxCfRule{Type: "top10", Rank: 1}, newStringFormulaArg("a"), false},
+		{&xlsxCfRule{Type: "aboveAverage", AboveAverage: &below}, newNumberFormulaArg(5), true},
+		{&xlsxCfRule{Type: "aboveAverage", StdDev: 1}, newNumberFormulaArg(9), true},
+		{&xlsxCfRule{Type: "aboveAverage", StdDev: 1}, newNumberFormulaArg(8), false},
+		{&xlsxCfRule{Type: "aboveAverage", AboveAverage: &below, EqualAverage: true}, newNumberFormulaArg(5.5), true},
+		{&xlsxCfRule{Type: "duplicateValues"}, newStringFormulaArg("a"), true},
+		{&xlsxCfRule{Type: "uniqueValues"}, newStringFormulaArg("b"), true},
+		{&xlsxCfRule{Type: "uniqueValues"}, newEmptyFormulaArg(), false},
+	} {
+		assert.Equal(t, c.expected, evalCondFmtRangeRule(c.rule, c.value, values), c.rule.Type)
+	}
+	assert.False(t, evalCondFmtRangeRule(&xlsxCfRule{Type: "top10", Rank: 1}, newNumberFormulaArg(1), nil))
+
+	numbers := []float64{0, 10, 20, 30}
+	rule := condFmtRule{rule: &xlsxCfRule{}, ranges: [][]int{{1, 1, 1, 1}}}
+	for _, c := range []struct {
+		cfvo     *xlsxCfvo
+		expected float64
+	}{
+		{&xlsxCfvo{Type: "num", Val: "5"}, 5},
+		{&xlsxCfvo{Type: "formula", Val: "2*3"}, 6},
+		{&xlsxCfvo{Type: "percent", Val: "50"}, 15},
+		{&xlsxCfvo{Type: "percentile", Val: "0"}, 0},
+		{&xlsxCfvo{Type: "percentile", Val: "50"}, 15},
+		{&xlsxCfvo{Type: "percentile", Val: "100"}, 30},
+	} {
+		val, err := f.getCondFmtCfvoValue(ctx, "Sheet1", "A1", rule, c.cfvo, numbers)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, val, c.cfvo.Type)
+	}
+	val, err := f.getCondFmtCfvoValue(ctx, "Sheet1", "A1", rule, &xlsxCfvo{Type: "min"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, val)
+}
+
+func TestGetColorRGB(t *testing.T) {
+	f := NewFile()
+	theme, invalidTheme := 1, 12
+	for _, c := range []struct {
+		color    *xlsxColor
+		expected string
+	}{
+		{nil, ""},
+		{&xlsxColor{Auto: true}, ""},
+		{&xlsxColor{RGB: "ff0000"}, "FFFF0000"},
+		{&xlsxColor{RGB: "80FF0000"}, "80FF0000"},
+		{&xlsxColor{Theme: &theme}, "FF000000"},
+		{&xlsxColor{Theme: &theme, Tint: 0.5}, "FF808080"},
+		{&xlsxColor{Theme: &invalidTheme}, ""},
+		{&xlsxColor{Indexed: intPtr(2)}, "FFFF0000"},
+		{&xlsxColor{}, ""},
+		{&xlsxColor{Indexed: intPtr(0)}, "FF000000"},
+		{&xlsxColor{Indexed: intPtr(100)}, ""},
+		{&xlsxColor{RGB: "FF0000", Tint: 0.5}, "FFFF8080"},
+		{&xlsxColor{RGB: "80000000", Tint: 0.5}, "80808080"},
+	} {
+		assert.Equal(t, c.expected, f.getColorRGB(c.color))
+	}
+	for _, c := range []struct {
+		color    *Color
+		expected string
+	}{
+		{&Color{}, ""},
+		{&Color{Auto: true, RGB: "FF0000"}, "FF000000"},
+		{&Color{RGB: "#4472c4"}, "FF4472C4"},
+		{&Color{Theme: &theme, Tint: -0.5}, "FF000000"},
+		{&Color{Indexed: intPtr(8), Theme: &theme}, "FF000000"},
+		{&Color{Indexed: intPtr(0)}, "FF000000"},
+		{&Color{Indexed: intPtr(10)}, "FFFF0000"},
+		{&Color{Indexed: intPtr(10), Tint: 0.5}, "FFFF8080"},
+		{&Color{Indexed: intPtr(64)}, "FF000000"},
+		{&Color{Indexed: intPtr(66)}, ""},
+	} {
+		color, err := f.GetColorRGB(c.color)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, color)
+	}
+	// Test get color with the modified legacy color palette
+	f.Styles.Colors = &xlsxStyleColors{IndexedColors: &xlsxIndexedColors{}}
+	for i := 0; i < 70; i++ {
+		f.Styles.Colors.IndexedColors.RgbColor = append(f.Styles.Colors.IndexedColors.RgbColor, xlsxRgbColor{RGB: "FF1F4E79"})
+	}
+	f.Styles.Colors.IndexedColors.RgbColor[11].RGB = "00b050"
+	f.Styles.Colors.IndexedColors.RgbColor[12].RGB = "invalid"
+	for idx, expected := range map[int]string{10: "FF1F4E79", 11: "FF00B050", 12: "FF0000FF", 63: "FF1F4E79", 64: "FF000000", 65: "FFFFFFFF"} {
+		color, err := f.GetColorRGB(&Color{Indexed: intPtr(idx)})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, color)
+	}
+	// Test get color with nil color settings
+	_, err := f.GetColorRGB(nil)
+	assert.Equal(t, ErrParameterInvalid, err)
+	// Test get color without theme
+	f.Theme = nil
+	f.Pkg.Delete(defaultXMLPathTheme)
+	assert.Equal(t, "", f.getColorRGB(&xlsxColor{Theme: &theme}))
+	// Test get color with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetColorRGB(&Color{Indexed: intPtr(10)})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestCompactStyles(t *testing.T) {
+	f := NewFile()
+	var styles []int
+	for _, style := range []*Style{
+		{Font: &Font{Bold: true, Color: "FF0000"}},
+		{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"00FF00"}}, CustomNumFmt: stringPtr("0.000;-0.000")},
+		{Border: []Border{{Type: "left", Color: "0000FF", Style: 2}}, CustomNumFmt: stringPtr("#,##0.0000")},
+		{Font: &Font{Italic: true}, Alignment: &Alignment{Horizontal: "center"}},
+		{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}},
+	} {
+		styleID, err := f.NewStyle(style)
+		assert.NoError(t, err)
+		styles = append(styles, styleID)
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styles[1]))
+	assert.NoError(t, f.SetRowStyle("Sheet1", 3, 3, styles[3]))
+	assert.NoError(t, f.SetColStyle("Sheet1", "E", styles[4]))
+	var dxfs []int
+	for _, color := range []string{"FF0000", "00FF00", "0000FF", "FFFF00"} {
+		dxfID, err := f.NewConditionalStyle(&Style{Font: &Font{Color: color}})
+		assert.NoError(t, err)
+		dxfs = append(dxfs, dxfID)
+	}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: dxfs[1], Value: "6"},
+	}))
+	assert.NoError(t, f.AddTableStyle(&TableStyle{Name: "Brand", Table: true, Elements: []TableStyleElement{
+		{Type: "wholeTable", Format: dxfs[3]},
+	}}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "G1:H3", StyleName: "Brand"}))
+	content, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	f.Pkg.Store("xl/tables/table1.xml", []byte(strings.Replace(string(content.([]byte)), "<table ", fmt.Sprintf(`<table headerRowDxfId="%d" `, dxfs[2]), 1)))
+	_, err := f.NewNamedStyle("Input", &Style{Font: &Font{Color: "3F3F76"}})
+	assert.NoError(t, err)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet2", "B2", "B2", styles[1]))
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$A$1:$A$2"}}}))
+	expected := map[string]*Style{}
+	for _, cell := range []string{"A1", "A3", "E1"} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		expected[cell], err = f.GetStyle(styleID)
+		assert.NoError(t, err)
+	}
+	cellXfs, fonts, numFmts := len(f.Styles.CellXfs.Xf), len(f.Styles.Fonts.Font), len(f.Styles.NumFmts.NumFmt)
+
+	assert.NoError(t, f.CompactStyles())
+	assert.Len(t, f.Styles.CellXfs.Xf, cellXfs-3)
+	assert.Len(t, f.Styles.Fonts.Font, fonts-1)
+	assert.Len(t, f.Styles.NumFmts.NumFmt, numFmts-1)
+	assert.Len(t, f.Styles.Dxfs.Dxfs, 3)
+	for cell, style := range expected {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		actual, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, style, actual, cell)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, styleID)
+	styleID, err = f.GetCellStyle("Sheet2", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, styleID)
+	// Test the differential formats referenced by the conditional formats,
+	// the custom table styles and the tables are remapped
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, 0, *ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule[0].DxfID)
+	assert.Equal(t, 2, *f.Styles.TableStyles.TableStyles[0].TableStyleElement[0].DxfID)
+	tables, err := f.getTableParts()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tables["xl/tables/table1.xml"].HeaderRowDxfID)
+	// Test the named cell styles are kept
+	namedStyles, err := f.GetNamedStyles()
+	assert.NoError(t, err)
+	assert.Equal(t, "Input", namedStyles[len(namedStyles)-1].Name)
+	assert.Equal(t, "3F3F76", namedStyles[len(namedStyles)-1].Style.Font.Color)
+	assert.NoError(t, f.SetCellNamedStyle("Sheet1", "B1", "B1", "Input"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCompactStyles.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test compact styles keep the differential formats referenced by the pivot tables
+	f = NewFile()
+	for _, color := range []string{"FF0000", "00FF00"} {
+		_, err := f.NewConditionalStyle(&Style{Font: &Font{Color: color}})
+		assert.NoError(t, err)
+	}
+	f.Pkg.Store("xl/pivotTables/pivotTable1.xml", []byte(`<pivotTableDefinition><formats><format dxfId="1"/></formats></pivotTableDefinition>`))
+	assert.NoError(t, f.CompactStyles())
+	assert.Len(t, f.Styles.Dxfs.Dxfs, 2)
+	f.Pkg.Delete("xl/pivotTables/pivotTable1.xml")
+	assert.NoError(t, f.CompactStyles())
+	assert.Len(t, f.Styles.Dxfs.Dxfs, 0)
+	// Test compact styles with invalid style indexes
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row = []xlsxRow{{R: 1, C: []xlsxC{{R: "A1", S: 10}}}}
+	ws.(*xlsxWorksheet).AutoFilter = &xlsxAutoFilter{Ref: "A1:A2", FilterColumn: []*xlsxFilterColumn{{ColorFilter: &xlsxColorFilter{DxfID: 5}}}}
+	assert.NoError(t, f.CompactStyles())
+	assert.Equal(t, 0, ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S)
+	assert.Equal(t, 0, ws.(*xlsxWorksheet).AutoFilter.FilterColumn[0].ColorFilter.DxfID)
+	// Test compact styles with unsupported charset table
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CompactStyles(), "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Delete("xl/tables/table1.xml")
+	// Test compact styles with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CompactStyles(), "XML syntax error on line 1: invalid UTF-8")
+	// Test compact styles with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = nil
+	assert.EqualError(t, f.CompactStyles(), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test compact styles only mark the worksheets with remapped styles as modified
+	f = NewFile()
+	_, err = f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	styleID, err = f.NewStyle(&Style{Font: &Font{Italic: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "A1"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	assert.NoError(t, f.CompactStyles())
+	assert.True(t, f.isPartModified("xl/worksheets/sheet1.xml"))
+	assert.False(t, f.isPartModified("xl/worksheets/sheet2.xml"))
+	assert.NoError(t, f.Close())
+
+	// Test compact styles of the workbook with stream writer
+	f = NewFile()
+	unused, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	styleID, err = f.NewStyle(&Style{Font: &Font{Italic: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, unused)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{Cell{StyleID: styleID, Value: "A1"}}))
+	assert.NoError(t, sw.Flush())
+	assert.Equal(t, ErrStreamCompactStyles, f.CompactStyles())
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	cellStyleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(cellStyleID)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Italic)
+	assert.NoError(t, f.Close())
+}
@@ -74,7 +74,8 @@ func parseTableOptions(opts *Table) (*Table, error) {
 // be unique, starts with a letter or underscore (_), doesn't include a
 // space or character, and should be no more than 255 characters
 //
-// StyleName: The built-in table style names
+// StyleName: The built-in table style names, or the name of the custom table
+// style created by the AddTableStyle function
 //
 //	TableStyleLight1 - TableStyleLight21
 //	TableStyleMedium1 - TableStyleMedium28
@@ -125,6 +126,192 @@ func (f *File) AddTable(sheet string, table *Table) error {
 	return f.addContentTypePart(tableID, "table")
 }
 
+// AddTableStyle provides the method to create the custom table style by given
+// table style settings, the custom table style could be used by the name in
+// the 'StyleName' field of the table settings, and the 'PivotTableStyleName'
+// field of the pivot table settings. The 'Table' and 'Pivot' fields specify
+// if the table style could be used by the tables or the pivot tables, the
+// table style will be available for both of them when neither of these fields
+// was specified. The formatting of each element of the table style was
+// specified by the differential formatting style index in the 'Format' field,
+// which was created by the NewConditionalStyle function. For example, create
+// a custom table style with the header row and banded rows, and apply it to
+// the table of Sheet1!A1:D5:
+//
+//	header, err := f.NewConditionalStyle(&excelize.Style{
+//	    Font: &excelize.Font{Bold: true, Color: "FFFFFF"},
+//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"1F4E78"}, Pattern: 1},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	stripe, err := f.NewConditionalStyle(&excelize.Style{
+//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.AddTableStyle(&excelize.TableStyle{
+//	    Name: "CorporateTable",
+//	    Elements: []excelize.TableStyleElement{
+//	        {Type: "headerRow", Format: header},
+//	        {Type: "firstRowStripe", Format: stripe},
+//	    },
+//	}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddTable("Sheet1", &excelize.Table{Range: "A1:D5", StyleName: "CorporateTable"})
+//
+// The following table shows the type of the table style elements used in
+// 'TableStyleElement.Type':
+//
+//	 Type                   | Type
+//	------------------------+------------------------
+//	 wholeTable             | secondSubtotalColumn
+//	 headerRow              | thirdSubtotalColumn
+//	 totalRow               | firstSubtotalRow
+//	 firstColumn            | secondSubtotalRow
+//	 lastColumn             | thirdSubtotalRow
+//	 firstRowStripe         | blankRow
+//	 secondRowStripe        | firstColumnSubheading
+//	 firstColumnStripe      | secondColumnSubheading
+//	 secondColumnStripe     | thirdColumnSubheading
+//	 firstHeaderCell        | firstRowSubheading
+//	 lastHeaderCell         | secondRowSubheading
+//	 firstTotalCell         | thirdRowSubheading
+//	 lastTotalCell          | pageFieldLabels
+//	 firstSubtotalColumn    | pageFieldValues
+//
+// The 'TableStyleElement.Size' specifies the number of rows or columns of the
+// stripe elements, the value should be between 1 and 9, the default value is
+// 1. This field doesn't take effect for other elements.
+func (f *File) AddTableStyle(style *TableStyle) error {
+	if style == nil {
+		return ErrParameterInvalid
+	}
+	if style.Name == "" {
+		return ErrParameterRequired
+	}
+	if utf8.RuneCountInString(style.Name) > MaxFieldLength {
+		return ErrNameLength
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if isBuiltInTableStyle(style.Name) {
+		return ErrExistsTableStyle
+	}
+	if s.TableStyles == nil {
+		s.TableStyles = &xlsxTableStyles{DefaultTableStyle: "TableStyleMedium2", DefaultPivotStyle: "PivotStyleLight16"}
+	}
+	for _, tableStyle := range s.TableStyles.TableStyles {
+		if strings.EqualFold(tableStyle.Name, style.Name) {
+			return ErrExistsTableStyle
+		}
+	}
+	tableStyle := &xlsxTableStyle{Name: style.Name}
+	if style.Table || style.Pivot {
+		tableStyle.Pivot, tableStyle.Table = boolPtr(style.Pivot), boolPtr(style.Table)
+	}
+	elements := make(map[string]bool)
+	for _, element := range style.Elements {
+		if !tableStyleElementTypes[element.Type] || elements[element.Type] {
+			return ErrParameterInvalid
+		}
+		elements[element.Type] = true
+		if s.Dxfs == nil || element.Format < 0 || element.Format >= len(s.Dxfs.Dxfs) {
+			return newInvalidStyleID(element.Format)
+		}
+		tableStyleElement := &xlsxTableStyleElement{Type: element.Type, DxfID: intPtr(element.Format)}
+		if strings.HasSuffix(element.Type, "Stripe") {
+			if element.Size < 0 || element.Size > 9 {
+				return ErrParameterInvalid
+			}
+			if element.Size > 1 {
+				tableStyleElement.Size = element.Size
+			}
+		}
+		tableStyle.TableStyleElement = append(tableStyle.TableStyleElement, tableStyleElement)
+	}
+	tableStyle.Count = len(tableStyle.TableStyleElement)
+	s.TableStyles.TableStyles = append(s.TableStyles.TableStyles, tableStyle)
+	s.TableStyles.Count = len(s.TableStyles.TableStyles)
+	return err
+}
+
+// tableStyleElementTypes defined the supported types of the table style
+// elements.
+var tableStyleElementTypes = map[string]bool{
+	"wholeTable": true, "headerRow": true, "totalRow": true, "firstColumn": true,
+	"lastColumn": true, "firstRowStripe": true, "secondRowStripe": true,
+	"firstColumnStripe": true, "secondColumnStripe": true,
+	"firstHeaderCell": true, "lastHeaderCell": true, "firstTotalCell": true,
+	"lastTotalCell": true, "firstSubtotalColumn": true,
+	"secondSubtotalColumn": true, "thirdSubtotalColumn": true,
+	"firstSubtotalRow": true, "secondSubtotalRow": true,
+	"thirdSubtotalRow": true, "blankRow": true, "firstColumnSubheading": true,
+	"secondColumnSubheading": true, "thirdColumnSubheading": true,
+	"firstRowSubheading": true, "secondRowSubheading": true,
+	"thirdRowSubheading": true, "pageFieldLabels": true, "pageFieldValues": true,
+}
+
+// isBuiltInTableStyle provides a function to check if the given name is the
+// name of the built-in table style or pivot table style.
+func isBuiltInTableStyle(name string) bool {
+	for _, prefix := range []string{
+		"TableStyleLight", "TableStyleMedium", "TableStyleDark",
+		"PivotStyleLight", "PivotStyleMedium", "PivotStyleDark",
+	} {
+		if len(name) > len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+			if _, err := strconv.Atoi(name[len(prefix):]); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// decodedTable directly maps the decoded table part with the content of the
+// part which it was decoded from.
+type decodedTable struct {
+	content []byte
+	table   *xlsxTable
+}
+
+// loadTable provides a function to get the decoded table by given table part
+// path, it returns nil if the part doesn't exist. The decoded table will be
+// reused until the content of the part was replaced, and the returned table
+// should not be modified.
+func (f *File) loadTable(path string) (*xlsxTable, error) {
+	content, ok := f.Pkg.Load(path)
+	if !ok {
+		return nil, nil
+	}
+	data, _ := content.([]byte)
+	if cached, ok := f.tables.Load(path); ok {
+		if c := cached.(*decodedTable); len(c.content) == len(data) &&
+			(len(data) == 0 || &c.content[0] == &data[0]) {
+			return c.table, nil
+		}
+	}
+	t := new(xlsxTable)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(data))).
+		Decode(t); err != nil && err != io.EOF {
+		return nil, err
+	}
+	f.tables.Store(path, &decodedTable{content: data, table: t})
+	return t, nil
+}
+
 // countTables provides a function to get table files count storage in the
 // folder xl/tables.
 func (f *File) countTables() int {
@@ -386,10 +573,10 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 	if err != nil {
 		return err
 	}
-	if ws.SheetPr != nil {
-		ws.SheetPr.FilterMode = true
+	if ws.SheetPr == nil {
+		ws.SheetPr = new(xlsxSheetPr)
 	}
-	ws.SheetPr = &xlsxSheetPr{FilterMode: true}
+	ws.SheetPr.FilterMode = true
 	filter := &xlsxAutoFilter{
 		Ref: ref,
 	}
@@ -74,6 +74,90 @@ func TestAddTable(t *testing.T) {
 	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B2"}))
 }
 
+func TestAddTableStyle(t *testing.T) {
+	f := NewFile()
+	header, err := f.NewConditionalStyle(&Style{
+		Font: &Font{Bold: true, Color: "FFFFFF"},
+		Fill: Fill{Type: "pattern", Color: []string{"1F4E78"}, Pattern: 1},
+	})
+	assert.NoError(t, err)
+	stripe, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddTableStyle(&TableStyle{
+		Name: "CorporateTable",
+		Elements: []TableStyleElement{
+			{Type: "wholeTable", Format: stripe},
+			{Type: "headerRow", Format: header},
+			{Type: "firstRowStripe", Format: stripe, Size: 2},
+			{Type: "secondRowStripe", Format: header, Size: 1},
+		},
+	}))
+	assert.NoError(t, f.AddTableStyle(&TableStyle{
+		Name: "CorporatePivot", Pivot: true,
+		Elements: []TableStyleElement{{Type: "headerRow", Format: header}, {Type: "blankRow", Format: stripe}},
+	}))
+	for idx, row := range [][]interface{}{{"Month", "Year", "Sales"}, {"Jan", 2023, 100}, {"Feb", 2023, 200}, {"Mar", 2023, 300}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:C4", StyleName: "CorporateTable"}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:           "Sheet1!A1:C4",
+		PivotTableRange:     "Sheet1!E1:G6",
+		Rows:                []PivotTableField{{Data: "Month"}},
+		Data:                []PivotTableField{{Data: "Sales"}},
+		PivotTableStyleName: "CorporatePivot",
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTableStyle.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddTableStyle.xlsx"))
+	assert.NoError(t, err)
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, styles.TableStyles.Count)
+	tableStyle := styles.TableStyles.TableStyles[0]
+	assert.Equal(t, "CorporateTable", tableStyle.Name)
+	assert.Nil(t, tableStyle.Pivot)
+	assert.Nil(t, tableStyle.Table)
+	assert.Equal(t, 4, tableStyle.Count)
+	assert.Equal(t, &xlsxTableStyleElement{Type: "firstRowStripe", Size: 2, DxfID: intPtr(stripe)}, tableStyle.TableStyleElement[2])
+	assert.Equal(t, &xlsxTableStyleElement{Type: "secondRowStripe", DxfID: intPtr(header)}, tableStyle.TableStyleElement[3])
+	tableStyle = styles.TableStyles.TableStyles[1]
+	assert.True(t, *tableStyle.Pivot)
+	assert.False(t, *tableStyle.Table)
+	// Test add table style with the existing name
+	assert.Equal(t, ErrExistsTableStyle, f.AddTableStyle(&TableStyle{Name: "corporateTable"}))
+	assert.Equal(t, ErrExistsTableStyle, f.AddTableStyle(&TableStyle{Name: "TableStyleMedium2"}))
+	assert.Equal(t, ErrExistsTableStyle, f.AddTableStyle(&TableStyle{Name: "pivotStyleLight16"}))
+	assert.NoError(t, f.Close())
+
+	// Test add table style with invalid parameters
+	f = NewFile()
+	assert.Equal(t, ErrParameterInvalid, f.AddTableStyle(nil))
+	assert.Equal(t, ErrParameterRequired, f.AddTableStyle(&TableStyle{}))
+	assert.Equal(t, ErrNameLength, f.AddTableStyle(&TableStyle{Name: strings.Repeat("s", MaxFieldLength+1)}))
+	assert.Equal(t, newInvalidStyleID(0), f.AddTableStyle(&TableStyle{Name: "Style", Elements: []TableStyleElement{{Type: "headerRow"}}}))
+	stripe, err = f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, newInvalidStyleID(1), f.AddTableStyle(&TableStyle{Name: "Style", Elements: []TableStyleElement{{Type: "headerRow", Format: 1}}}))
+	assert.Equal(t, ErrParameterInvalid, f.AddTableStyle(&TableStyle{Name: "Style", Elements: []TableStyleElement{{Type: "unknown"}}}))
+	assert.Equal(t, ErrParameterInvalid, f.AddTableStyle(&TableStyle{Name: "Style", Elements: []TableStyleElement{{Type: "headerRow", Format: stripe}, {Type: "headerRow", Format: stripe}}}))
+	assert.Equal(t, ErrParameterInvalid, f.AddTableStyle(&TableStyle{Name: "Style", Elements: []TableStyleElement{{Type: "firstColumnStripe", Format: stripe, Size: 10}}}))
+	// Test add table style without the table styles in the style sheet
+	styles, err = f.stylesReader()
+	assert.NoError(t, err)
+	styles.TableStyles = nil
+	assert.NoError(t, f.AddTableStyle(&TableStyle{Name: "Style", Table: true}))
+	assert.Equal(t, &xlsxTableStyles{Count: 1, DefaultTableStyle: "TableStyleMedium2", DefaultPivotStyle: "PivotStyleLight16",
+		TableStyles: []*xlsxTableStyle{{Name: "Style", Pivot: boolPtr(false), Table: boolPtr(true)}}}, styles.TableStyles)
+	// Test add table style with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddTableStyle(&TableStyle{Name: "Style"}), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestSetTableHeader(t *testing.T) {
 	f := NewFile()
 	_, err := f.setTableHeader("Sheet1", true, 1, 0, 1)
@@ -101,6 +185,16 @@ func TestAutoFilter(t *testing.T) {
 		})
 	}
 
+	// Test add auto filter with the existing sheet properties
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{TabColorRGB: stringPtr("FF0000"), CodeName: stringPtr("Sheet1Code")}))
+	assert.NoError(t, f.AutoFilter("Sheet1", "D4:B1", nil))
+	opts, err := f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "FF0000", *opts.TabColorRGB)
+	assert.Equal(t, "Sheet1Code", *opts.CodeName)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.True(t, ws.(*xlsxWorksheet).SheetPr.FilterMode)
 	// Test add auto filter with invalid sheet name
 	assert.EqualError(t, f.AutoFilter("Sheet:1", "A1:B1", nil), ErrSheetNameInvalid.Error())
 	// Test add auto filter with illegal cell reference
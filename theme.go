@@ -0,0 +1,424 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// themeRelIDExp defined the regular expression to match the relationship ID
+// attributes which reference the pictures in the theme fills.
+var themeRelIDExp = regexp.MustCompile(`(\sr:(?:embed|link))="([^"]*)"`)
+
+// GetTheme provides a function to get the theme name, the twelve colors of
+// the color scheme and the major and minor fonts of the font scheme of the
+// workbook. The colors are returned in the RRGGBB hex format, and the colors
+// bound to the operating system elements are resolved by their last computed
+// value. An empty theme will be returned if the workbook doesn't contain a
+// theme. For example, get the first accent color of the theme:
+//
+//	theme, err := f.GetTheme()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(theme.Colors.Accent1)
+func (f *File) GetTheme() (*Theme, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	theme := f.Theme
+	if theme == nil {
+		var err error
+		if theme, err = f.themeReader(); err != nil || theme == nil {
+			return &Theme{}, err
+		}
+	}
+	clrScheme, fontScheme := theme.ThemeElements.ClrScheme, theme.ThemeElements.FontScheme
+	return &Theme{
+		Name:            theme.Name,
+		ColorSchemeName: clrScheme.Name,
+		Colors: ThemeColors{
+			Dark1:             getThemeColor(clrScheme.Dk1),
+			Light1:            getThemeColor(clrScheme.Lt1),
+			Dark2:             getThemeColor(clrScheme.Dk2),
+			Light2:            getThemeColor(clrScheme.Lt2),
+			Accent1:           getThemeColor(clrScheme.Accent1),
+			Accent2:           getThemeColor(clrScheme.Accent2),
+			Accent3:           getThemeColor(clrScheme.Accent3),
+			Accent4:           getThemeColor(clrScheme.Accent4),
+			Accent5:           getThemeColor(clrScheme.Accent5),
+			Accent6:           getThemeColor(clrScheme.Accent6),
+			Hyperlink:         getThemeColor(clrScheme.Hlink),
+			FollowedHyperlink: getThemeColor(clrScheme.FolHlink),
+		},
+		FontSchemeName: fontScheme.Name,
+		MajorFont:      getThemeFont(fontScheme.MajorFont),
+		MinorFont:      getThemeFont(fontScheme.MinorFont),
+	}, nil
+}
+
+// SetTheme provides a function to set the theme name, the colors of the color
+// scheme and the major and minor fonts of the font scheme of the workbook.
+// The empty fields of the given theme settings will keep the current value of
+// the workbook theme. The colors should be specified in the RRGGBB hex format
+// with or without the leading "#". The default theme will be created if the
+// workbook doesn't contain a theme. The cell styles, charts and shapes
+// referencing the theme colors and fonts will follow the new theme. For
+// example, set the first two accent colors and the body font of the theme:
+//
+//	err := f.SetTheme(&excelize.Theme{
+//	    Colors: excelize.ThemeColors{
+//	        Accent1: "1F4E79",
+//	        Accent2: "C55A11",
+//	    },
+//	    MinorFont: excelize.ThemeFont{Latin: "Arial"},
+//	})
+func (f *File) SetTheme(opts *Theme) error {
+	if opts == nil {
+		return ErrParameterInvalid
+	}
+	colors := []string{
+		opts.Colors.Dark1, opts.Colors.Light1, opts.Colors.Dark2, opts.Colors.Light2,
+		opts.Colors.Accent1, opts.Colors.Accent2, opts.Colors.Accent3,
+		opts.Colors.Accent4, opts.Colors.Accent5, opts.Colors.Accent6,
+		opts.Colors.Hyperlink, opts.Colors.FollowedHyperlink,
+	}
+	for idx, color := range colors {
+		if color == "" {
+			continue
+		}
+		RGB, ok := parseThemeColor(color)
+		if !ok {
+			return newInvalidThemeColorError(color)
+		}
+		colors[idx] = RGB
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	theme, err := f.prepareTheme()
+	if err != nil {
+		return err
+	}
+	f.setPartModified(defaultXMLPathTheme)
+	if opts.Name != "" {
+		theme.Name = opts.Name
+	}
+	if opts.ColorSchemeName != "" {
+		theme.ThemeElements.ClrScheme.Name = opts.ColorSchemeName
+	}
+	s := &theme.ThemeElements.ClrScheme
+	for idx, clr := range []*xlsxCTColor{
+		&s.Dk1, &s.Lt1, &s.Dk2, &s.Lt2, &s.Accent1, &s.Accent2, &s.Accent3,
+		&s.Accent4, &s.Accent5, &s.Accent6, &s.Hlink, &s.FolHlink,
+	} {
+		if colors[idx] != "" {
+			*clr = xlsxCTColor{SrgbClr: &attrValString{Val: stringPtr(colors[idx])}}
+		}
+	}
+	if opts.FontSchemeName != "" {
+		theme.ThemeElements.FontScheme.Name = opts.FontSchemeName
+	}
+	setThemeFont(&theme.ThemeElements.FontScheme.MajorFont, opts.MajorFont)
+	setThemeFont(&theme.ThemeElements.FontScheme.MinorFont, opts.MinorFont)
+	return err
+}
+
+// LoadTheme provides a function to replace the workbook theme by the theme
+// in the given Office theme (.thmx) file, the pictures referenced by the
+// theme fills will be imported into the workbook. For example, apply a
+// corporate theme to the workbook:
+//
+//	file, err := os.ReadFile("Brand.thmx")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.LoadTheme(file); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+func (f *File) LoadTheme(file []byte) error {
+	zr, err := zip.NewReader(bytes.NewReader(file), int64(len(file)))
+	if err != nil {
+		return ErrThemeFile
+	}
+	unzipSize, unzipSizeLimit := int64(0), f.options.UnzipSizeLimit
+	if unzipSizeLimit == 0 {
+		unzipSizeLimit = UnzipSizeLimit
+	}
+	for _, v := range zr.File {
+		if unzipSize += v.FileInfo().Size(); unzipSize > unzipSizeLimit {
+			return newUnzipSizeLimitError(unzipSizeLimit)
+		}
+	}
+	partName := f.getThemeFilePartName(zr)
+	content, err := readThemeFilePart(zr, partName)
+	if err != nil {
+		return err
+	}
+	theme := xlsxTheme{XMLNSa: NameSpaceDrawingML.Value, XMLNSr: SourceRelationship.Value}
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+		Decode(&theme); err != nil && err != io.EOF {
+		return ErrThemeFile
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err = f.prepareTheme(); err != nil {
+		return err
+	}
+	if err = f.importThemePictures(zr, partName, &theme); err != nil {
+		return err
+	}
+	f.Theme = &theme
+	f.setPartModified(defaultXMLPathTheme)
+	return nil
+}
+
+// themePicture directly maps the picture referenced by the theme fills in the
+// Office theme file, the file and extension are empty for the external
+// picture.
+type themePicture struct {
+	rel  xlsxRelationship
+	file []byte
+	ext  string
+}
+
+// importThemePictures provides a function to import the pictures referenced
+// by the fills of the theme in the given Office theme file into the workbook,
+// and replace the relationships of the workbook theme. The relationship IDs
+// in the theme fills will be updated to the IDs of the imported pictures.
+func (f *File) importThemePictures(zr *zip.Reader, partName string, theme *xlsxTheme) error {
+	var themeRels xlsxRelationships
+	if content, err := readThemeFilePart(zr, path.Join(path.Dir(partName), "_rels", path.Base(partName)+".rels")); err == nil {
+		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+			Decode(&themeRels); err != nil && err != io.EOF {
+			return ErrThemeFile
+		}
+	}
+	fmtScheme := &theme.ThemeElements.FmtScheme
+	var pictures []themePicture
+	IDs := make(map[string]string)
+	for _, ref := range themeRelIDExp.FindAllStringSubmatch(fmtScheme.FillStyleLst.FillStyleLst+fmtScheme.BgFillStyleLst.BgFillStyleLst, -1) {
+		if _, ok := IDs[ref[2]]; ok {
+			continue
+		}
+		picture, ok := readThemePicture(zr, partName, &themeRels, ref[2])
+		if !ok {
+			return ErrThemeFile
+		}
+		pictures = append(pictures, picture)
+		IDs[ref[2]] = "rId" + strconv.Itoa(len(pictures))
+	}
+	rels := &xlsxRelationships{}
+	for idx, picture := range pictures {
+		rel := xlsxRelationship{ID: "rId" + strconv.Itoa(idx+1), Type: SourceRelationshipImage, Target: picture.rel.Target, TargetMode: picture.rel.TargetMode}
+		if picture.file != nil {
+			rel.Target = "../media/" + path.Base(f.addMedia(picture.file, picture.ext))
+		}
+		rels.Relationships = append(rels.Relationships, rel)
+	}
+	replaceID := func(attr string) string {
+		match := themeRelIDExp.FindStringSubmatch(attr)
+		return match[1] + `="` + IDs[match[2]] + `"`
+	}
+	fmtScheme.FillStyleLst.FillStyleLst = themeRelIDExp.ReplaceAllStringFunc(fmtScheme.FillStyleLst.FillStyleLst, replaceID)
+	fmtScheme.BgFillStyleLst.BgFillStyleLst = themeRelIDExp.ReplaceAllStringFunc(fmtScheme.BgFillStyleLst.BgFillStyleLst, replaceID)
+	relsPath := path.Join(path.Dir(defaultXMLPathTheme), "_rels", path.Base(defaultXMLPathTheme)+".rels")
+	if len(rels.Relationships) == 0 {
+		f.Relationships.Delete(relsPath)
+		f.Pkg.Delete(relsPath)
+		return nil
+	}
+	f.Relationships.Store(relsPath, rels)
+	f.setPartModified(relsPath)
+	return f.setContentTypePartImageExtensions()
+}
+
+// readThemePicture provides a function to read the picture by given
+// relationship ID in the Office theme file, it returns false if the picture
+// doesn't exist.
+func readThemePicture(zr *zip.Reader, partName string, themeRels *xlsxRelationships, rID string) (themePicture, bool) {
+	for _, rel := range themeRels.Relationships {
+		if rel.ID != rID || rel.Type != SourceRelationshipImage {
+			continue
+		}
+		if rel.TargetMode == "External" {
+			return themePicture{rel: rel}, true
+		}
+		ext, ok := supportedImageTypes[strings.ToLower(path.Ext(rel.Target))]
+		if !ok {
+			break
+		}
+		target := path.Join(path.Dir(partName), rel.Target)
+		if strings.HasPrefix(rel.Target, "/") {
+			target = path.Clean(rel.Target)
+		}
+		file, err := readThemeFilePart(zr, strings.TrimPrefix(target, "/"))
+		if err != nil {
+			break
+		}
+		return themePicture{rel: rel, file: file, ext: ext}, true
+	}
+	return themePicture{}, false
+}
+
+// getThemeFilePartName provides a function to get the path of the theme part
+// in the given Office theme file by the package relationships.
+func (f *File) getThemeFilePartName(zr *zip.Reader) string {
+	partName := "theme/theme/theme1.xml"
+	content, err := readThemeFilePart(zr, "_rels/.rels")
+	if err != nil {
+		return partName
+	}
+	var rels xlsxRelationships
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+		Decode(&rels); err != nil && err != io.EOF {
+		return partName
+	}
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipOfficeDocument {
+			return strings.TrimPrefix(path.Clean("/"+rel.Target), "/")
+		}
+	}
+	return partName
+}
+
+// readThemeFilePart provides a function to read the part in the given Office
+// theme file by the given path.
+func readThemeFilePart(zr *zip.Reader, name string) ([]byte, error) {
+	for _, v := range zr.File {
+		if strings.EqualFold(strings.ReplaceAll(v.Name, "\\", "/"), name) {
+			return readFile(v)
+		}
+	}
+	return nil, ErrThemeFile
+}
+
+// prepareTheme provides a function to get the workbook theme, and create the
+// default theme part with the relationship and the content type if the
+// workbook doesn't contain a theme.
+func (f *File) prepareTheme() (*xlsxTheme, error) {
+	if f.Theme != nil {
+		return f.Theme, nil
+	}
+	if _, ok := f.Pkg.Load(defaultXMLPathTheme); !ok {
+		if err := f.addThemeRels(); err != nil {
+			return nil, err
+		}
+		f.Pkg.Store(defaultXMLPathTheme, []byte(xml.Header+templateTheme))
+	}
+	theme, err := f.themeReader()
+	if err != nil {
+		return nil, err
+	}
+	f.Theme = theme
+	return f.Theme, nil
+}
+
+// addThemeRels provides a function to add the content type and the workbook
+// relationship of the theme part if they don't exist.
+func (f *File) addThemeRels() error {
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	var ok bool
+	content.mu.Lock()
+	for _, override := range content.Overrides {
+		if override.PartName == "/"+defaultXMLPathTheme {
+			ok = true
+			break
+		}
+	}
+	content.mu.Unlock()
+	if !ok {
+		if err = f.setContentTypes("/"+defaultXMLPathTheme, ContentTypeTheme); err != nil {
+			return err
+		}
+	}
+	rels, err := f.loadRels(f.getWorkbookRelsPath())
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipTheme {
+				rels.mu.Unlock()
+				return err
+			}
+		}
+		rels.mu.Unlock()
+	}
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipTheme, "theme/theme1.xml", "")
+	return err
+}
+
+// getThemeColor provides a function to get the RRGGBB hex color by given
+// color scheme slot of the theme.
+func getThemeColor(clr xlsxCTColor) string {
+	if clr.SrgbClr != nil && clr.SrgbClr.Val != nil {
+		return strings.ToUpper(*clr.SrgbClr.Val)
+	}
+	if clr.SysClr != nil {
+		return strings.ToUpper(clr.SysClr.LastClr)
+	}
+	return ""
+}
+
+// parseThemeColor provides a function to parse the given RRGGBB hex color
+// with or without the leading "#", and returns the color in upper case.
+func parseThemeColor(color string) (string, bool) {
+	RGB := strings.ToUpper(strings.TrimPrefix(color, "#"))
+	if len(RGB) != 6 {
+		return RGB, false
+	}
+	_, err := strconv.ParseUint(RGB, 16, 32)
+	return RGB, err == nil
+}
+
+// getThemeFont provides a function to get the typefaces by given font
+// collection of the theme font scheme.
+func getThemeFont(fonts xlsxFontCollection) ThemeFont {
+	var font ThemeFont
+	if fonts.Latin != nil {
+		font.Latin = fonts.Latin.Typeface
+	}
+	if fonts.Ea != nil {
+		font.EastAsian = fonts.Ea.Typeface
+	}
+	if fonts.Cs != nil {
+		font.ComplexScript = fonts.Cs.Typeface
+	}
+	return font
+}
+
+// setThemeFont provides a function to set the non-empty typefaces of the
+// given font settings to the font collection of the theme font scheme.
+func setThemeFont(fonts *xlsxFontCollection, font ThemeFont) {
+	if font.Latin != "" {
+		fonts.Latin = &xlsxCTTextFont{Typeface: font.Latin}
+	}
+	if font.EastAsian != "" {
+		fonts.Ea = &xlsxCTTextFont{Typeface: font.EastAsian}
+	}
+	if font.ComplexScript != "" {
+		fonts.Cs = &xlsxCTTextFont{Typeface: font.ComplexScript}
+	}
+}
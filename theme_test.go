@@ -0,0 +1,254 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTheme(t *testing.T) {
+	f := NewFile()
+	theme, err := f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, &Theme{
+		Name:            "Office Theme",
+		ColorSchemeName: "Office",
+		Colors: ThemeColors{
+			Dark1: "000000", Light1: "FFFFFF", Dark2: "44546A", Light2: "E7E6E6",
+			Accent1: "5B9BD5", Accent2: "ED7D31", Accent3: "A5A5A5",
+			Accent4: "FFC000", Accent5: "4472C4", Accent6: "70AD47",
+			Hyperlink: "0563C1", FollowedHyperlink: "954F72",
+		},
+		FontSchemeName: "Office",
+		MajorFont:      ThemeFont{Latin: "Calibri Light"},
+		MinorFont:      ThemeFont{Latin: "Calibri"},
+	}, theme)
+	// Test get theme without theme part
+	f.Theme = nil
+	f.Pkg.Delete(defaultXMLPathTheme)
+	theme, err = f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, &Theme{}, theme)
+	// Test get theme with unsupported charset theme
+	f.Pkg.Store(defaultXMLPathTheme, MacintoshCyrillicCharset)
+	_, err = f.GetTheme()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetTheme(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetTheme(&Theme{
+		Name:            "Brand",
+		ColorSchemeName: "Brand Colors",
+		Colors:          ThemeColors{Dark1: "#1f1f1f", Accent1: "1F4E79"},
+		FontSchemeName:  "Brand Fonts",
+		MajorFont:       ThemeFont{Latin: "Georgia", EastAsian: "SimSun", ComplexScript: "Arial"},
+		MinorFont:       ThemeFont{Latin: "Arial"},
+	}))
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFFFF"}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetTheme.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSetTheme.xlsx"))
+	assert.NoError(t, err)
+	theme, err := f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "Brand", theme.Name)
+	assert.Equal(t, "Brand Colors", theme.ColorSchemeName)
+	assert.Equal(t, "1F1F1F", theme.Colors.Dark1)
+	assert.Equal(t, "FFFFFF", theme.Colors.Light1)
+	assert.Equal(t, "1F4E79", theme.Colors.Accent1)
+	assert.Equal(t, "ED7D31", theme.Colors.Accent2)
+	assert.Equal(t, "Brand Fonts", theme.FontSchemeName)
+	assert.Equal(t, ThemeFont{Latin: "Georgia", EastAsian: "SimSun", ComplexScript: "Arial"}, theme.MajorFont)
+	assert.Equal(t, ThemeFont{Latin: "Arial"}, theme.MinorFont)
+	// Test the theme colors used by the cell styles follow the new theme
+	assert.Equal(t, []string{"FFFFFF", "1F1F1F"}, f.getThemeColorList()[:2])
+	// Test get theme keep the theme unmodified
+	assert.False(t, f.isPartModified(defaultXMLPathTheme))
+	assert.NoError(t, f.SetTheme(&Theme{Name: "Brand"}))
+	assert.True(t, f.isPartModified(defaultXMLPathTheme))
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test set theme with nil options
+	assert.Equal(t, ErrParameterInvalid, f.SetTheme(nil))
+	// Test set theme with invalid colors
+	for _, color := range []string{"FFF", "GGGGGG", "#FF00FF00"} {
+		assert.EqualError(t, f.SetTheme(&Theme{Colors: ThemeColors{Hyperlink: color}}), newInvalidThemeColorError(color).Error())
+	}
+	// Test set theme without theme part
+	f.Theme = nil
+	f.Pkg.Delete(defaultXMLPathTheme)
+	assert.NoError(t, f.SetTheme(&Theme{Colors: ThemeColors{Accent6: "00B050"}}))
+	assert.NoError(t, f.SetTheme(&Theme{Name: "Brand"}))
+	theme, err = f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "Brand", theme.Name)
+	assert.Equal(t, "00B050", theme.Colors.Accent6)
+	assert.Equal(t, "5B9BD5", theme.Colors.Accent1)
+	rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	var count int
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipTheme {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+	var overrides int
+	for _, override := range f.ContentTypes.Overrides {
+		if override.ContentType == ContentTypeTheme {
+			overrides++
+		}
+	}
+	assert.Equal(t, 1, overrides)
+	// Test set theme with unsupported charset theme
+	f.Theme = nil
+	f.Pkg.Store(defaultXMLPathTheme, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetTheme(&Theme{Name: "Brand"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test set theme without theme part, content type and relationship
+	f.Theme = nil
+	f.Pkg.Delete(defaultXMLPathTheme)
+	f.ContentTypes.Overrides = nil
+	rels.Relationships = nil
+	assert.NoError(t, f.SetTheme(&Theme{Name: "Brand"}))
+	assert.Equal(t, []xlsxOverride{{PartName: "/xl/theme/theme1.xml", ContentType: ContentTypeTheme}}, f.ContentTypes.Overrides)
+	assert.Equal(t, []xlsxRelationship{{ID: "rId1", Type: SourceRelationshipTheme, Target: "theme/theme1.xml"}}, rels.Relationships)
+	// Test set theme with unsupported charset workbook relationships
+	f.Theme = nil
+	f.Pkg.Delete(defaultXMLPathTheme)
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetTheme(&Theme{Name: "Brand"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test set theme with unsupported charset content types
+	f.Theme, f.ContentTypes = nil, nil
+	f.Pkg.Delete(defaultXMLPathTheme)
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetTheme(&Theme{Name: "Brand"}), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestLoadTheme(t *testing.T) {
+	brand := strings.Replace(strings.Replace(templateTheme, `name="Office Theme"`, `name="Brand"`, 1),
+		`<a:accent1><a:srgbClr val="5B9BD5"/>`, `<a:accent1><a:srgbClr val="1F4E79"/>`, 1)
+	newThemeFile := func(parts map[string]string) []byte {
+		buf := new(bytes.Buffer)
+		zw := zip.NewWriter(buf)
+		for name, content := range parts {
+			fi, err := zw.Create(name)
+			assert.NoError(t, err)
+			_, err = fi.Write([]byte(content))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+	f := NewFile()
+	assert.NoError(t, f.LoadTheme(newThemeFile(map[string]string{
+		"_rels/.rels":            `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="theme/theme/theme1.xml"/></Relationships>`,
+		"theme/theme/theme1.xml": brand,
+	})))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestLoadTheme.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestLoadTheme.xlsx"))
+	assert.NoError(t, err)
+	theme, err := f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "Brand", theme.Name)
+	assert.Equal(t, "1F4E79", theme.Colors.Accent1)
+	assert.False(t, f.isPartModified(defaultXMLPathTheme))
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test load theme file without package relationships
+	assert.NoError(t, f.LoadTheme(newThemeFile(map[string]string{"theme/theme/theme1.xml": brand})))
+	theme, err = f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "Brand", theme.Name)
+	// Test load theme file with invalid package relationships
+	assert.NoError(t, f.LoadTheme(newThemeFile(map[string]string{
+		"_rels/.rels":            "<Relationships",
+		"theme/theme/theme1.xml": brand,
+	})))
+	// Test load theme with invalid theme files
+	assert.Equal(t, ErrThemeFile, f.LoadTheme([]byte("Brand")))
+	assert.Equal(t, ErrThemeFile, f.LoadTheme(newThemeFile(map[string]string{"theme/theme1.xml": brand})))
+	assert.Equal(t, ErrThemeFile, f.LoadTheme(newThemeFile(map[string]string{"theme/theme/theme1.xml": "<a:theme"})))
+	assert.Equal(t, ErrThemeFile, f.LoadTheme(newThemeFile(map[string]string{"theme/theme/theme1.xml": templateRels})))
+	// Test load theme file with the picture fills
+	picture, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	blipFill := func(rID string) string {
+		return `<a:blipFill><a:blip r:embed="` + rID + `"/><a:stretch><a:fillRect/></a:stretch></a:blipFill>`
+	}
+	pictureTheme := strings.Replace(strings.Replace(brand, `<a:fillStyleLst>`, `<a:fillStyleLst>`+blipFill("rId3"), 1),
+		`<a:bgFillStyleLst>`, `<a:bgFillStyleLst>`+blipFill("rId3")+blipFill("rId5"), 1)
+	themeRels := `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="../media/image1.png"/>` +
+		`<Relationship Id="rId5" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="https://example.com/bg.png" TargetMode="External"/></Relationships>`
+	f = NewFile()
+	assert.NoError(t, f.LoadTheme(newThemeFile(map[string]string{
+		"theme/theme/theme1.xml":            pictureTheme,
+		"theme/theme/_rels/theme1.xml.rels": themeRels,
+		"theme/media/image1.png":            string(picture),
+	})))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	rels, err := f.loadRels("xl/theme/_rels/theme1.xml.rels")
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxRelationship{
+		{ID: "rId1", Type: SourceRelationshipImage, Target: "../media/image1.png"},
+		{ID: "rId2", Type: SourceRelationshipImage, Target: "https://example.com/bg.png", TargetMode: "External"},
+	}, rels.Relationships)
+	media, ok := f.Pkg.Load("xl/media/image1.png")
+	assert.True(t, ok)
+	assert.Equal(t, picture, media)
+	content, ok := f.Pkg.Load(defaultXMLPathTheme)
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<fillStyleLst>`+blipFill("rId1"))
+	assert.Contains(t, string(content.([]byte)), `<bgFillStyleLst>`+blipFill("rId1")+blipFill("rId2"))
+	assert.Contains(t, string(f.readXML(defaultXMLPathContentTypes)), `<Default Extension="png" ContentType="image/png"></Default>`)
+	// Test load theme file without picture fills remove the theme relationships
+	assert.NoError(t, f.LoadTheme(newThemeFile(map[string]string{"theme/theme/theme1.xml": brand})))
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	_, ok = f.Pkg.Load("xl/theme/_rels/theme1.xml.rels")
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+	// Test load theme file with invalid picture fills
+	f = NewFile()
+	for _, parts := range []map[string]string{
+		{"theme/theme/theme1.xml": pictureTheme},
+		{"theme/theme/theme1.xml": pictureTheme, "theme/theme/_rels/theme1.xml.rels": themeRels},
+		{"theme/theme/theme1.xml": pictureTheme, "theme/theme/_rels/theme1.xml.rels": "<Relationships"},
+		{"theme/theme/theme1.xml": pictureTheme, "theme/media/image1.png": string(picture),
+			"theme/theme/_rels/theme1.xml.rels": strings.ReplaceAll(themeRels, "image1.png", "image1.txt")},
+	} {
+		assert.Equal(t, ErrThemeFile, f.LoadTheme(newThemeFile(parts)))
+	}
+	_, ok = f.Pkg.Load("xl/media/image1.png")
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+	// Test load theme file exceeds the unzip size limit
+	f.options.UnzipSizeLimit = 100
+	assert.EqualError(t, f.LoadTheme(newThemeFile(map[string]string{"theme/theme/theme1.xml": brand})), newUnzipSizeLimitError(100).Error())
+	// Test load theme with unsupported charset theme
+	f = NewFile()
+	f.Theme = nil
+	f.Pkg.Store(defaultXMLPathTheme, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.LoadTheme(newThemeFile(map[string]string{"theme/theme/theme1.xml": brand})), "XML syntax error on line 1: invalid UTF-8")
+}
@@ -81,7 +81,7 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 // getSheetComments provides the method to get the target comment reference by
 // given worksheet file path.
 func (f *File) getSheetComments(sheetFile string) string {
-	rels, _ := f.relsReader("xl/worksheets/_rels/" + sheetFile + ".rels")
+	rels, _ := f.loadRels("xl/worksheets/_rels/" + sheetFile + ".rels")
 	if sheetRels := rels; sheetRels != nil {
 		sheetRels.mu.Lock()
 		defer sheetRels.mu.Unlock()
@@ -152,6 +152,7 @@ func (f *File) DeleteComment(sheet, cell string) error {
 			}
 			cmts.CommentList.Comment = nil
 		}
+		f.setPartModified(commentsXML)
 		f.Comments[commentsXML] = cmts
 	}
 	return err
@@ -206,7 +207,7 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 			RPr: &xlsxRPr{
 				Sz: &attrValFloat{Val: float64Ptr(9)},
 				Color: &xlsxColor{
-					Indexed: 81,
+					Indexed: intPtr(81),
 				},
 				RFont:  &attrValString{Val: stringPtr(defaultFont)},
 				Family: &attrValInt{Val: intPtr(2)},
@@ -222,6 +223,7 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 		cmt.Text.R = append(cmt.Text.R, r)
 	}
 	cmts.CommentList.Comment = append(cmts.CommentList.Comment, cmt)
+	f.setPartModified(commentsXML)
 	f.Comments[commentsXML] = cmts
 	return err
 }
@@ -264,7 +266,7 @@ func (f *File) commentsReader(path string) (*xlsxComments, error) {
 // serialize structure.
 func (f *File) commentsWriter() {
 	for path, c := range f.Comments {
-		if c != nil {
+		if c != nil && f.isPartModified(path) {
 			v, _ := xml.Marshal(c)
 			f.saveFileList(path, v)
 		}
@@ -425,6 +427,7 @@ func (f *File) DeleteFormControl(sheet, cell string) error {
 			break
 		}
 	}
+	f.setPartModified(drawingVML)
 	f.VMLDrawing[drawingVML] = vml
 	return err
 }
@@ -467,7 +470,7 @@ func (f *File) decodeVMLDrawingReader(path string) (*decodeVmlDrawing, error) {
 // after serialize structure.
 func (f *File) vmlDrawingWriter() {
 	for path, vml := range f.VMLDrawing {
-		if vml != nil {
+		if vml != nil && f.isPartModified(path) {
 			v, _ := xml.Marshal(vml)
 			f.Pkg.Store(path, v)
 		}
@@ -880,6 +883,7 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 		Val:         string(s[13 : len(s)-14]),
 	}
 	vml.Shape = append(vml.Shape, shape)
+	f.setPartModified(drawingVML)
 	f.VMLDrawing[drawingVML] = vml
 	return err
 }
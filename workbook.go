@@ -47,7 +47,7 @@ func (f *File) SetWorkbookProps(opts *WorkbookPropsOptions) error {
 // GetWorkbookProps provides a function to gets workbook properties.
 func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 	var opts WorkbookPropsOptions
-	wb, err := f.workbookReader()
+	wb, err := f.loadWorkbook()
 	if err != nil {
 		return opts, err
 	}
@@ -144,7 +144,7 @@ func (f *File) setWorkbook(name string, sheetID, rid int) {
 // getWorkbookPath provides a function to get the path of the workbook.xml in
 // the spreadsheet.
 func (f *File) getWorkbookPath() (path string) {
-	if rels, _ := f.relsReader("_rels/.rels"); rels != nil {
+	if rels, _ := f.loadRels("_rels/.rels"); rels != nil {
 		rels.mu.Lock()
 		defer rels.mu.Unlock()
 		for _, rel := range rels.Relationships {
@@ -171,8 +171,16 @@ func (f *File) getWorkbookRelsPath() (path string) {
 }
 
 // workbookReader provides a function to get the pointer to the workbook.xml
-// structure after deserialization.
+// structure after deserialization, and mark the workbook as modified.
 func (f *File) workbookReader() (*xlsxWorkbook, error) {
+	wb, err := f.loadWorkbook()
+	f.setPartModified(f.getWorkbookPath())
+	return wb, err
+}
+
+// loadWorkbook provides a function to get the pointer to the workbook.xml
+// structure after deserialization without marking the workbook as modified.
+func (f *File) loadWorkbook() (*xlsxWorkbook, error) {
 	var err error
 	if f.WorkBook == nil {
 		wbPath := f.getWorkbookPath()
@@ -191,9 +199,11 @@ func (f *File) workbookReader() (*xlsxWorkbook, error) {
 }
 
 // workBookWriter provides a function to save workbook.xml after serialize
-// structure.
+// structure. The 1900 date compatibility will be disabled in the output when
+// saving the spreadsheet in the Strict Open XML conformance class.
 func (f *File) workBookWriter() {
-	if f.WorkBook != nil {
+	strict := f.options != nil && f.options.StrictOpenXML
+	if f.WorkBook != nil && (strict || f.isPartModified(f.getWorkbookPath())) {
 		if f.WorkBook.DecodeAlternateContent != nil {
 			f.WorkBook.AlternateContent = &xlsxAlternateContent{
 				Content: f.WorkBook.DecodeAlternateContent.Content,
@@ -201,7 +211,22 @@ func (f *File) workBookWriter() {
 			}
 		}
 		f.WorkBook.DecodeAlternateContent = nil
+		f.WorkBook.Conformance = ""
+		workbookPr := f.WorkBook.WorkbookPr
+		if strict && (workbookPr == nil || !workbookPr.Date1904) {
+			strictWorkbookPr := xlsxWorkbookPr{}
+			if workbookPr != nil {
+				strictWorkbookPr = *workbookPr
+			}
+			strictWorkbookPr.DateCompatibility = boolPtr(false)
+			f.WorkBook.WorkbookPr = &strictWorkbookPr
+		}
 		output, _ := xml.Marshal(f.WorkBook)
-		f.saveFileList(f.getWorkbookPath(), replaceRelationshipsBytes(f.replaceNameSpaceBytes(f.getWorkbookPath(), output)))
+		f.WorkBook.WorkbookPr = workbookPr
+		output = replaceRelationshipsBytes(f.replaceNameSpaceBytes(f.getWorkbookPath(), output))
+		if strict {
+			output = bytes.Replace(output, []byte("<workbook "), []byte(`<workbook conformance="strict" `), 1)
+		}
+		f.saveFileList(f.getWorkbookPath(), output)
 	}
 }
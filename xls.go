@@ -0,0 +1,791 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"unicode/utf16"
+
+	"github.com/richardlehane/mscfb"
+)
+
+// Record types of the Excel binary file format (BIFF8) used by the legacy
+// workbook reader.
+const (
+	biffRecordFormula     = 0x0006
+	biffRecordEOF         = 0x000A
+	biffRecordFilePass    = 0x002F
+	biffRecordDateMode    = 0x0022
+	biffRecordFont        = 0x0031
+	biffRecordContinue    = 0x003C
+	biffRecordColInfo     = 0x007D
+	biffRecordBoundSheet  = 0x0085
+	biffRecordPalette     = 0x0092
+	biffRecordMulRk       = 0x00BD
+	biffRecordMulBlank    = 0x00BE
+	biffRecordXF          = 0x00E0
+	biffRecordMergedCells = 0x00E5
+	biffRecordSST         = 0x00FC
+	biffRecordLabelSST    = 0x00FD
+	biffRecordBlank       = 0x0201
+	biffRecordNumber      = 0x0203
+	biffRecordLabel       = 0x0204
+	biffRecordBoolErr     = 0x0205
+	biffRecordString      = 0x0207
+	biffRecordRow         = 0x0208
+	biffRecordRk          = 0x027E
+	biffRecordFormat      = 0x041E
+	biffRecordBOF         = 0x0809
+	biffVersion8          = 0x0600
+)
+
+// biffErrors defined the error values of the BOOLERR and FORMULA records.
+var biffErrors = map[byte]string{
+	0x00: formulaErrorNULL,
+	0x07: formulaErrorDIV,
+	0x0F: formulaErrorVALUE,
+	0x17: formulaErrorREF,
+	0x1D: formulaErrorNAME,
+	0x24: formulaErrorNUM,
+	0x2A: formulaErrorNA,
+	0x2B: formulaErrorGETTINGDATA,
+}
+
+// biffHorizontalAlignment and biffVerticalAlignment defined the alignment
+// types of the XF record.
+var (
+	biffHorizontalAlignment = []string{"", "left", "center", "right", "fill", "justify", "centerContinuous", "distributed"}
+	biffVerticalAlignment   = []string{"top", "center", "", "justify", "distributed"}
+)
+
+// xlsBoundSheet directly maps the sheet information of the BOUNDSHEET8
+// record.
+type xlsBoundSheet struct {
+	offset int
+	state  byte
+	name   string
+}
+
+// xlsXF directly maps the cell formatting of the XF record.
+type xlsXF struct {
+	font, numFmt int
+	style        Style
+}
+
+// xlsReader is used for reading the Workbook stream of the Excel binary file
+// format (BIFF8) into a spreadsheet.
+type xlsReader struct {
+	file     *File
+	stream   []byte
+	date1904 bool
+	sheets   []xlsBoundSheet
+	sst      []string
+	numFmts  map[int]string
+	fonts    []Font
+	xfs      []xlsXF
+	palette  []string
+	styles   map[int]int
+}
+
+// extractWorkbookStream provides a function to extract the BIFF8 Workbook
+// stream from the compound file binary of legacy workbook. It returns nil if
+// the given data isn't a legacy workbook.
+func extractWorkbookStream(raw []byte) []byte {
+	doc, err := mscfb.New(bytes.NewReader(raw))
+	if err != nil {
+		return nil
+	}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		if entry.Name == "Workbook" {
+			buf := make([]byte, entry.Size)
+			if i, _ := doc.Read(buf); i > 0 {
+				return buf[:i]
+			}
+		}
+	}
+	return nil
+}
+
+// openXLS provides a function to read the Workbook stream of the Excel binary
+// file format (BIFF8) into a new spreadsheet. The cell values, shared strings,
+// number formats, cell formats, column widths, row heights, merged cells and
+// worksheet names will be loaded. The cached results of the formulas are
+// loaded as cell values, and the formula expressions will be ignored.
+func openXLS(stream []byte, opts *Options) (*File, error) {
+	r := &xlsReader{
+		file:    NewFile(*opts),
+		stream:  stream,
+		numFmts: make(map[int]string),
+		palette: append([]string{}, IndexedColorMapping...),
+		styles:  make(map[int]int),
+	}
+	if err := r.readGlobals(); err != nil {
+		return nil, err
+	}
+	if err := r.file.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(r.date1904)}); err != nil {
+		return nil, err
+	}
+	for i, sheet := range r.sheets {
+		if i == 0 {
+			if err := r.file.SetSheetName(r.file.GetSheetName(0), sheet.name); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if _, err := r.file.NewSheet(sheet.name); err != nil {
+			return nil, err
+		}
+	}
+	for _, sheet := range r.sheets {
+		if err := r.readSheet(sheet); err != nil {
+			return nil, err
+		}
+	}
+	for _, sheet := range r.sheets {
+		if sheet.state != 0 {
+			if err := r.file.SetSheetVisible(sheet.name, false, sheet.state == 2); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return r.file, nil
+}
+
+// nextRecord provides a function to read the record at the given stream
+// offset, it returns the record type, record data and the offset of the next
+// record.
+func (r *xlsReader) nextRecord(offset int) (uint16, []byte, int, error) {
+	if offset+4 > len(r.stream) {
+		return 0, nil, offset, ErrWorkbookFileFormat
+	}
+	typ := binary.LittleEndian.Uint16(r.stream[offset:])
+	size := int(binary.LittleEndian.Uint16(r.stream[offset+2:]))
+	if offset+4+size > len(r.stream) {
+		return 0, nil, offset, ErrWorkbookFileFormat
+	}
+	return typ, r.stream[offset+4 : offset+4+size], offset + 4 + size, nil
+}
+
+// readSubstream provides a function to iterate the records of a substream
+// starting at the given offset until the EOF record, the BOF record and its
+// type will be checked. The CONTINUE records will be passed with the
+// preceding record.
+func (r *xlsReader) readSubstream(offset int, dt uint16, fn func(typ uint16, data [][]byte) error) error {
+	typ, data, next, err := r.nextRecord(offset)
+	if err != nil {
+		return err
+	}
+	if typ != biffRecordBOF || len(data) < 4 || binary.LittleEndian.Uint16(data) != biffVersion8 ||
+		binary.LittleEndian.Uint16(data[2:]) != dt {
+		return ErrWorkbookFileFormat
+	}
+	for {
+		if typ, data, next, err = r.nextRecord(next); err != nil {
+			return err
+		}
+		if typ == biffRecordEOF {
+			return nil
+		}
+		segments := [][]byte{data}
+		for next+4 <= len(r.stream) && binary.LittleEndian.Uint16(r.stream[next:]) == biffRecordContinue {
+			var cont []byte
+			if _, cont, next, err = r.nextRecord(next); err != nil {
+				return err
+			}
+			segments = append(segments, cont)
+		}
+		if err = fn(typ, segments); err != nil {
+			return err
+		}
+	}
+}
+
+// readGlobals provides a function to read the workbook globals substream.
+func (r *xlsReader) readGlobals() error {
+	return r.readSubstream(0, 0x0005, func(typ uint16, segments [][]byte) error {
+		data := segments[0]
+		switch typ {
+		case biffRecordFilePass:
+			return ErrUnsupportedEncryptMechanism
+		case biffRecordDateMode:
+			if len(data) < 2 {
+				return ErrWorkbookFileFormat
+			}
+			r.date1904 = binary.LittleEndian.Uint16(data) == 1
+		case biffRecordBoundSheet:
+			if len(data) < 8 {
+				return ErrWorkbookFileFormat
+			}
+			name, _, err := readXLUnicodeString(data[6:], 1)
+			if err != nil {
+				return err
+			}
+			if data[5] == 0 {
+				r.sheets = append(r.sheets, xlsBoundSheet{
+					offset: int(binary.LittleEndian.Uint32(data)), state: data[4] & 0x03, name: name,
+				})
+			}
+		case biffRecordFormat:
+			if len(data) < 2 {
+				return ErrWorkbookFileFormat
+			}
+			code, _, err := readXLUnicodeString(data[2:], 2)
+			if err != nil {
+				return err
+			}
+			r.numFmts[int(binary.LittleEndian.Uint16(data))] = code
+		case biffRecordFont:
+			return r.readFont(data)
+		case biffRecordPalette:
+			return r.readPalette(data)
+		case biffRecordXF:
+			return r.readXF(data)
+		case biffRecordSST:
+			return r.readSST(segments)
+		}
+		return nil
+	})
+}
+
+// readFont provides a function to read the FONT record.
+func (r *xlsReader) readFont(data []byte) error {
+	if len(data) < 15 {
+		return ErrWorkbookFileFormat
+	}
+	name, _, err := readXLUnicodeString(data[14:], 1)
+	if err != nil {
+		return err
+	}
+	flags := binary.LittleEndian.Uint16(data[2:])
+	font := Font{
+		Family:       name,
+		Size:         float64(binary.LittleEndian.Uint16(data)) / 20,
+		Italic:       flags&0x02 != 0,
+		Strike:       flags&0x08 != 0,
+		Bold:         binary.LittleEndian.Uint16(data[6:]) >= 700,
+		ColorIndexed: int(binary.LittleEndian.Uint16(data[4:])),
+	}
+	switch binary.LittleEndian.Uint16(data[8:]) {
+	case 1:
+		font.VertAlign = "superscript"
+	case 2:
+		font.VertAlign = "subscript"
+	}
+	switch data[10] {
+	case 0x01, 0x21:
+		font.Underline = "single"
+	case 0x02, 0x22:
+		font.Underline = "double"
+	}
+	r.fonts = append(r.fonts, font)
+	return nil
+}
+
+// readPalette provides a function to read the PALETTE record, which overrides
+// the colors of the indexed color table starting at the index 8.
+func (r *xlsReader) readPalette(data []byte) error {
+	if len(data) < 2 {
+		return ErrWorkbookFileFormat
+	}
+	count := int(binary.LittleEndian.Uint16(data))
+	if len(data) < 2+count*4 {
+		return ErrWorkbookFileFormat
+	}
+	for i := 0; i < count && i+8 < len(r.palette); i++ {
+		rgb := data[2+i*4 : 5+i*4]
+		r.palette[i+8] = fmt.Sprintf("%02X%02X%02X", rgb[0], rgb[1], rgb[2])
+	}
+	return nil
+}
+
+// getColor provides a function to get the RGB color by given color index of
+// the BIFF8 records, it returns an empty string for the system and automatic
+// colors.
+func (r *xlsReader) getColor(idx int) string {
+	if idx < 0 || idx >= 64 {
+		return ""
+	}
+	return r.palette[idx]
+}
+
+// readXF provides a function to read the XF record.
+func (r *xlsReader) readXF(data []byte) error {
+	if len(data) < 20 {
+		return ErrWorkbookFileFormat
+	}
+	xf := xlsXF{
+		font:   int(binary.LittleEndian.Uint16(data)),
+		numFmt: int(binary.LittleEndian.Uint16(data[2:])),
+	}
+	alignment := Alignment{
+		Horizontal:   biffHorizontalAlignment[data[6]&0x07],
+		WrapText:     data[6]&0x08 != 0,
+		TextRotation: int(data[7]),
+		Indent:       int(data[8] & 0x0F),
+		ShrinkToFit:  data[8]&0x10 != 0,
+	}
+	if vertical := int(data[6]>>4) & 0x07; vertical < len(biffVerticalAlignment) {
+		alignment.Vertical = biffVerticalAlignment[vertical]
+	}
+	if alignment != (Alignment{}) {
+		xf.style.Alignment = &alignment
+	}
+	border1, border2 := binary.LittleEndian.Uint32(data[10:]), binary.LittleEndian.Uint32(data[14:])
+	for _, b := range []struct {
+		typ          string
+		style, color uint32
+	}{
+		{"left", border1 & 0x0F, border1 >> 16 & 0x7F},
+		{"right", border1 >> 4 & 0x0F, border1 >> 23 & 0x7F},
+		{"top", border1 >> 8 & 0x0F, border2 & 0x7F},
+		{"bottom", border1 >> 12 & 0x0F, border2 >> 7 & 0x7F},
+	} {
+		if b.style != 0 {
+			xf.style.Border = append(xf.style.Border, Border{
+				Type: b.typ, Style: int(b.style), Color: r.getColor(int(b.color)),
+			})
+		}
+	}
+	if pattern := int(border2 >> 26); pattern != 0 {
+		fill := binary.LittleEndian.Uint16(data[18:])
+		xf.style.Fill = Fill{Type: "pattern", Pattern: pattern}
+		if color := r.getColor(int(fill & 0x7F)); color != "" {
+			xf.style.Fill.Color = []string{color}
+		}
+	}
+	r.xfs = append(r.xfs, xf)
+	return nil
+}
+
+// getStyleID provides a function to get the style index of the spreadsheet by
+// given XF record index, the styles will be created on demand.
+func (r *xlsReader) getStyleID(idx int) (int, error) {
+	if styleID, ok := r.styles[idx]; ok {
+		return styleID, nil
+	}
+	if idx < 0 || idx >= len(r.xfs) {
+		return 0, nil
+	}
+	xf := r.xfs[idx]
+	style := xf.style
+	// The font index 4 is omitted in the FONT records
+	if fontIdx := xf.font; fontIdx != 4 {
+		if fontIdx > 4 {
+			fontIdx--
+		}
+		if fontIdx < len(r.fonts) {
+			font := r.fonts[fontIdx]
+			font.Color, font.ColorIndexed = r.getColor(font.ColorIndexed), 0
+			style.Font = &font
+		}
+	}
+	if code, ok := r.numFmts[xf.numFmt]; ok {
+		style.CustomNumFmt = &code
+	} else if _, ok := builtInNumFmt[xf.numFmt]; ok {
+		style.NumFmt = xf.numFmt
+	}
+	styleID, err := r.file.NewStyle(&style)
+	r.styles[idx] = styleID
+	return styleID, err
+}
+
+// xlsStringReader is used for reading strings which might be split across the
+// CONTINUE records, such as the shared string table.
+type xlsStringReader struct {
+	segments [][]byte
+	seg, pos int
+}
+
+// available returns the number of remaining bytes in the current segment, and
+// moves to the next segment if the current segment has been exhausted.
+func (sr *xlsStringReader) available() int {
+	for sr.seg < len(sr.segments) && sr.pos >= len(sr.segments[sr.seg]) {
+		sr.seg, sr.pos = sr.seg+1, 0
+	}
+	if sr.seg >= len(sr.segments) {
+		return 0
+	}
+	return len(sr.segments[sr.seg]) - sr.pos
+}
+
+// read provides a function to read the given number of bytes across the
+// segments.
+func (sr *xlsStringReader) read(n int) ([]byte, error) {
+	var buf []byte
+	for n > 0 {
+		size := sr.available()
+		if size == 0 {
+			return nil, ErrWorkbookFileFormat
+		}
+		if size > n {
+			size = n
+		}
+		buf = append(buf, sr.segments[sr.seg][sr.pos:sr.pos+size]...)
+		sr.pos, n = sr.pos+size, n-size
+	}
+	return buf, nil
+}
+
+// readString provides a function to read the XLUnicodeRichExtendedString
+// structure. When the characters of the string are split into the next
+// segment, the segment begins with a new option flags byte.
+func (sr *xlsStringReader) readString() (string, error) {
+	header, err := sr.read(3)
+	if err != nil {
+		return "", err
+	}
+	count, flags := int(binary.LittleEndian.Uint16(header)), header[2]
+	var runs, ext int
+	if flags&0x08 != 0 {
+		b, err := sr.read(2)
+		if err != nil {
+			return "", err
+		}
+		runs = int(binary.LittleEndian.Uint16(b))
+	}
+	if flags&0x04 != 0 {
+		b, err := sr.read(4)
+		if err != nil {
+			return "", err
+		}
+		ext = int(binary.LittleEndian.Uint32(b))
+	}
+	var chars []uint16
+	for len(chars) < count {
+		size := sr.available()
+		if size == 0 {
+			return "", ErrWorkbookFileFormat
+		}
+		if len(chars) > 0 && sr.pos == 0 {
+			b, _ := sr.read(1)
+			if flags = b[0]; size == 1 {
+				continue
+			}
+			size--
+		}
+		width := 1
+		if flags&0x01 != 0 {
+			width = 2
+		}
+		n := count - len(chars)
+		if size/width < n {
+			n = size / width
+		}
+		if n == 0 {
+			return "", ErrWorkbookFileFormat
+		}
+		b, _ := sr.read(n * width)
+		chars = append(chars, decodeXLSChars(b, width)...)
+	}
+	if _, err = sr.read(runs*4 + ext); err != nil {
+		return "", err
+	}
+	return string(utf16.Decode(chars)), nil
+}
+
+// readSST provides a function to read the shared string table from the SST
+// record and the following CONTINUE records.
+func (r *xlsReader) readSST(segments [][]byte) error {
+	if len(segments[0]) < 8 {
+		return ErrWorkbookFileFormat
+	}
+	count := int(binary.LittleEndian.Uint32(segments[0][4:]))
+	sr := &xlsStringReader{segments: segments, pos: 8}
+	for i := 0; i < count; i++ {
+		str, err := sr.readString()
+		if err != nil {
+			return err
+		}
+		r.sst = append(r.sst, str)
+	}
+	return nil
+}
+
+// decodeXLSChars provides a function to decode the compressed (width 1) or
+// UTF-16 (width 2) characters.
+func decodeXLSChars(b []byte, width int) []uint16 {
+	chars := make([]uint16, len(b)/width)
+	for i := range chars {
+		if width == 1 {
+			chars[i] = uint16(b[i])
+			continue
+		}
+		chars[i] = binary.LittleEndian.Uint16(b[i*2:])
+	}
+	return chars
+}
+
+// readXLUnicodeString provides a function to read the unsplit string with the
+// characters count field in the given size, such as the ShortXLUnicodeString
+// and XLUnicodeString structures. It returns the string and the number of
+// bytes read.
+func readXLUnicodeString(data []byte, size int) (string, int, error) {
+	if len(data) < size+1 {
+		return "", 0, ErrWorkbookFileFormat
+	}
+	count := int(data[0])
+	if size == 2 {
+		count = int(binary.LittleEndian.Uint16(data))
+	}
+	width := 1
+	if data[size]&0x01 != 0 {
+		width = 2
+	}
+	end := size + 1 + count*width
+	if len(data) < end {
+		return "", 0, ErrWorkbookFileFormat
+	}
+	return string(utf16.Decode(decodeXLSChars(data[size+1:end], width))), end, nil
+}
+
+// decodeRK provides a function to decode the RK number.
+func decodeRK(rk uint32) float64 {
+	var num float64
+	if rk&0x02 != 0 {
+		num = float64(int32(rk) >> 2)
+	} else {
+		num = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+	if rk&0x01 != 0 {
+		num /= 100
+	}
+	return num
+}
+
+// readSheet provides a function to read the worksheet substream.
+func (r *xlsReader) readSheet(sheet xlsBoundSheet) error {
+	ws, err := r.file.workSheetReader(sheet.name)
+	if err != nil {
+		return err
+	}
+	var formulaCell *xlsxC
+	return r.readSubstream(sheet.offset, 0x0010, func(typ uint16, segments [][]byte) error {
+		data := segments[0]
+		if typ == biffRecordString && formulaCell != nil {
+			str, _, err := readXLUnicodeString(data, 2)
+			if err != nil {
+				return err
+			}
+			formulaCell.T, formulaCell.V, err = r.file.setCellString(str)
+			formulaCell = nil
+			return err
+		}
+		switch typ {
+		case biffRecordColInfo:
+			return r.readColInfo(sheet.name, data)
+		case biffRecordRow:
+			return r.readRow(sheet.name, data)
+		case biffRecordMergedCells:
+			return r.readMergedCells(sheet.name, data)
+		case biffRecordMulRk:
+			formulaCell = nil
+			return r.readMulCells(ws, data, 6, func(c *xlsxC, b []byte) {
+				c.T, c.V = setCellFloat(decodeRK(binary.LittleEndian.Uint32(b[2:])), -1, 64)
+			})
+		case biffRecordMulBlank:
+			formulaCell = nil
+			return r.readMulCells(ws, data, 2, func(c *xlsxC, b []byte) {})
+		case biffRecordBlank, biffRecordLabelSST, biffRecordNumber, biffRecordRk,
+			biffRecordBoolErr, biffRecordLabel, biffRecordFormula:
+			if len(data) < 6 {
+				return ErrWorkbookFileFormat
+			}
+			c, err := r.prepareCell(ws, data[:2], data[2:4], data[4:6])
+			if err != nil {
+				return err
+			}
+			if typ == biffRecordFormula {
+				formulaCell, err = r.setFormulaResult(c, data[6:])
+				return err
+			}
+			formulaCell = nil
+			return r.setCellValue(c, typ, data[6:])
+		}
+		return nil
+	})
+}
+
+// prepareCell provides a function to prepare the cell by given row, column
+// and XF index fields of the cell records.
+func (r *xlsReader) prepareCell(ws *xlsxWorksheet, row, col, xf []byte) (*xlsxC, error) {
+	cell, err := CoordinatesToCellName(int(binary.LittleEndian.Uint16(col))+1, int(binary.LittleEndian.Uint16(row))+1)
+	if err != nil {
+		return nil, err
+	}
+	c, _, _, err := ws.prepareCell(cell)
+	if err != nil {
+		return nil, err
+	}
+	c.S, err = r.getStyleID(int(binary.LittleEndian.Uint16(xf)))
+	return c, err
+}
+
+// readMulCells provides a function to read the MULRK and MULBLANK records,
+// the given function will be called for each cell with the cell data in the
+// given size, begins with the XF index.
+func (r *xlsReader) readMulCells(ws *xlsxWorksheet, data []byte, size int, fn func(c *xlsxC, b []byte)) error {
+	if len(data) < 6 {
+		return ErrWorkbookFileFormat
+	}
+	first := int(binary.LittleEndian.Uint16(data[2:]))
+	for i := 0; 4+(i+1)*size <= len(data)-2; i++ {
+		b := data[4+i*size : 4+(i+1)*size]
+		col := make([]byte, 2)
+		binary.LittleEndian.PutUint16(col, uint16(first+i))
+		c, err := r.prepareCell(ws, data[:2], col, b[:2])
+		if err != nil {
+			return err
+		}
+		fn(c, b)
+	}
+	return nil
+}
+
+// setCellValue provides a function to set the cell value by given cell record
+// type and the record data after the cell header.
+func (r *xlsReader) setCellValue(c *xlsxC, typ uint16, data []byte) error {
+	var err error
+	switch typ {
+	case biffRecordLabelSST:
+		if len(data) < 4 {
+			return ErrWorkbookFileFormat
+		}
+		idx := int(binary.LittleEndian.Uint32(data))
+		if idx >= len(r.sst) {
+			return ErrWorkbookFileFormat
+		}
+		c.T, c.V, err = r.file.setCellString(r.sst[idx])
+	case biffRecordLabel:
+		var str string
+		if str, _, err = readXLUnicodeString(data, 2); err == nil {
+			c.T, c.V, err = r.file.setCellString(str)
+		}
+	case biffRecordNumber:
+		if len(data) < 8 {
+			return ErrWorkbookFileFormat
+		}
+		c.T, c.V = setCellFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)), -1, 64)
+	case biffRecordRk:
+		if len(data) < 4 {
+			return ErrWorkbookFileFormat
+		}
+		c.T, c.V = setCellFloat(decodeRK(binary.LittleEndian.Uint32(data)), -1, 64)
+	case biffRecordBoolErr:
+		if len(data) < 2 {
+			return ErrWorkbookFileFormat
+		}
+		if data[1] == 0 {
+			c.T, c.V = setCellBool(data[0] != 0)
+			break
+		}
+		c.T, c.V = "e", biffErrors[data[0]]
+	}
+	return err
+}
+
+// setFormulaResult provides a function to set the cached result of the
+// FORMULA record as the cell value. It returns the cell if the string result
+// is stored in the following STRING record.
+func (r *xlsReader) setFormulaResult(c *xlsxC, data []byte) (*xlsxC, error) {
+	if len(data) < 8 {
+		return nil, ErrWorkbookFileFormat
+	}
+	if binary.LittleEndian.Uint16(data[6:]) != 0xFFFF {
+		c.T, c.V = setCellFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)), -1, 64)
+		return nil, nil
+	}
+	switch data[0] {
+	case 0x00:
+		return c, nil
+	case 0x01:
+		c.T, c.V = setCellBool(data[2] != 0)
+	case 0x02:
+		c.T, c.V = "e", biffErrors[data[2]]
+	case 0x03:
+		c.T, c.V = "str", ""
+	}
+	return nil, nil
+}
+
+// readColInfo provides a function to read the COLINFO record.
+func (r *xlsReader) readColInfo(sheet string, data []byte) error {
+	if len(data) < 10 {
+		return ErrWorkbookFileFormat
+	}
+	first, last := int(binary.LittleEndian.Uint16(data))+1, int(binary.LittleEndian.Uint16(data[2:]))+1
+	if last > MaxColumns {
+		last = MaxColumns
+	}
+	if first > last {
+		return nil
+	}
+	startCol, err := ColumnNumberToName(first)
+	if err != nil {
+		return err
+	}
+	endCol, _ := ColumnNumberToName(last)
+	width := math.Min(float64(binary.LittleEndian.Uint16(data[4:]))/256, MaxColumnWidth)
+	if err = r.file.SetColWidth(sheet, startCol, endCol, width); err != nil {
+		return err
+	}
+	if binary.LittleEndian.Uint16(data[8:])&0x01 != 0 {
+		return r.file.SetColVisible(sheet, startCol+":"+endCol, false)
+	}
+	return nil
+}
+
+// readRow provides a function to read the ROW record.
+func (r *xlsReader) readRow(sheet string, data []byte) error {
+	if len(data) < 16 {
+		return ErrWorkbookFileFormat
+	}
+	row, height := int(binary.LittleEndian.Uint16(data))+1, binary.LittleEndian.Uint16(data[6:])
+	flags := binary.LittleEndian.Uint16(data[12:])
+	if flags&0x40 != 0 {
+		if err := r.file.SetRowHeight(sheet, row, float64(height&0x7FFF)/20); err != nil {
+			return err
+		}
+	}
+	if flags&0x20 != 0 {
+		return r.file.SetRowVisible(sheet, row, false)
+	}
+	return nil
+}
+
+// readMergedCells provides a function to read the MERGEDCELLS record.
+func (r *xlsReader) readMergedCells(sheet string, data []byte) error {
+	if len(data) < 2 {
+		return ErrWorkbookFileFormat
+	}
+	count := int(binary.LittleEndian.Uint16(data))
+	if len(data) < 2+count*8 {
+		return ErrWorkbookFileFormat
+	}
+	for i := 0; i < count; i++ {
+		ref := data[2+i*8:]
+		topLeftCell, err := CoordinatesToCellName(int(binary.LittleEndian.Uint16(ref[4:]))+1, int(binary.LittleEndian.Uint16(ref))+1)
+		if err != nil {
+			return err
+		}
+		bottomRightCell, err := CoordinatesToCellName(int(binary.LittleEndian.Uint16(ref[6:]))+1, int(binary.LittleEndian.Uint16(ref[2:]))+1)
+		if err != nil {
+			return err
+		}
+		if err = r.file.MergeCell(sheet, topLeftCell, bottomRightCell); err != nil {
+			return err
+		}
+	}
+	return nil
+}
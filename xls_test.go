@@ -0,0 +1,231 @@
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// biffRecord provides a function to encode the BIFF8 record by given record
+// type and data.
+func biffRecord(typ uint16, data ...[]byte) []byte {
+	var buf bytes.Buffer
+	for _, b := range data {
+		buf.Write(b)
+	}
+	record := make([]byte, 4)
+	binary.LittleEndian.PutUint16(record, typ)
+	binary.LittleEndian.PutUint16(record[2:], uint16(buf.Len()))
+	return append(record, buf.Bytes()...)
+}
+
+// biffUint provides a function to encode the little-endian unsigned integers
+// in the given size.
+func biffUint(size int, values ...uint64) []byte {
+	var buf []byte
+	for _, v := range values {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, v)
+		buf = append(buf, b[:size]...)
+	}
+	return buf
+}
+
+// biffString provides a function to encode the compressed XLUnicodeString (2)
+// or ShortXLUnicodeString (1) structure by given characters count field size.
+func biffString(size int, str string) []byte {
+	return append(append(biffUint(size, uint64(len(str))), 0), str...)
+}
+
+// biffCell provides a function to encode the cell record header.
+func biffCell(row, col, xf int) []byte {
+	return biffUint(2, uint64(row), uint64(col), uint64(xf))
+}
+
+// newTestXLS provides a function to create the compound file binary of the
+// legacy workbook with the given worksheet substreams.
+func newTestXLS(globals []byte, sheets map[string][]byte, order []string) []byte {
+	bof := func(dt uint64) []byte {
+		return biffRecord(biffRecordBOF, biffUint(2, biffVersion8, dt), make([]byte, 12))
+	}
+	eof := biffRecord(biffRecordEOF)
+	size := len(bof(5)) + len(globals) + len(eof)
+	for _, name := range order {
+		size += len(biffRecord(biffRecordBoundSheet, make([]byte, 6), biffString(1, name)))
+	}
+	stream := append(bof(5), globals...)
+	var body []byte
+	for i, name := range order {
+		state := byte(0)
+		if i > 0 {
+			state = byte(i)
+		}
+		stream = append(stream, biffRecord(biffRecordBoundSheet, biffUint(4, uint64(size+len(body))), []byte{state, 0}, biffString(1, name))...)
+		body = append(append(append(body, bof(0x10)...), sheets[name]...), eof...)
+	}
+	stream = append(append(stream, eof...), body...)
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5}},
+	}
+	compoundFile.put("Workbook", stream)
+	return compoundFile.write()
+}
+
+func TestOpenXLS(t *testing.T) {
+	font := func(height, flags, color, weight, underline uint64, name string) []byte {
+		return biffRecord(biffRecordFont, biffUint(2, height, flags, color, weight, 0), []byte{byte(underline), 0, 0, 0}, biffString(1, name))
+	}
+	xf := func(fnt, numFmt uint64, align byte, border1, border2 uint32, fill uint64) []byte {
+		return biffRecord(biffRecordXF, biffUint(2, fnt, numFmt, 0), []byte{align, 0, 0, 0}, biffUint(4, uint64(border1), uint64(border2)), biffUint(2, fill))
+	}
+	var globals []byte
+	globals = append(globals, biffRecord(biffRecordDateMode, biffUint(2, 0))...)
+	for i := 0; i < 4; i++ {
+		globals = append(globals, font(200, 0, 0x7FFF, 400, 0, "Arial")...)
+	}
+	globals = append(globals, font(240, 0x02, 10, 700, 1, "Times New Roman")...)
+	globals = append(globals, biffRecord(biffRecordFormat, biffUint(2, 164), biffString(2, "0.000"))...)
+	globals = append(globals, biffRecord(biffRecordPalette, biffUint(2, 1), []byte{0x12, 0x34, 0x56, 0})...)
+	for i := 0; i < 15; i++ {
+		globals = append(globals, xf(0, 0, 0x20, 0, 0, 0)...)
+	}
+	// XF 15: default, XF 16: custom number format with font 5, XF 17: borders,
+	// fill and alignment, XF 18: built-in date number format
+	globals = append(globals, xf(0, 0, 0x20, 0, 0, 0)...)
+	globals = append(globals, xf(5, 164, 0x20, 0, 0, 0)...)
+	globals = append(globals, xf(0, 0, 0x1A, 0x1|0x2<<12|8<<16, 10<<7|1<<26, 13)...)
+	globals = append(globals, xf(0, 14, 0x20, 0, 0, 0)...)
+	// Shared string table, the third string is split across the CONTINUE
+	// record and switched to the UTF-16 characters
+	sst := biffRecord(biffRecordSST, biffUint(4, 4, 4), biffString(2, "Name"), biffString(2, "Price"), biffUint(2, 4), []byte{0}, []byte("Ca"))
+	sst = append(sst, biffRecord(biffRecordContinue, []byte{1}, biffUint(2, 'f', 0xE9), biffUint(2, 4), []byte{0x08}, biffUint(2, 1), []byte("Rich"), biffUint(2, 0, 0))...)
+	globals = append(globals, sst...)
+
+	var sheet1 []byte
+	sheet1 = append(sheet1, biffRecord(biffRecordColInfo, biffUint(2, 0, 1, 20*256, 15, 0, 0))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordColInfo, biffUint(2, 3, 3, 10*256, 15, 1, 0))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordColInfo, biffUint(2, 4, 4, 0xFFFF, 15, 0, 0))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordRow, biffUint(2, 0, 0, 2, 600, 0, 0, 0x40, 15))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordRow, biffUint(2, 4, 0, 2, 255, 0, 0, 0x20, 15))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordLabelSST, biffCell(0, 0, 17), biffUint(4, 0))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordLabelSST, biffCell(0, 1, 15), biffUint(4, 1))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordLabelSST, biffCell(1, 0, 15), biffUint(4, 2))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordNumber, biffCell(1, 1, 16), biffUint(8, math.Float64bits(1.5)))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordRk, biffCell(2, 0, 15), biffUint(4, 42<<2|0x02))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordMulRk, biffUint(2, 3, 0, 15), biffUint(4, 1234<<2|0x03), biffUint(2, 18), biffUint(4, math.Float64bits(45000)>>32), biffUint(2, 1))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordBoolErr, biffCell(2, 1, 15), []byte{1, 0})...)
+	sheet1 = append(sheet1, biffRecord(biffRecordBoolErr, biffCell(2, 2, 15), []byte{0x07, 1})...)
+	sheet1 = append(sheet1, biffRecord(biffRecordFormula, biffCell(4, 0, 15), biffUint(8, math.Float64bits(3)), make([]byte, 8))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordFormula, biffCell(4, 1, 15), []byte{0, 0, 0, 0, 0, 0, 0xFF, 0xFF}, make([]byte, 8))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordString, biffString(2, "Result"))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordFormula, biffCell(4, 2, 15), []byte{1, 0, 1, 0, 0, 0, 0xFF, 0xFF}, make([]byte, 8))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordLabel, biffCell(5, 0, 15), biffString(2, "Label"))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordMulBlank, biffUint(2, 6, 0, 17, 17, 1))...)
+	sheet1 = append(sheet1, biffRecord(biffRecordMergedCells, biffUint(2, 1, 6, 7, 0, 1))...)
+	data := newTestXLS(globals, map[string][]byte{"Data": sheet1, "Hidden": nil}, []string{"Data", "Hidden"})
+
+	f, err := OpenReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Data", "Hidden"}, f.GetSheetList())
+	visible, err := f.GetSheetVisible("Hidden")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	rows, err := f.GetRows("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Price"},
+		{"Café", "1.500"},
+		{"42", "TRUE", "#DIV/0!"},
+		{"12.34", "03-15-23"},
+		{"3", "Result", "TRUE"},
+		{"Label"},
+	}, rows)
+	for cell, expected := range map[string]CellType{"A1": CellTypeSharedString, "B3": CellTypeBool, "C3": CellTypeError} {
+		cellType, err := f.GetCellType("Data", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType)
+	}
+	width, err := f.GetColWidth("Data", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	// Test the column width exceeds the maximum column width was clamped
+	width, err = f.GetColWidth("Data", "E")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(MaxColumnWidth), width)
+	visible, err = f.GetColVisible("Data", "D")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	height, err := f.GetRowHeight("Data", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	visible, err = f.GetRowVisible("Data", 5)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	mergeCells, err := f.GetMergeCells("Data")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A7:B8", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+
+	styleID, err := f.GetCellStyle("Data", "B2")
+	assert.NoError(t, err)
+	style := f.Styles.CellXfs.Xf[styleID]
+	fnt := f.Styles.Fonts.Font[*style.FontID]
+	assert.Equal(t, "Times New Roman", *fnt.Name.Val)
+	assert.Equal(t, 12.0, *fnt.Sz.Val)
+	assert.NotNil(t, fnt.B)
+	assert.NotNil(t, fnt.I)
+	assert.Equal(t, "FFFF0000", fnt.Color.RGB)
+	styleID, err = f.GetCellStyle("Data", "A1")
+	assert.NoError(t, err)
+	style = f.Styles.CellXfs.Xf[styleID]
+	assert.Equal(t, "center", style.Alignment.Horizontal)
+	assert.Equal(t, "center", style.Alignment.Vertical)
+	assert.True(t, style.Alignment.WrapText)
+	border := f.Styles.Borders.Border[*style.BorderID]
+	assert.Equal(t, "thin", border.Left.Style)
+	assert.Equal(t, "FF123456", border.Left.Color.RGB)
+	assert.Equal(t, "medium", border.Bottom.Style)
+	fill := f.Styles.Fills.Fill[*style.FillID]
+	assert.Equal(t, "solid", fill.PatternFill.PatternType)
+	assert.Equal(t, "FFFFFF00", fill.PatternFill.FgColor.RGB)
+	styleID, err = f.GetCellStyle("Data", "B7")
+	assert.NoError(t, err)
+	assert.NotZero(t, styleID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenXLS.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test open legacy workbook with invalid records
+	for _, globals := range [][]byte{
+		biffRecord(biffRecordFilePass),
+		biffRecord(biffRecordDateMode),
+		biffRecord(biffRecordFont),
+		biffRecord(biffRecordXF),
+		biffRecord(biffRecordPalette, biffUint(2, 1)),
+		biffRecord(biffRecordFormat, biffUint(2, 164), biffUint(2, 5)),
+		biffRecord(biffRecordSST, biffUint(4, 1, 1), biffUint(2, 3), []byte{0}),
+	} {
+		_, err = OpenReader(bytes.NewReader(newTestXLS(globals, nil, nil)))
+		assert.Error(t, err)
+	}
+	for _, sheet := range [][]byte{
+		biffRecord(biffRecordNumber, biffCell(0, 0, 15)),
+		biffRecord(biffRecordLabelSST, biffCell(0, 0, 15), biffUint(4, 10)),
+		biffRecord(biffRecordRow, biffUint(2, 0)),
+		biffRecord(biffRecordColInfo, biffUint(2, 0)),
+		biffRecord(biffRecordMergedCells, biffUint(2, 1)),
+		biffRecord(biffRecordMulRk, biffUint(2, 0)),
+		biffRecord(biffRecordFormula, biffCell(0, 0, 15)),
+		biffRecord(biffRecordNumber, biffCell(0, MaxColumns, 15), biffUint(8, 0)),
+	} {
+		_, err = OpenReader(bytes.NewReader(newTestXLS(nil, map[string][]byte{"Sheet1": sheet}, []string{"Sheet1"})))
+		assert.Error(t, err)
+	}
+	// Test open legacy workbook with truncated stream
+	_, err = OpenReader(bytes.NewReader(newTestXLS(nil, map[string][]byte{"Sheet1": {0x01, 0x02}}, []string{"Sheet1"})))
+	assert.EqualError(t, err, ErrWorkbookFileFormat.Error())
+}
@@ -0,0 +1,855 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Record types of the Excel binary workbook (BIFF12) parts used by the XLSB
+// reader.
+const (
+	brtRowHdr           = 0
+	brtCellBlank        = 1
+	brtCellRk           = 2
+	brtCellError        = 3
+	brtCellBool         = 4
+	brtCellReal         = 5
+	brtCellSt           = 6
+	brtCellIsst         = 7
+	brtFmlaString       = 8
+	brtFmlaNum          = 9
+	brtFmlaBool         = 10
+	brtFmlaError        = 11
+	brtSSTItem          = 19
+	brtFont             = 43
+	brtFmt              = 44
+	brtFill             = 45
+	brtBorder           = 46
+	brtXF               = 47
+	brtColInfo          = 60
+	brtWbProp           = 153
+	brtBundleSh         = 156
+	brtMergeCell        = 176
+	brtExternSheet      = 362
+	brtBeginCellXFs     = 617
+	brtEndCellXFs       = 618
+	defaultXLSBWorkbook = "xl/workbook.bin"
+)
+
+// biffFunction defined the name and the fixed number of arguments of the
+// built-in function in the parsed formulas, the number of arguments will be -1
+// for the functions with variable arguments.
+type biffFunction struct {
+	name string
+	args int
+}
+
+// biffFunctions defined the built-in functions by function index of the
+// parsed formulas.
+var biffFunctions = map[int]biffFunction{
+	0: {"COUNT", -1}, 1: {"IF", -1}, 2: {"ISNA", 1}, 3: {"ISERROR", 1}, 4: {"SUM", -1},
+	5: {"AVERAGE", -1}, 6: {"MIN", -1}, 7: {"MAX", -1}, 8: {"ROW", -1}, 9: {"COLUMN", -1},
+	10: {"NA", 0}, 11: {"NPV", -1}, 12: {"STDEV", -1}, 13: {"DOLLAR", -1}, 14: {"FIXED", -1},
+	15: {"SIN", 1}, 16: {"COS", 1}, 17: {"TAN", 1}, 18: {"ATAN", 1}, 19: {"PI", 0},
+	20: {"SQRT", 1}, 21: {"EXP", 1}, 22: {"LN", 1}, 23: {"LOG10", 1}, 24: {"ABS", 1},
+	25: {"INT", 1}, 26: {"SIGN", 1}, 27: {"ROUND", 2}, 28: {"LOOKUP", -1}, 29: {"INDEX", -1},
+	30: {"REPT", 2}, 31: {"MID", 3}, 32: {"LEN", 1}, 33: {"VALUE", 1}, 34: {"TRUE", 0},
+	35: {"FALSE", 0}, 36: {"AND", -1}, 37: {"OR", -1}, 38: {"NOT", 1}, 39: {"MOD", 2},
+	46: {"VAR", -1}, 48: {"TEXT", 2}, 56: {"PV", -1}, 57: {"FV", -1}, 58: {"NPER", -1},
+	59: {"PMT", -1}, 60: {"RATE", -1}, 63: {"RAND", 0}, 64: {"MATCH", -1}, 65: {"DATE", 3},
+	66: {"TIME", 3}, 67: {"DAY", 1}, 68: {"MONTH", 1}, 69: {"YEAR", 1}, 70: {"WEEKDAY", -1},
+	71: {"HOUR", 1}, 72: {"MINUTE", 1}, 73: {"SECOND", 1}, 74: {"NOW", 0}, 76: {"ROWS", 1},
+	77: {"COLUMNS", 1}, 78: {"OFFSET", -1}, 82: {"SEARCH", -1}, 97: {"ATAN2", 2}, 98: {"ASIN", 1},
+	99: {"ACOS", 1}, 100: {"CHOOSE", -1}, 101: {"HLOOKUP", -1}, 102: {"VLOOKUP", -1}, 109: {"LOG", -1},
+	111: {"CHAR", 1}, 112: {"LOWER", 1}, 113: {"UPPER", 1}, 114: {"PROPER", 1}, 115: {"LEFT", -1},
+	116: {"RIGHT", -1}, 117: {"EXACT", 2}, 118: {"TRIM", 1}, 119: {"REPLACE", 4}, 120: {"SUBSTITUTE", -1},
+	121: {"CODE", 1}, 124: {"FIND", -1}, 125: {"CELL", -1}, 126: {"ISERR", 1}, 127: {"ISTEXT", 1},
+	128: {"ISNUMBER", 1}, 129: {"ISBLANK", 1}, 130: {"T", 1}, 131: {"N", 1}, 140: {"DATEVALUE", 1},
+	141: {"TIMEVALUE", 1}, 142: {"SLN", 3}, 148: {"INDIRECT", -1}, 162: {"CLEAN", 1}, 163: {"MDETERM", 1},
+	165: {"MMULT", 2}, 167: {"IPMT", -1}, 168: {"PPMT", -1}, 169: {"COUNTA", -1}, 183: {"PRODUCT", -1},
+	184: {"FACT", 1}, 190: {"ISNONTEXT", 1}, 197: {"TRUNC", -1}, 198: {"ISLOGICAL", 1}, 212: {"ROUNDUP", 2},
+	213: {"ROUNDDOWN", 2}, 216: {"RANK", -1}, 219: {"ADDRESS", -1}, 220: {"DAYS360", -1}, 221: {"TODAY", 0},
+	227: {"MEDIAN", -1}, 228: {"SUMPRODUCT", -1}, 229: {"SINH", 1}, 230: {"COSH", 1}, 231: {"TANH", 1},
+	247: {"DB", -1}, 252: {"FREQUENCY", 2}, 261: {"ERROR.TYPE", 1}, 269: {"AVEDEV", -1}, 276: {"COMBIN", 2},
+	279: {"EVEN", 1}, 285: {"FLOOR", 2}, 288: {"CEILING", 2}, 298: {"ODD", 1}, 312: {"CORREL", 2},
+	318: {"DEVSQ", -1}, 321: {"SUMSQ", -1}, 325: {"LARGE", 2}, 326: {"SMALL", 2}, 328: {"PERCENTILE", 2},
+	330: {"MODE", -1}, 336: {"CONCATENATE", -1}, 337: {"POWER", 2}, 342: {"RADIANS", 1}, 343: {"DEGREES", 1},
+	344: {"SUBTOTAL", -1}, 345: {"SUMIF", -1}, 346: {"COUNTIF", 2}, 347: {"COUNTBLANK", 1}, 354: {"ROMAN", -1},
+	359: {"HYPERLINK", -1}, 361: {"AVERAGEA", -1}, 362: {"MAXA", -1}, 363: {"MINA", -1}, 480: {"IFERROR", 2},
+	481: {"COUNTIFS", -1}, 482: {"SUMIFS", -1}, 483: {"AVERAGEIF", -1}, 484: {"AVERAGEIFS", -1},
+}
+
+// biffOperators defined the binary operators of the parsed formulas.
+var biffOperators = map[byte]string{
+	0x03: "+", 0x04: "-", 0x05: "*", 0x06: "/", 0x07: "^", 0x08: "&", 0x09: "<", 0x0A: "<=",
+	0x0B: "=", 0x0C: ">=", 0x0D: ">", 0x0E: "<>", 0x0F: " ", 0x10: ",", 0x11: ":",
+}
+
+// biffTokenSizes defined the data size of the parsed formula tokens in the
+// Excel binary workbook.
+var biffTokenSizes = map[byte]int{
+	0x17: 2, 0x19: 3, 0x1C: 1, 0x1D: 1, 0x1E: 2, 0x1F: 8, 0x21: 2, 0x22: 3, 0x24: 6, 0x25: 12,
+	0x26: 6, 0x27: 6, 0x28: 6, 0x29: 2, 0x2A: 6, 0x2B: 12, 0x3A: 8, 0x3B: 14, 0x3C: 8, 0x3D: 14,
+}
+
+// xlsbSheet directly maps the sheet information of the BrtBundleSh record.
+type xlsbSheet struct {
+	name, path string
+	state      int
+}
+
+// xlsbReader is used for reading the binary parts of the Excel binary
+// workbook (BIFF12) into a spreadsheet.
+type xlsbReader struct {
+	file         *File
+	parts        map[string]*zip.File
+	date1904     bool
+	sheets       []xlsbSheet
+	externSheets []int
+	sst          []string
+	numFmts      map[int]string
+	fonts        []Font
+	fills        []Fill
+	borders      [][]Border
+	xfs          []Style
+	styles       map[int]int
+}
+
+// isXLSB provides a function to check if the given zip archive is an Excel
+// binary workbook.
+func isXLSB(zr *zip.Reader) bool {
+	for _, v := range zr.File {
+		if strings.EqualFold(strings.ReplaceAll(v.Name, "\\", "/"), defaultXLSBWorkbook) {
+			return true
+		}
+	}
+	return false
+}
+
+// openXLSB provides a function to read the Excel binary workbook (BIFF12) into
+// a new spreadsheet. The cell values, shared strings, formulas, cell formats,
+// column widths, row heights, merged cells and worksheet names will be loaded.
+// The formulas which contain the unsupported tokens, such as defined names
+// and shared formulas, will be loaded with the cached results only. All the
+// worksheets are converted into memory on open, so the Rows iterator reads the
+// converted worksheets instead of streaming the binary parts.
+func openXLSB(zr *zip.Reader, opts *Options) (*File, error) {
+	r := &xlsbReader{
+		file:    NewFile(*opts),
+		parts:   make(map[string]*zip.File, len(zr.File)),
+		numFmts: make(map[int]string),
+		styles:  make(map[int]int),
+	}
+	var unzipSize int64
+	for _, v := range zr.File {
+		if unzipSize += v.FileInfo().Size(); unzipSize > opts.UnzipSizeLimit {
+			return nil, newUnzipSizeLimitError(opts.UnzipSizeLimit)
+		}
+		r.parts[strings.ToLower(strings.ReplaceAll(v.Name, "\\", "/"))] = v
+	}
+	if err := r.readWorkbook(); err != nil {
+		return nil, err
+	}
+	if err := r.file.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(r.date1904)}); err != nil {
+		return nil, err
+	}
+	var worksheets []xlsbSheet
+	for _, sheet := range r.sheets {
+		if sheet.path == "" {
+			continue
+		}
+		if len(worksheets) == 0 {
+			if err := r.file.SetSheetName(r.file.GetSheetName(0), sheet.name); err != nil {
+				return nil, err
+			}
+		} else if _, err := r.file.NewSheet(sheet.name); err != nil {
+			return nil, err
+		}
+		worksheets = append(worksheets, sheet)
+	}
+	for _, sheet := range worksheets {
+		if err := r.readSheet(sheet); err != nil {
+			return nil, err
+		}
+	}
+	for _, sheet := range worksheets {
+		if sheet.state != 0 {
+			if err := r.file.SetSheetVisible(sheet.name, false, sheet.state == 2); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return r.file, nil
+}
+
+// readXLSBVarInt provides a function to read the variable-length integer of
+// the record type and size with the given maximum number of bytes.
+func readXLSBVarInt(r io.ByteReader, size int) (int, error) {
+	var v int
+	for i := 0; i < size; i++ {
+		b, err := r.ReadByte()
+		if err == io.EOF && i > 0 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		if v |= int(b&0x7F) << (7 * i); b&0x80 == 0 {
+			break
+		}
+	}
+	return v, nil
+}
+
+// readPart provides a function to iterate the records of the binary part by
+// given part name, it does nothing if the part doesn't exist.
+func (r *xlsbReader) readPart(name string, fn func(typ int, data []byte) error) error {
+	part, ok := r.parts[strings.ToLower(name)]
+	if !ok {
+		return nil
+	}
+	rc, err := part.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	br := bufio.NewReader(rc)
+	for {
+		typ, err := readXLSBVarInt(br, 2)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		size, err := readXLSBVarInt(br, 4)
+		if err != nil {
+			return ErrWorkbookFileFormat
+		}
+		data := make([]byte, size)
+		if _, err = io.ReadFull(br, data); err != nil {
+			return ErrWorkbookFileFormat
+		}
+		if err = fn(typ, data); err != nil {
+			return err
+		}
+	}
+}
+
+// readRels provides a function to read the relationships of the given part,
+// it returns the absolute target paths by relationship ID.
+func (r *xlsbReader) readRels(partName string) (map[string]xlsxRelationship, error) {
+	rels := map[string]xlsxRelationship{}
+	part, ok := r.parts[strings.ToLower(path.Join(path.Dir(partName), "_rels", path.Base(partName)+".rels"))]
+	if !ok {
+		return rels, nil
+	}
+	content, err := readFile(part)
+	if err != nil {
+		return rels, err
+	}
+	var relationships xlsxRelationships
+	if err = r.file.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).Decode(&relationships); err != nil && err != io.EOF {
+		return rels, err
+	}
+	for _, rel := range relationships.Relationships {
+		if strings.HasPrefix(rel.Target, "/") {
+			rel.Target = strings.TrimPrefix(rel.Target, "/")
+		} else {
+			rel.Target = path.Join(path.Dir(partName), rel.Target)
+		}
+		rels[rel.ID] = rel
+	}
+	return rels, nil
+}
+
+// readXLWideString provides a function to read the XLWideString structure, it
+// returns the string and the number of bytes read. The null string of the
+// XLNullableWideString structure will be returned as an empty string.
+func readXLWideString(data []byte) (string, int, error) {
+	if len(data) < 4 {
+		return "", 0, ErrWorkbookFileFormat
+	}
+	count := binary.LittleEndian.Uint32(data)
+	if count == math.MaxUint32 {
+		return "", 4, nil
+	}
+	if uint64(len(data)-4) < uint64(count)*2 {
+		return "", 0, ErrWorkbookFileFormat
+	}
+	end := 4 + int(count)*2
+	return string(utf16.Decode(decodeXLSChars(data[4:end], 2))), end, nil
+}
+
+// readWorkbook provides a function to read the workbook part, and the
+// relationship parts, shared string table, styles and theme of the workbook.
+func (r *xlsbReader) readWorkbook() error {
+	rels, err := r.readRels(defaultXLSBWorkbook)
+	if err != nil {
+		return err
+	}
+	if err = r.readPart(defaultXLSBWorkbook, func(typ int, data []byte) error {
+		switch typ {
+		case brtWbProp:
+			if len(data) < 4 {
+				return ErrWorkbookFileFormat
+			}
+			r.date1904 = data[0]&0x01 != 0
+		case brtBundleSh:
+			if len(data) < 8 {
+				return ErrWorkbookFileFormat
+			}
+			rID, n, err := readXLWideString(data[8:])
+			if err != nil {
+				return err
+			}
+			name, _, err := readXLWideString(data[8+n:])
+			if err != nil {
+				return err
+			}
+			sheet := xlsbSheet{name: name, state: int(binary.LittleEndian.Uint32(data))}
+			if rel, ok := rels[rID]; ok && rel.Type == SourceRelationshipWorkSheet {
+				sheet.path = rel.Target
+			}
+			r.sheets = append(r.sheets, sheet)
+		case brtExternSheet:
+			if len(data) < 4 {
+				return ErrWorkbookFileFormat
+			}
+			count := int(binary.LittleEndian.Uint32(data))
+			if len(data) < 4+count*12 {
+				return ErrWorkbookFileFormat
+			}
+			for i := 0; i < count; i++ {
+				r.externSheets = append(r.externSheets, int(int32(binary.LittleEndian.Uint32(data[8+i*12:]))))
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	// The theme should be loaded before the styles for resolving the theme
+	// colors of the styles
+	for _, typ := range []string{"theme", "styles", "sharedStrings"} {
+		for _, rel := range rels {
+			if path.Base(rel.Type) != typ {
+				continue
+			}
+			if err = r.readWorkbookRel(rel); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readWorkbookRel provides a function to read the theme, shared strings or
+// styles part by given relationship of the workbook.
+func (r *xlsbReader) readWorkbookRel(rel xlsxRelationship) error {
+	switch path.Base(rel.Type) {
+	case "theme":
+		part, ok := r.parts[strings.ToLower(rel.Target)]
+		if !ok {
+			return nil
+		}
+		content, err := readFile(part)
+		if err != nil {
+			return err
+		}
+		r.file.Pkg.Store(defaultXMLPathTheme, content)
+		r.file.Theme, err = r.file.themeReader()
+		return err
+	case "sharedStrings":
+		return r.readPart(rel.Target, r.readSSTItem)
+	}
+	return r.readStyles(rel.Target)
+}
+
+// readSSTItem provides a function to read the BrtSSTItem record of the shared
+// string table.
+func (r *xlsbReader) readSSTItem(typ int, data []byte) error {
+	if typ != brtSSTItem {
+		return nil
+	}
+	if len(data) < 1 {
+		return ErrWorkbookFileFormat
+	}
+	str, _, err := readXLWideString(data[1:])
+	r.sst = append(r.sst, str)
+	return err
+}
+
+// getColor provides a function to get the RGB color by given BrtColor
+// structure, it returns an empty string for the automatic color.
+func (r *xlsbReader) getColor(data []byte) string {
+	switch data[0] >> 1 {
+	case 1:
+		if int(data[1]) < len(IndexedColorMapping) && data[1] < 64 {
+			return IndexedColorMapping[data[1]]
+		}
+	case 2:
+		return fmt.Sprintf("%02X%02X%02X", data[4], data[5], data[6])
+	case 3:
+		theme := int(data[1])
+		if rgb := r.file.getColorRGB(&xlsxColor{Theme: &theme, Tint: getXLSBTint(data)}); rgb != "" {
+			return rgb[2:]
+		}
+	}
+	return ""
+}
+
+// getXLSBTint provides a function to get the tint value of the BrtColor
+// structure.
+func getXLSBTint(data []byte) float64 {
+	return float64(int16(binary.LittleEndian.Uint16(data[2:]))) / 32767
+}
+
+// readStyles provides a function to read the number formats, fonts, fills,
+// borders and cell formats in the styles part.
+func (r *xlsbReader) readStyles(name string) error {
+	var cellXfs bool
+	return r.readPart(name, func(typ int, data []byte) error {
+		switch typ {
+		case brtFmt:
+			if len(data) < 2 {
+				return ErrWorkbookFileFormat
+			}
+			code, _, err := readXLWideString(data[2:])
+			r.numFmts[int(binary.LittleEndian.Uint16(data))] = code
+			return err
+		case brtFont:
+			return r.readFont(data)
+		case brtFill:
+			if len(data) < 12 {
+				return ErrWorkbookFileFormat
+			}
+			var fill Fill
+			if pattern := int(binary.LittleEndian.Uint32(data)); 0 < pattern && pattern < 19 {
+				fill = Fill{Type: "pattern", Pattern: pattern}
+				if color := r.getColor(data[4:12]); color != "" {
+					fill.Color = []string{color}
+				}
+			}
+			r.fills = append(r.fills, fill)
+		case brtBorder:
+			if len(data) < 41 {
+				return ErrWorkbookFileFormat
+			}
+			var borders []Border
+			for i, typ := range []string{"top", "bottom", "left", "right"} {
+				if b := data[1+i*10:]; b[0] != 0 && b[0] < 14 {
+					borders = append(borders, Border{Type: typ, Style: int(b[0]), Color: r.getColor(b[2:10])})
+				}
+			}
+			r.borders = append(r.borders, borders)
+		case brtBeginCellXFs, brtEndCellXFs:
+			cellXfs = typ == brtBeginCellXFs
+		case brtXF:
+			if cellXfs {
+				return r.readXF(data)
+			}
+		}
+		return nil
+	})
+}
+
+// readFont provides a function to read the BrtFont record.
+func (r *xlsbReader) readFont(data []byte) error {
+	if len(data) < 21 {
+		return ErrWorkbookFileFormat
+	}
+	name, _, err := readXLWideString(data[21:])
+	if err != nil {
+		return err
+	}
+	flags := binary.LittleEndian.Uint16(data[2:])
+	font := Font{
+		Family: name,
+		Size:   float64(binary.LittleEndian.Uint16(data)) / 20,
+		Italic: flags&0x02 != 0,
+		Strike: flags&0x08 != 0,
+		Bold:   binary.LittleEndian.Uint16(data[4:]) >= 700,
+	}
+	switch binary.LittleEndian.Uint16(data[6:]) {
+	case 1:
+		font.VertAlign = "superscript"
+	case 2:
+		font.VertAlign = "subscript"
+	}
+	switch data[8] {
+	case 0x01, 0x21:
+		font.Underline = "single"
+	case 0x02, 0x22:
+		font.Underline = "double"
+	}
+	if color := data[12:20]; color[0]>>1 == 3 {
+		theme := int(color[1])
+		font.ColorTheme, font.ColorTint = &theme, getXLSBTint(color)
+	} else {
+		font.Color = r.getColor(color)
+	}
+	r.fonts = append(r.fonts, font)
+	return nil
+}
+
+// readXF provides a function to read the BrtXF record of the cell formats.
+func (r *xlsbReader) readXF(data []byte) error {
+	if len(data) < 14 {
+		return ErrWorkbookFileFormat
+	}
+	var style Style
+	numFmt := int(binary.LittleEndian.Uint16(data[2:]))
+	if code, ok := r.numFmts[numFmt]; ok {
+		style.CustomNumFmt = &code
+	} else if _, ok := builtInNumFmt[numFmt]; ok {
+		style.NumFmt = numFmt
+	}
+	if idx := int(binary.LittleEndian.Uint16(data[4:])); idx < len(r.fonts) {
+		font := r.fonts[idx]
+		style.Font = &font
+	}
+	if idx := int(binary.LittleEndian.Uint16(data[6:])); idx < len(r.fills) {
+		style.Fill = r.fills[idx]
+	}
+	if idx := int(binary.LittleEndian.Uint16(data[8:])); idx < len(r.borders) {
+		style.Border = r.borders[idx]
+	}
+	flags := binary.LittleEndian.Uint16(data[12:])
+	alignment := Alignment{
+		Horizontal:   biffHorizontalAlignment[flags&0x07],
+		WrapText:     flags&0x40 != 0,
+		TextRotation: int(data[10]),
+		Indent:       int(data[11]),
+		ShrinkToFit:  flags&0x100 != 0,
+	}
+	if vertical := int(flags>>3) & 0x07; vertical < len(biffVerticalAlignment) {
+		alignment.Vertical = biffVerticalAlignment[vertical]
+	}
+	if alignment != (Alignment{}) {
+		style.Alignment = &alignment
+	}
+	r.xfs = append(r.xfs, style)
+	return nil
+}
+
+// getStyleID provides a function to get the style index of the spreadsheet by
+// given cell format index, the styles will be created on demand.
+func (r *xlsbReader) getStyleID(idx int) (int, error) {
+	if styleID, ok := r.styles[idx]; ok {
+		return styleID, nil
+	}
+	if idx <= 0 || idx >= len(r.xfs) {
+		return 0, nil
+	}
+	styleID, err := r.file.NewStyle(&r.xfs[idx])
+	r.styles[idx] = styleID
+	return styleID, err
+}
+
+// readSheet provides a function to read the worksheet part.
+func (r *xlsbReader) readSheet(sheet xlsbSheet) error {
+	ws, err := r.file.workSheetReader(sheet.name)
+	if err != nil {
+		return err
+	}
+	var row int
+	return r.readPart(sheet.path, func(typ int, data []byte) error {
+		switch typ {
+		case brtRowHdr:
+			if len(data) < 12 {
+				return ErrWorkbookFileFormat
+			}
+			row = int(binary.LittleEndian.Uint32(data)) + 1
+			return r.readRowHdr(sheet.name, row, data)
+		case brtColInfo:
+			return r.readColInfo(sheet.name, data)
+		case brtMergeCell:
+			return r.readMergeCell(sheet.name, data)
+		case brtCellBlank, brtCellRk, brtCellError, brtCellBool, brtCellReal, brtCellSt,
+			brtCellIsst, brtFmlaString, brtFmlaNum, brtFmlaBool, brtFmlaError:
+			if len(data) < 8 {
+				return ErrWorkbookFileFormat
+			}
+			cell, err := CoordinatesToCellName(int(binary.LittleEndian.Uint32(data))+1, row)
+			if err != nil {
+				return err
+			}
+			c, _, _, err := ws.prepareCell(cell)
+			if err != nil {
+				return err
+			}
+			if c.S, err = r.getStyleID(int(binary.LittleEndian.Uint32(data[4:]) & 0xFFFFFF)); err != nil {
+				return err
+			}
+			return r.setCellValue(c, typ, data[8:])
+		}
+		return nil
+	})
+}
+
+// readRowHdr provides a function to read the height and visibility of the
+// BrtRowHdr record.
+func (r *xlsbReader) readRowHdr(sheet string, row int, data []byte) error {
+	if data[11]&0x20 != 0 {
+		if err := r.file.SetRowHeight(sheet, row, float64(binary.LittleEndian.Uint16(data[8:]))/20); err != nil {
+			return err
+		}
+	}
+	if data[11]&0x10 != 0 {
+		return r.file.SetRowVisible(sheet, row, false)
+	}
+	return nil
+}
+
+// readColInfo provides a function to read the BrtColInfo record.
+func (r *xlsbReader) readColInfo(sheet string, data []byte) error {
+	if len(data) < 18 {
+		return ErrWorkbookFileFormat
+	}
+	first, last := int(binary.LittleEndian.Uint32(data))+1, int(binary.LittleEndian.Uint32(data[4:]))+1
+	if last > MaxColumns {
+		last = MaxColumns
+	}
+	if first > last {
+		return nil
+	}
+	startCol, err := ColumnNumberToName(first)
+	if err != nil {
+		return err
+	}
+	endCol, _ := ColumnNumberToName(last)
+	if err = r.file.SetColWidth(sheet, startCol, endCol, float64(binary.LittleEndian.Uint32(data[8:]))/256); err != nil {
+		return err
+	}
+	if data[16]&0x01 != 0 {
+		return r.file.SetColVisible(sheet, startCol+":"+endCol, false)
+	}
+	return nil
+}
+
+// readMergeCell provides a function to read the BrtMergeCell record.
+func (r *xlsbReader) readMergeCell(sheet string, data []byte) error {
+	if len(data) < 16 {
+		return ErrWorkbookFileFormat
+	}
+	topLeftCell, err := CoordinatesToCellName(int(binary.LittleEndian.Uint32(data[8:]))+1, int(binary.LittleEndian.Uint32(data))+1)
+	if err != nil {
+		return err
+	}
+	bottomRightCell, err := CoordinatesToCellName(int(binary.LittleEndian.Uint32(data[12:]))+1, int(binary.LittleEndian.Uint32(data[4:]))+1)
+	if err != nil {
+		return err
+	}
+	return r.file.MergeCell(sheet, topLeftCell, bottomRightCell)
+}
+
+// setCellValue provides a function to set the cell value and formula by given
+// cell record type and the record data after the cell header.
+func (r *xlsbReader) setCellValue(c *xlsxC, typ int, data []byte) error {
+	var err error
+	size := map[int]int{
+		brtCellRk: 4, brtCellError: 1, brtFmlaError: 1, brtCellBool: 1, brtFmlaBool: 1,
+		brtCellReal: 8, brtFmlaNum: 8, brtCellIsst: 4,
+	}[typ]
+	if len(data) < size {
+		return ErrWorkbookFileFormat
+	}
+	switch typ {
+	case brtCellRk:
+		c.T, c.V = setCellFloat(decodeRK(binary.LittleEndian.Uint32(data)), -1, 64)
+	case brtCellError, brtFmlaError:
+		c.T, c.V = "e", biffErrors[data[0]]
+	case brtCellBool, brtFmlaBool:
+		c.T, c.V = setCellBool(data[0] != 0)
+	case brtCellReal, brtFmlaNum:
+		c.T, c.V = setCellFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)), -1, 64)
+	case brtCellSt, brtFmlaString:
+		var str string
+		if str, size, err = readXLWideString(data); err != nil {
+			return err
+		}
+		c.T, c.V = "str", str
+		if typ == brtCellSt {
+			c.T, c.V, err = r.file.setCellString(str)
+		}
+	case brtCellIsst:
+		idx := int(binary.LittleEndian.Uint32(data))
+		if idx >= len(r.sst) {
+			return ErrWorkbookFileFormat
+		}
+		c.T, c.V, err = r.file.setCellString(r.sst[idx])
+	}
+	if typ < brtFmlaString || typ > brtFmlaError || err != nil {
+		return err
+	}
+	// Skip the formula flags before the parsed formula
+	if data = data[size:]; len(data) < 6 {
+		return ErrWorkbookFileFormat
+	}
+	cce := binary.LittleEndian.Uint32(data[2:])
+	if uint64(len(data)-6) < uint64(cce) {
+		return ErrWorkbookFileFormat
+	}
+	if content, ok := r.parseFormula(data[6 : 6+cce]); ok {
+		c.F = &xlsxF{Content: content}
+	}
+	return nil
+}
+
+// getXLSBCellRef provides a function to get the cell reference by given
+// zero-based row number and the column field of the parsed formula tokens,
+// which contains the relative flags in the high bits.
+func getXLSBCellRef(row uint32, col uint16) string {
+	colName, _ := ColumnNumberToName(int(col&0x3FFF) + 1)
+	rowNum := strconv.Itoa(int(row) + 1)
+	if col&0x4000 == 0 {
+		colName = "$" + colName
+	}
+	if col&0x8000 == 0 {
+		rowNum = "$" + rowNum
+	}
+	return colName + rowNum
+}
+
+// getSheetPrefix provides a function to get the sheet name prefix of the 3D
+// reference by given index of the external sheet references.
+func (r *xlsbReader) getSheetPrefix(ixti int) (string, bool) {
+	if ixti >= len(r.externSheets) || r.externSheets[ixti] < 0 || r.externSheets[ixti] >= len(r.sheets) {
+		return "", false
+	}
+	return quoteSheetName(r.sheets[r.externSheets[ixti]].name) + "!", true
+}
+
+// parseFormula provides a function to convert the parsed formula tokens into
+// the formula text. It returns false if the formula contains unsupported
+// tokens.
+func (r *xlsbReader) parseFormula(rgce []byte) (string, bool) {
+	var stack []string
+	pop := func(n int) []string {
+		args := append([]string{}, stack[len(stack)-n:]...)
+		stack = stack[:len(stack)-n]
+		return args
+	}
+	for i := 0; i < len(rgce); {
+		ptg := rgce[i]
+		if ptg >= 0x20 {
+			ptg = ptg&0x1F | 0x20
+		}
+		size := biffTokenSizes[ptg]
+		if i+1+size > len(rgce) {
+			return "", false
+		}
+		data := rgce[i+1 : i+1+size]
+		i += 1 + size
+		if op, ok := biffOperators[ptg]; ok {
+			if len(stack) < 2 {
+				return "", false
+			}
+			args := pop(2)
+			stack = append(stack, args[0]+op+args[1])
+			continue
+		}
+		if len(stack) < 1 && (ptg == 0x12 || ptg == 0x13 || ptg == 0x14 || ptg == 0x15) {
+			return "", false
+		}
+		switch ptg {
+		case 0x12, 0x13:
+			stack[len(stack)-1] = map[byte]string{0x12: "+", 0x13: "-"}[ptg] + stack[len(stack)-1]
+		case 0x14:
+			stack[len(stack)-1] += "%"
+		case 0x15:
+			stack[len(stack)-1] = "(" + stack[len(stack)-1] + ")"
+		case 0x16:
+			stack = append(stack, "")
+		case 0x17:
+			count := int(binary.LittleEndian.Uint16(data)) * 2
+			if i+count > len(rgce) {
+				return "", false
+			}
+			str := string(utf16.Decode(decodeXLSChars(rgce[i:i+count], 2)))
+			stack, i = append(stack, "\""+strings.ReplaceAll(str, "\"", "\"\"")+"\""), i+count
+		case 0x19:
+			if data[0]&0x04 != 0 {
+				i += (int(binary.LittleEndian.Uint16(data[1:])) + 1) * 2
+			}
+			if data[0]&0x10 != 0 {
+				if len(stack) < 1 {
+					return "", false
+				}
+				stack[len(stack)-1] = "SUM(" + stack[len(stack)-1] + ")"
+			}
+		case 0x1C:
+			stack = append(stack, biffErrors[data[0]])
+		case 0x1D:
+			stack = append(stack, strings.ToUpper(strconv.FormatBool(data[0] != 0)))
+		case 0x1E:
+			stack = append(stack, strconv.Itoa(int(binary.LittleEndian.Uint16(data))))
+		case 0x1F:
+			stack = append(stack, strconv.FormatFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)), 'f', -1, 64))
+		case 0x21, 0x22:
+			args, idx := 0, int(binary.LittleEndian.Uint16(data))
+			if ptg == 0x22 {
+				args, idx = int(data[0]), int(binary.LittleEndian.Uint16(data[1:])&0x7FFF)
+			}
+			fn, ok := biffFunctions[idx]
+			if !ok || (ptg == 0x21 && fn.args < 0) {
+				return "", false
+			}
+			if ptg == 0x21 {
+				args = fn.args
+			}
+			if len(stack) < args {
+				return "", false
+			}
+			stack = append(stack, fn.name+"("+strings.Join(pop(args), ",")+")")
+		case 0x24:
+			stack = append(stack, getXLSBCellRef(binary.LittleEndian.Uint32(data), binary.LittleEndian.Uint16(data[4:])))
+		case 0x25:
+			stack = append(stack, getXLSBCellRef(binary.LittleEndian.Uint32(data), binary.LittleEndian.Uint16(data[8:]))+":"+
+				getXLSBCellRef(binary.LittleEndian.Uint32(data[4:]), binary.LittleEndian.Uint16(data[10:])))
+		case 0x26, 0x27, 0x28, 0x29:
+		case 0x2A, 0x2B:
+			stack = append(stack, formulaErrorREF)
+		case 0x3A, 0x3B, 0x3C, 0x3D:
+			prefix, ok := r.getSheetPrefix(int(binary.LittleEndian.Uint16(data)))
+			if !ok {
+				return "", false
+			}
+			ref := formulaErrorREF
+			if ptg == 0x3A {
+				ref = getXLSBCellRef(binary.LittleEndian.Uint32(data[2:]), binary.LittleEndian.Uint16(data[6:]))
+			}
+			if ptg == 0x3B {
+				ref = getXLSBCellRef(binary.LittleEndian.Uint32(data[2:]), binary.LittleEndian.Uint16(data[10:])) + ":" +
+					getXLSBCellRef(binary.LittleEndian.Uint32(data[6:]), binary.LittleEndian.Uint16(data[12:]))
+			}
+			stack = append(stack, prefix+ref)
+		default:
+			return "", false
+		}
+	}
+	if len(stack) != 1 {
+		return "", false
+	}
+	return stack[0], true
+}
@@ -0,0 +1,357 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// brtRecord provides a function to encode the BIFF12 record by given record
+// type and data.
+func brtRecord(typ int, data ...[]byte) []byte {
+	var buf []byte
+	for _, b := range data {
+		buf = append(buf, b...)
+	}
+	var record []byte
+	for _, v := range []struct{ value, size int }{{typ, 2}, {len(buf), 4}} {
+		for i := 0; i < v.size; i++ {
+			b := byte(v.value & 0x7F)
+			if v.value >>= 7; v.value > 0 {
+				b |= 0x80
+			}
+			if record = append(record, b); v.value == 0 {
+				break
+			}
+		}
+	}
+	return append(record, buf...)
+}
+
+// brtString provides a function to encode the XLWideString structure.
+func brtString(str string) []byte {
+	chars := utf16.Encode([]rune(str))
+	buf := biffUint(4, uint64(len(chars)))
+	for _, c := range chars {
+		buf = append(buf, biffUint(2, uint64(c))...)
+	}
+	return buf
+}
+
+// brtCell provides a function to encode the cell structure by given column
+// and cell format index.
+func brtCell(col, xf int) []byte {
+	return biffUint(4, uint64(col), uint64(xf))
+}
+
+// brtFormula provides a function to encode the formula flags and the parsed
+// formula by given tokens.
+func brtFormula(tokens ...[]byte) []byte {
+	var rgce []byte
+	for _, token := range tokens {
+		rgce = append(rgce, token...)
+	}
+	return append(append(biffUint(2, 0), biffUint(4, uint64(len(rgce)))...), append(rgce, biffUint(4, 0)...)...)
+}
+
+// newTestXLSB provides a function to create the Excel binary workbook by
+// given parts.
+func newTestXLSB(t *testing.T, parts map[string][]byte) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range parts {
+		fi, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = fi.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestOpenXLSB(t *testing.T) {
+	rels := `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.bin"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.bin"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.bin"/>
+<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.bin"/>
+<Relationship Id="rId5" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme" Target="theme/theme1.xml"/>
+<Relationship Id="rId6" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet" Target="chartsheets/sheet1.bin"/>
+</Relationships>`
+	var workbook []byte
+	workbook = append(workbook, brtRecord(brtWbProp, biffUint(4, 0))...)
+	workbook = append(workbook, brtRecord(brtBundleSh, biffUint(4, 0, 1), brtString("rId1"), brtString("Data"))...)
+	workbook = append(workbook, brtRecord(brtBundleSh, biffUint(4, 0, 2), brtString("rId6"), brtString("Chart"))...)
+	workbook = append(workbook, brtRecord(brtBundleSh, biffUint(4, 1, 3), brtString("rId2"), brtString("Other Sheet"))...)
+	workbook = append(workbook, brtRecord(brtExternSheet, biffUint(4, 1, 0, 2, 2))...)
+
+	sst := append(brtRecord(brtSSTItem, []byte{0}, brtString("Name")), brtRecord(brtSSTItem, []byte{0}, brtString("Café"))...)
+
+	font := func(height, flags, weight uint64, color []byte, name string) []byte {
+		return brtRecord(brtFont, biffUint(2, height, flags, weight, 0), []byte{0, 2, 0, 0}, color, []byte{0}, brtString(name))
+	}
+	border := func(left []byte) []byte {
+		none := make([]byte, 10)
+		return brtRecord(brtBorder, []byte{0}, none, none, left, none, none)
+	}
+	xf := func(numFmt, font, fill, border uint64, rotation, indent byte, flags uint64) []byte {
+		return brtRecord(brtXF, biffUint(2, 0, numFmt, font, fill, border), []byte{rotation, indent}, biffUint(2, flags), []byte{0, 0})
+	}
+	var styles []byte
+	styles = append(styles, brtRecord(brtFmt, biffUint(2, 164), brtString("0.000"))...)
+	styles = append(styles, font(220, 0, 400, []byte{3 << 1, 1, 0, 0, 0, 0, 0, 0xFF}, "Calibri")...)
+	styles = append(styles, font(240, 0x02, 700, []byte{2<<1 | 1, 0, 0, 0, 0xFF, 0, 0, 0xFF}, "Arial")...)
+	styles = append(styles, brtRecord(brtFill, biffUint(4, 0), make([]byte, 16))...)
+	styles = append(styles, brtRecord(brtFill, biffUint(4, 1), []byte{3 << 1, 4, 0, 0, 0, 0, 0, 0xFF}, make([]byte, 8))...)
+	styles = append(styles, border(make([]byte, 10))...)
+	styles = append(styles, border([]byte{1, 0, 1 << 1, 2, 0, 0, 0, 0, 0, 0xFF})...)
+	styles = append(styles, xf(0, 0, 0, 0, 0, 0, 0)...)
+	styles = append(styles, brtRecord(brtBeginCellXFs, biffUint(4, 3))...)
+	styles = append(styles, xf(0, 0, 0, 0, 0, 0, 2<<3)...)
+	styles = append(styles, xf(164, 1, 1, 1, 45, 1, 2|1<<3|0x40)...)
+	styles = append(styles, xf(14, 0, 0, 0, 0, 0, 2<<3)...)
+	styles = append(styles, brtRecord(brtEndCellXFs)...)
+
+	ptgRef := func(row, col uint64) []byte {
+		return append(append([]byte{0x44}, biffUint(4, row)...), biffUint(2, col|0xC000)...)
+	}
+	ptgInt := func(v uint64) []byte { return append([]byte{0x1E}, biffUint(2, v)...) }
+	ptgStr := func(s string) []byte {
+		return append(append([]byte{0x17}, biffUint(2, uint64(len(s)))...), brtString(s)[4:]...)
+	}
+	var sheet1 []byte
+	sheet1 = append(sheet1, brtRecord(brtColInfo, biffUint(4, 0, 1, 20*256, 0), biffUint(2, 0))...)
+	sheet1 = append(sheet1, brtRecord(brtColInfo, biffUint(4, 3, 3, 10*256, 0), biffUint(2, 1))...)
+	sheet1 = append(sheet1, brtRecord(brtRowHdr, biffUint(4, 0, 0), biffUint(2, 600), []byte{0, 0x20, 0}, biffUint(4, 0))...)
+	sheet1 = append(sheet1, brtRecord(brtCellIsst, brtCell(0, 1), biffUint(4, 0))...)
+	sheet1 = append(sheet1, brtRecord(brtCellSt, brtCell(1, 0), brtString("Text"))...)
+	sheet1 = append(sheet1, brtRecord(brtRowHdr, biffUint(4, 1, 0), biffUint(2, 300), []byte{0, 0, 0}, biffUint(4, 0))...)
+	sheet1 = append(sheet1, brtRecord(brtCellReal, brtCell(0, 0), biffUint(8, math.Float64bits(1.5)))...)
+	sheet1 = append(sheet1, brtRecord(brtCellRk, brtCell(1, 2), biffUint(4, math.Float64bits(45000)>>32))...)
+	sheet1 = append(sheet1, brtRecord(brtCellBool, brtCell(2, 0), []byte{1})...)
+	sheet1 = append(sheet1, brtRecord(brtCellError, brtCell(3, 0), []byte{0x07})...)
+	sheet1 = append(sheet1, brtRecord(brtCellBlank, brtCell(4, 1))...)
+	sheet1 = append(sheet1, brtRecord(brtRowHdr, biffUint(4, 2, 0), biffUint(2, 300), []byte{0, 0, 0}, biffUint(4, 0))...)
+	sheet1 = append(sheet1, brtRecord(brtFmlaNum, brtCell(0, 0), biffUint(8, math.Float64bits(2.5)),
+		brtFormula(ptgRef(1, 0), ptgInt(1), []byte{0x03}))...)
+	sheet1 = append(sheet1, brtRecord(brtFmlaString, brtCell(1, 0), brtString("yes"),
+		brtFormula(ptgRef(1, 0), ptgInt(0), []byte{0x0D}, []byte{0x19, 0x40, 0, 0}, ptgStr("yes"), ptgStr("n\"o"), []byte{0x42, 3, 1, 0}))...)
+	sheet1 = append(sheet1, brtRecord(brtFmlaBool, brtCell(2, 0), []byte{0},
+		brtFormula([]byte{0x3A, 0, 0, 0, 0, 0, 0, 0, 0}, ptgInt(0), []byte{0x0D}))...)
+	sheet1 = append(sheet1, brtRecord(brtFmlaError, brtCell(3, 0), []byte{0x07},
+		brtFormula(append(append([]byte{0x25}, biffUint(4, 1, 1)...), biffUint(2, 0xC000, 0xC001)...), []byte{0x19, 0x10, 0, 0}, ptgInt(0), []byte{0x06}))...)
+	sheet1 = append(sheet1, brtRecord(brtFmlaNum, brtCell(4, 0), biffUint(8, math.Float64bits(7)),
+		brtFormula([]byte{0x23, 1, 0, 0, 0}))...)
+	sheet1 = append(sheet1, brtRecord(brtFmlaNum, brtCell(5, 0), biffUint(8, math.Float64bits(1.5)),
+		brtFormula(ptgRef(1, 0), ptgInt(2), []byte{0x41, 27, 0}, []byte{0x15}, []byte{0x13}, []byte{0x14}))...)
+	sheet1 = append(sheet1, brtRecord(brtRowHdr, biffUint(4, 3, 0), biffUint(2, 300), []byte{0, 0x10, 0}, biffUint(4, 0))...)
+	sheet1 = append(sheet1, brtRecord(brtMergeCell, biffUint(4, 4, 5, 0, 1))...)
+
+	parts := map[string][]byte{
+		"xl/workbook.bin":            workbook,
+		"xl/_rels/workbook.bin.rels": []byte(rels),
+		"xl/sharedStrings.bin":       sst,
+		"xl/styles.bin":              styles,
+		"xl/theme/theme1.xml":        []byte(templateTheme),
+		"xl/worksheets/sheet1.bin":   sheet1,
+		"xl/worksheets/sheet2.bin": append(brtRecord(brtRowHdr, biffUint(4, 0, 0), biffUint(2, 300), []byte{0, 0, 0}, biffUint(4, 0)),
+			brtRecord(brtCellIsst, brtCell(0, 0), biffUint(4, 1))...),
+	}
+	f, err := OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Data", "Other Sheet"}, f.GetSheetList())
+	visible, err := f.GetSheetVisible("Other Sheet")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	rows, err := f.GetRows("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Text"},
+		{"1.5", "03-15-23", "TRUE", "#DIV/0!"},
+		{"2.5", "yes", "FALSE", "#DIV/0!", "7", "1.5"},
+	}, rows)
+	rows, err = f.GetRows("Other Sheet")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Café"}}, rows)
+	for cell, expected := range map[string]string{
+		"A3": "A2+1",
+		"B3": "IF(A2>0,\"yes\",\"n\"\"o\")",
+		"C3": "'Other Sheet'!$A$1>0",
+		"D3": "SUM(A2:B2)/0",
+		"E3": "",
+		"F3": "-(ROUND(A2,2))%",
+	} {
+		formula, err := f.GetCellFormula("Data", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	width, err := f.GetColWidth("Data", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	visible, err = f.GetColVisible("Data", "D")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	height, err := f.GetRowHeight("Data", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	visible, err = f.GetRowVisible("Data", 4)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	mergeCells, err := f.GetMergeCells("Data")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A5", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "B6", mergeCells[0].GetEndAxis())
+
+	styleID, err := f.GetCellStyle("Data", "A1")
+	assert.NoError(t, err)
+	style := f.Styles.CellXfs.Xf[styleID]
+	fnt := f.Styles.Fonts.Font[*style.FontID]
+	assert.Equal(t, "Arial", *fnt.Name.Val)
+	assert.Equal(t, 12.0, *fnt.Sz.Val)
+	assert.NotNil(t, fnt.B)
+	assert.NotNil(t, fnt.I)
+	assert.Equal(t, "FFFF0000", fnt.Color.RGB)
+	assert.Equal(t, "center", style.Alignment.Horizontal)
+	assert.Equal(t, "center", style.Alignment.Vertical)
+	assert.Equal(t, 45, style.Alignment.TextRotation)
+	assert.True(t, style.Alignment.WrapText)
+	assert.Equal(t, "FF5B9BD5", f.Styles.Fills.Fill[*style.FillID].PatternFill.FgColor.RGB)
+	bdr := f.Styles.Borders.Border[*style.BorderID]
+	assert.Equal(t, "thin", bdr.Left.Style)
+	assert.Equal(t, "FFFF0000", bdr.Left.Color.RGB)
+	numFmt, err := f.GetCellValue("Data", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Name", numFmt)
+	styleID, err = f.GetCellStyle("Data", "E2")
+	assert.NoError(t, err)
+	assert.NotZero(t, styleID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenXLSB.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test the theme colors of the styles resolved by the workbook theme
+	parts["xl/theme/theme1.xml"] = []byte(strings.ReplaceAll(templateTheme, "5B9BD5", "4472C4"))
+	for i := 0; i < 10; i++ {
+		f, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+		assert.NoError(t, err)
+		assert.Equal(t, "FF4472C4", f.Styles.Fills.Fill[*style.FillID].PatternFill.FgColor.RGB)
+		assert.NoError(t, f.Close())
+	}
+	parts["xl/theme/theme1.xml"] = []byte(templateTheme)
+
+	// Test open Excel binary workbook with invalid records
+	for name, content := range map[string][]byte{
+		"xl/workbook.bin":            brtRecord(brtWbProp),
+		"xl/sharedStrings.bin":       brtRecord(brtSSTItem),
+		"xl/styles.bin":              brtRecord(brtFont),
+		"xl/worksheets/sheet1.bin":   brtRecord(brtRowHdr),
+		"xl/worksheets/sheet2.bin":   brtRecord(brtCellReal, brtCell(0, 0)),
+		"xl/_rels/workbook.bin.rels": MacintoshCyrillicCharset,
+	} {
+		invalid := make(map[string][]byte, len(parts))
+		for k, v := range parts {
+			invalid[k] = v
+		}
+		invalid[name] = content
+		_, err = OpenReader(bytes.NewReader(newTestXLSB(t, invalid)))
+		assert.Error(t, err, name)
+	}
+	for _, content := range [][]byte{
+		brtRecord(brtBundleSh),
+		brtRecord(brtBundleSh, biffUint(4, 0, 1), biffUint(4, 10)),
+		brtRecord(brtBundleSh, biffUint(4, 0, 1), brtString("rId1"), biffUint(4, 10)),
+		brtRecord(brtExternSheet),
+		brtRecord(brtExternSheet, biffUint(4, 1)),
+		{0x80},
+		{0x01, 0x05},
+	} {
+		parts["xl/workbook.bin"] = content
+		_, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+		assert.Error(t, err)
+	}
+	parts["xl/workbook.bin"] = workbook
+	for _, content := range [][]byte{
+		brtRecord(brtFmt),
+		brtRecord(brtFmt, biffUint(2, 164), biffUint(4, 10)),
+		brtRecord(brtFill),
+		brtRecord(brtBorder),
+		brtRecord(brtFont, make([]byte, 21), biffUint(4, 10)),
+		append(brtRecord(brtBeginCellXFs), brtRecord(brtXF)...),
+	} {
+		parts["xl/styles.bin"] = content
+		_, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+		assert.Error(t, err)
+	}
+	parts["xl/styles.bin"] = styles
+	rowHdr := brtRecord(brtRowHdr, biffUint(4, 0, 0), biffUint(2, 300), []byte{0, 0, 0}, biffUint(4, 0))
+	for _, content := range [][]byte{
+		brtRecord(brtColInfo),
+		brtRecord(brtMergeCell),
+		brtRecord(brtMergeCell, biffUint(4, 0, 0, MaxColumns, 0)),
+		brtRecord(brtMergeCell, biffUint(4, 0, 0, 0, MaxColumns)),
+		brtRecord(brtCellIsst, brtCell(0, 0), biffUint(4, 10)),
+		append(rowHdr, brtRecord(brtCellSt, brtCell(0, 0), biffUint(4, 10))...),
+		append(rowHdr, brtRecord(brtCellIsst, brtCell(MaxColumns, 0), biffUint(4, 0))...),
+		append(rowHdr, brtRecord(brtCellIsst, brtCell(0, 0), biffUint(4, 10))...),
+		append(rowHdr, brtRecord(brtFmlaNum, brtCell(0, 0), biffUint(8, 0))...),
+		append(rowHdr, brtRecord(brtFmlaNum, brtCell(0, 0), biffUint(8, 0), biffUint(2, 0), biffUint(4, 10))...),
+	} {
+		parts["xl/worksheets/sheet1.bin"] = content
+		_, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+		assert.Error(t, err)
+	}
+	// Test open Excel binary workbook with out of range column information
+	parts["xl/worksheets/sheet1.bin"] = append(brtRecord(brtColInfo, biffUint(4, MaxColumns, MaxColumns+1, 0, 0), biffUint(2, 0)),
+		brtRecord(brtColInfo, biffUint(4, MaxColumns-1, math.MaxUint32-1, 10*256, 0), biffUint(2, 0))...)
+	f, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)))
+	assert.NoError(t, err)
+	width, err = f.GetColWidth("Data", "XFD")
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, width)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).Cols.Col, 1)
+	// Test open Excel binary workbook exceeds the unzip size limit
+	_, err = OpenReader(bytes.NewReader(newTestXLSB(t, parts)), Options{UnzipSizeLimit: 10})
+	assert.EqualError(t, err, newUnzipSizeLimitError(10).Error())
+}
+
+func TestParseXLSBFormula(t *testing.T) {
+	r := &xlsbReader{sheets: []xlsbSheet{{name: "Sheet1"}}, externSheets: []int{0, 5}}
+	for _, c := range []struct {
+		rgce     []byte
+		expected string
+		ok       bool
+	}{
+		{[]byte{0x1D, 1}, "TRUE", true},
+		{[]byte{0x1C, 0x2A}, "#N/A", true},
+		{append([]byte{0x1F}, biffUint(8, math.Float64bits(0.25))...), "0.25", true},
+		{[]byte{0x16, 0x1E, 1, 0, 0x22, 2, 0, 0}, "COUNT(,1)", true},
+		{append([]byte{0x19, 0x04, 1, 0}, append(biffUint(2, 0, 0), 0x1E, 1, 0)...), "1", true},
+		{[]byte{0x2A, 0, 0, 0, 0, 0, 0}, "#REF!", true},
+		{append([]byte{0x3C}, make([]byte, 8)...), "Sheet1!#REF!", true},
+		{append(append([]byte{0x3B}, make([]byte, 10)...), biffUint(2, 0, 1)...), "Sheet1!$A$1:$B$1", true},
+		{append([]byte{0x26}, append(make([]byte, 6), 0x1E, 1, 0)...), "1", true},
+		{append([]byte{0x3A, 1, 0}, make([]byte, 6)...), "", false},
+		{[]byte{0x1E, 1}, "", false},
+		{[]byte{0x03}, "", false},
+		{[]byte{0x12}, "", false},
+		{[]byte{0x19, 0x10, 0, 0}, "", false},
+		{[]byte{0x21, 0xFF, 0}, "", false},
+		{[]byte{0x21, 4, 0}, "", false},
+		{[]byte{0x21, 27, 0}, "", false},
+		{[]byte{0x17, 5, 0, 'a'}, "", false},
+		{[]byte{0x1E, 1, 0, 0x1E, 1, 0}, "", false},
+		{[]byte{0x01}, "", false},
+	} {
+		formula, ok := r.parseFormula(c.rgce)
+		assert.Equal(t, c.ok, ok)
+		assert.Equal(t, c.expected, formula)
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// Source namespaces and the media type of the OpenDocument spreadsheet.
+const (
+	NameSpaceODFOffice   = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	NameSpaceODFStyle    = "urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+	NameSpaceODFTable    = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	NameSpaceODFText     = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	NameSpaceODFFo       = "urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"
+	NameSpaceODFNumber   = "urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0"
+	NameSpaceODFOf       = "urn:oasis:names:tc:opendocument:xmlns:of:1.2"
+	NameSpaceODFManifest = "urn:oasis:names:tc:opendocument:xmlns:manifest:1.0"
+	ContentTypeODS       = "application/vnd.oasis.opendocument.spreadsheet"
+)
+
+// odsDocument directly maps the root element of the content.xml and
+// styles.xml parts of the OpenDocument spreadsheet.
+type odsDocument struct {
+	AutomaticStyles odsStyles  `xml:"automatic-styles"`
+	Styles          odsStyles  `xml:"styles"`
+	Tables          []odsTable `xml:"body>spreadsheet>table"`
+}
+
+// odsStyles directly maps the office:styles and office:automatic-styles
+// elements, which contain the styles and the data styles.
+type odsStyles struct {
+	Style      []odsStyle       `xml:"style"`
+	DataStyles []odsNumberStyle `xml:",any"`
+}
+
+// odsStyle directly maps the style:style element.
+type odsStyle struct {
+	Name                string         `xml:"name,attr"`
+	Family              string         `xml:"family,attr"`
+	ParentStyleName     string         `xml:"parent-style-name,attr"`
+	DataStyleName       string         `xml:"data-style-name,attr"`
+	TableCellProperties *odsProperties `xml:"table-cell-properties"`
+	ParagraphProperties *odsProperties `xml:"paragraph-properties"`
+	TextProperties      *odsProperties `xml:"text-properties"`
+	ColumnProperties    *odsProperties `xml:"table-column-properties"`
+	RowProperties       *odsProperties `xml:"table-row-properties"`
+	TableProperties     *odsProperties `xml:"table-properties"`
+}
+
+// odsProperties directly maps the formatting properties elements of the
+// style, such as style:table-cell-properties and style:text-properties.
+type odsProperties struct {
+	Attrs []xml.Attr `xml:",any,attr"`
+}
+
+// odsNumberStyle directly maps the data style elements, such as
+// number:number-style and number:date-style.
+type odsNumberStyle struct {
+	XMLName            xml.Name
+	Name               string          `xml:"name,attr"`
+	TruncateOnOverflow string          `xml:"truncate-on-overflow,attr"`
+	Items              []odsNumberItem `xml:",any"`
+}
+
+// odsNumberItem directly maps the child elements of the data styles, such as
+// number:number, number:text and number:year.
+type odsNumberItem struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Text    string     `xml:",chardata"`
+}
+
+// odsTable directly maps the table:table element. The columns and rows in
+// the column and row groups will be flatten.
+type odsTable struct {
+	Name      string
+	StyleName string
+	Columns   []odsColumn
+	Rows      []odsRow
+}
+
+// odsColumn directly maps the table:table-column element.
+type odsColumn struct {
+	StyleName  string `xml:"style-name,attr"`
+	Repeated   int    `xml:"number-columns-repeated,attr"`
+	Visibility string `xml:"visibility,attr"`
+}
+
+// odsRow directly maps the table:table-row element.
+type odsRow struct {
+	StyleName  string
+	Repeated   int
+	Visibility string
+	Cells      []odsCell
+}
+
+// odsCell directly maps the table:table-cell and table:covered-table-cell
+// elements.
+type odsCell struct {
+	XMLName      xml.Name
+	StyleName    string    `xml:"urn:oasis:names:tc:opendocument:xmlns:table:1.0 style-name,attr"`
+	Repeated     int       `xml:"urn:oasis:names:tc:opendocument:xmlns:table:1.0 number-columns-repeated,attr"`
+	ColsSpanned  int       `xml:"urn:oasis:names:tc:opendocument:xmlns:table:1.0 number-columns-spanned,attr"`
+	RowsSpanned  int       `xml:"urn:oasis:names:tc:opendocument:xmlns:table:1.0 number-rows-spanned,attr"`
+	Formula      string    `xml:"urn:oasis:names:tc:opendocument:xmlns:table:1.0 formula,attr"`
+	ValueType    string    `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 value-type,attr"`
+	Value        string    `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 value,attr"`
+	DateValue    string    `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 date-value,attr"`
+	TimeValue    string    `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 time-value,attr"`
+	BooleanValue string    `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 boolean-value,attr"`
+	StringValue  string    `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 string-value,attr"`
+	CalcExtType  string    `xml:"urn:org:documentfoundation:names:experimental:calc:xmlns:calcext:1.0 value-type,attr"`
+	Paragraphs   []odsText `xml:"urn:oasis:names:tc:opendocument:xmlns:text:1.0 p"`
+}
+
+// odsText directly maps the text:p element, the text of the spans, spaces,
+// tabs and line breaks will be merged into the plain text.
+type odsText struct {
+	Text string
+}
+
+// get provides a function to get the attribute value of the properties by
+// given local name.
+func (p *odsProperties) get(name string) string {
+	if p == nil {
+		return ""
+	}
+	for _, attr := range p.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// get provides a function to get the attribute value of the data style item
+// by given local name.
+func (item *odsNumberItem) get(name string) string {
+	for _, attr := range item.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// getODSAttr provides a function to get the attribute value of the element
+// by given local name.
+func getODSAttr(se xml.StartElement, name string) string {
+	for _, attr := range se.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// UnmarshalXML convert the table:table element into the odsTable, the
+// columns and rows in the groups and headers will be read in order.
+func (t *odsTable) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	t.Name, t.StyleName = getODSAttr(start, "name"), getODSAttr(start, "style-name")
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := token.(type) {
+		case xml.StartElement:
+			switch el.Name.Local {
+			case "table-column":
+				var col odsColumn
+				if err = d.DecodeElement(&col, &el); err != nil {
+					return err
+				}
+				t.Columns = append(t.Columns, col)
+			case "table-row":
+				var row odsRow
+				if err = d.DecodeElement(&row, &el); err != nil {
+					return err
+				}
+				t.Rows = append(t.Rows, row)
+			case "table-columns", "table-column-group", "table-header-columns",
+				"table-rows", "table-row-group", "table-header-rows":
+			default:
+				if err = d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if el.Name == start.Name {
+				return nil
+			}
+		}
+	}
+}
+
+// UnmarshalXML convert the table:table-row element into the odsRow.
+func (r *odsRow) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	r.StyleName, r.Visibility = getODSAttr(start, "style-name"), getODSAttr(start, "visibility")
+	r.Repeated, _ = strconv.Atoi(getODSAttr(start, "number-rows-repeated"))
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := token.(type) {
+		case xml.StartElement:
+			if el.Name.Local == "table-cell" || el.Name.Local == "covered-table-cell" {
+				var cell odsCell
+				if err = d.DecodeElement(&cell, &el); err != nil {
+					return err
+				}
+				r.Cells = append(r.Cells, cell)
+				continue
+			}
+			if err = d.Skip(); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// UnmarshalXML convert the text:p element into the plain text.
+func (t *odsText) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var buf strings.Builder
+	for depth := 1; depth > 0; {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := token.(type) {
+		case xml.CharData:
+			buf.Write(el)
+		case xml.StartElement:
+			depth++
+			switch el.Name.Local {
+			case "s":
+				count, _ := strconv.Atoi(getODSAttr(el, "c"))
+				if count < 1 {
+					count = 1
+				}
+				buf.WriteString(strings.Repeat(" ", count))
+			case "tab":
+				buf.WriteString("\t")
+			case "line-break":
+				buf.WriteString("\n")
+			case "annotation", "note":
+				if err = d.Skip(); err != nil {
+					return err
+				}
+				depth--
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+	t.Text = buf.String()
+	return nil
+}
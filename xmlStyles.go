@@ -72,7 +72,7 @@ type xlsxLine struct {
 type xlsxColor struct {
 	Auto    bool    `xml:"auto,attr,omitempty"`
 	RGB     string  `xml:"rgb,attr,omitempty"`
-	Indexed int     `xml:"indexed,attr,omitempty"`
+	Indexed *int    `xml:"indexed,attr"`
 	Theme   *int    `xml:"theme,attr"`
 	Tint    float64 `xml:"tint,attr,omitempty"`
 }
@@ -280,11 +280,21 @@ type xlsxTableStyles struct {
 // a single table style definition that indicates how a spreadsheet application
 // should format and display a table.
 type xlsxTableStyle struct {
-	Name              string `xml:"name,attr,omitempty"`
-	Pivot             int    `xml:"pivot,attr"`
-	Count             int    `xml:"count,attr,omitempty"`
-	Table             bool   `xml:"table,attr,omitempty"`
-	TableStyleElement string `xml:",innerxml"`
+	Name              string                   `xml:"name,attr,omitempty"`
+	Pivot             *bool                    `xml:"pivot,attr"`
+	Table             *bool                    `xml:"table,attr"`
+	Count             int                      `xml:"count,attr,omitempty"`
+	TableStyleElement []*xlsxTableStyleElement `xml:"tableStyleElement"`
+}
+
+// xlsxTableStyleElement directly maps the tableStyleElement element. This
+// element specifies the formatting of a particular area of the table, such as
+// the header row or the row stripes, by referencing the differential
+// formatting record.
+type xlsxTableStyleElement struct {
+	Type  string `xml:"type,attr"`
+	Size  int    `xml:"size,attr,omitempty"`
+	DxfID *int   `xml:"dxfId,attr"`
 }
 
 // xlsxNumFmts directly maps the numFmts element. This element defines the
@@ -309,7 +319,21 @@ type xlsxNumFmt struct {
 // legacy color palette has been modified (backwards compatibility settings) or
 // a custom color has been selected while using this workbook.
 type xlsxStyleColors struct {
-	Color string `xml:",innerxml"`
+	IndexedColors *xlsxIndexedColors `xml:"indexedColors"`
+	MruColors     *xlsxInnerXML      `xml:"mruColors"`
+}
+
+// xlsxIndexedColors directly maps the indexedColors element. This element
+// contains the modified legacy color palette, the sequence of RGB color values
+// correspond to the color indexes (zero-based).
+type xlsxIndexedColors struct {
+	RgbColor []xlsxRgbColor `xml:"rgbColor"`
+}
+
+// xlsxRgbColor directly maps the rgbColor element. This element is an ARGB
+// color value of the legacy color palette.
+type xlsxRgbColor struct {
+	RGB string `xml:"rgb,attr"`
 }
 
 // Alignment directly maps the alignment settings of the cells.
@@ -347,6 +371,18 @@ type Font struct {
 	VertAlign    string
 }
 
+// Color directly maps the color settings used by the fonts, fills and borders
+// of the cells. The color could be specified by the automatic color, the RGB
+// value, the theme color index with tint, or the legacy indexed color, and
+// the first specified one takes effect in that order.
+type Color struct {
+	Auto    bool
+	RGB     string
+	Indexed *int
+	Theme   *int
+	Tint    float64
+}
+
 // Fill directly maps the fill settings of the cells.
 type Fill struct {
 	Type    string
@@ -373,3 +409,11 @@ type Style struct {
 	CustomNumFmt  *string
 	NegRed        bool
 }
+
+// NamedStyle directly maps the settings of the named cell style, which could
+// be applied to the cells from the cell styles gallery of the spreadsheet
+// application.
+type NamedStyle struct {
+	Name  string
+	Style *Style
+}
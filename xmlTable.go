@@ -34,6 +34,8 @@ type xlsxTable struct {
 	Name                 string              `xml:"name,attr"`
 	Published            bool                `xml:"published,attr,omitempty"`
 	Ref                  string              `xml:"ref,attr"`
+	TableBorderDxfID     int                 `xml:"tableBorderDxfId,attr,omitempty"`
+	TotalsRowBorderDxfID int                 `xml:"totalsRowBorderDxfId,attr,omitempty"`
 	TotalsRowCount       int                 `xml:"totalsRowCount,attr,omitempty"`
 	TotalsRowDxfID       int                 `xml:"totalsRowDxfId,attr,omitempty"`
 	TotalsRowShown       bool                `xml:"totalsRowShown,attr"`
@@ -208,6 +210,22 @@ type Table struct {
 	ShowRowStripes    *bool
 }
 
+// TableStyle directly maps the settings of the custom table style.
+type TableStyle struct {
+	Name     string
+	Pivot    bool
+	Table    bool
+	Elements []TableStyleElement
+}
+
+// TableStyleElement directly maps the settings of the element of the custom
+// table style.
+type TableStyleElement struct {
+	Type   string
+	Size   int
+	Format int
+}
+
 // AutoFilterOptions directly maps the auto filter settings.
 type AutoFilterOptions struct {
 	Column     string
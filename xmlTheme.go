@@ -161,3 +161,38 @@ type xlsxSysClr struct {
 	Val     string `xml:"val,attr"`
 	LastClr string `xml:"lastClr,attr"`
 }
+
+// ThemeColors directly maps the twelve color slots of the theme color scheme.
+// Each color is given in the RRGGBB hex format, for example "4472C4".
+type ThemeColors struct {
+	Dark1             string
+	Light1            string
+	Dark2             string
+	Light2            string
+	Accent1           string
+	Accent2           string
+	Accent3           string
+	Accent4           string
+	Accent5           string
+	Accent6           string
+	Hyperlink         string
+	FollowedHyperlink string
+}
+
+// ThemeFont directly maps the typefaces of the major or minor font in the
+// theme font scheme.
+type ThemeFont struct {
+	Latin         string
+	EastAsian     string
+	ComplexScript string
+}
+
+// Theme directly maps the settings of the workbook theme.
+type Theme struct {
+	Name            string
+	ColorSchemeName string
+	Colors          ThemeColors
+	FontSchemeName  string
+	MajorFont       ThemeFont
+	MinorFont       ThemeFont
+}
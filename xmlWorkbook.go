@@ -110,6 +110,7 @@ type xlsxFileVersion struct {
 // defines a collection of workbook properties.
 type xlsxWorkbookPr struct {
 	Date1904                   bool   `xml:"date1904,attr,omitempty"`
+	DateCompatibility          *bool  `xml:"dateCompatibility,attr"`
 	ShowObjects                string `xml:"showObjects,attr,omitempty"`
 	ShowBorderUnselectedTables *bool  `xml:"showBorderUnselectedTables,attr"`
 	FilterPrivacy              bool   `xml:"filterPrivacy,attr,omitempty"`